@@ -0,0 +1,81 @@
+// asterix/asterixtest/suite.go
+
+// Package asterixtest provides helpers for testing data item
+// implementations, including items of third-party categories.
+package asterixtest
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+// RunItemSuite checks a data item implementation against encoded samples.
+// For each sample a fresh item is created by factory and must:
+//
+//   - decode the sample, consuming exactly its length
+//   - pass Validate
+//   - re-encode from its typed fields to the identical bytes, reporting the written length
+//   - produce a String (if implemented) without panicking
+//
+// Samples must therefore be in canonical form: an item that normalizes its
+// input, e.g. by dropping unused FSPEC extensions, fails on the
+// non-canonical sample, which is the point of the check. Items implementing
+// asterix.RawRetainer have their retained bytes discarded before encoding,
+// so the typed fields are what is tested. Each sample runs
+// as a subtest named after its index.
+func RunItemSuite(t *testing.T, factory func() asterix.DataItem, samples [][]byte) {
+	t.Helper()
+	for i, sample := range samples {
+		t.Run(fmt.Sprintf("sample%d", i), func(t *testing.T) {
+			if err := CheckItem(factory(), sample); err != nil {
+				t.Errorf("% X: %v", sample, err)
+			}
+		})
+	}
+}
+
+// CheckItem runs the checks of RunItemSuite for a single sample and returns
+// the first failure.
+func CheckItem(item asterix.DataItem, sample []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	n, err := item.Decode(bytes.NewBuffer(sample))
+	if err != nil {
+		return fmt.Errorf("Decode() error = %w", err)
+	}
+	if n != len(sample) {
+		return fmt.Errorf("Decode() consumed %d bytes, want %d", n, len(sample))
+	}
+
+	if err := item.Validate(); err != nil {
+		return fmt.Errorf("Validate() error = %w", err)
+	}
+
+	if rr, ok := item.(asterix.RawRetainer); ok {
+		rr.DiscardRaw()
+	}
+
+	var buf bytes.Buffer
+	n, err = item.Encode(&buf)
+	if err != nil {
+		return fmt.Errorf("Encode() error = %w", err)
+	}
+	if !bytes.Equal(buf.Bytes(), sample) {
+		return fmt.Errorf("Encode() = % X, not symmetric", buf.Bytes())
+	}
+	if n != buf.Len() {
+		return fmt.Errorf("Encode() reported %d bytes, wrote %d", n, buf.Len())
+	}
+
+	if s, ok := item.(fmt.Stringer); ok {
+		_ = s.String()
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// asterix/asterixtest/suite_test.go
+package asterixtest_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+)
+
+// octet is a one-byte item; mask selects the bits it keeps on decode
+type octet struct {
+	value  byte
+	mask   byte
+	panics bool
+}
+
+func (o *octet) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	o.value = b & o.mask
+	return 1, nil
+}
+
+func (o *octet) Encode(buf *bytes.Buffer) (int, error) {
+	return 1, buf.WriteByte(o.value)
+}
+
+func (o *octet) Validate() error { return nil }
+
+func (o *octet) String() string {
+	if o.panics {
+		panic("broken String")
+	}
+	return "octet"
+}
+
+func TestRunItemSuite(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &octet{mask: 0xFF} },
+		[][]byte{{0x00}, {0x7F}, {0xFF}})
+}
+
+func TestCheckItem(t *testing.T) {
+	tests := []struct {
+		name   string
+		item   asterix.DataItem
+		sample []byte
+		want   string
+	}{
+		{"symmetric", &octet{mask: 0xFF}, []byte{0xA5}, ""},
+		{"lossy", &octet{mask: 0xF0}, []byte{0xA5}, "not symmetric"},
+		{"trailing bytes", &octet{mask: 0xFF}, []byte{0xA5, 0x00}, "consumed 1 bytes, want 2"},
+		{"empty", &octet{mask: 0xFF}, nil, "Decode() error"},
+		{"String panics", &octet{mask: 0xFF, panics: true}, []byte{0xA5}, "panic: broken String"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := asterixtest.CheckItem(tt.item, tt.sample)
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("CheckItem() error = %v", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("CheckItem() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// asterix/bits.go
+package asterix
+
+// SignExtend interprets the low bits of value as a two's complement number
+// of the given width (1 to 32 bits) and returns it sign-extended.
+func SignExtend(value uint32, bits uint) int32 {
+	shift := 32 - bits
+	return int32(value<<shift) >> shift
+}
@@ -0,0 +1,30 @@
+// asterix/bits_test.go
+package asterix_test
+
+import (
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+func TestSignExtend(t *testing.T) {
+	tests := []struct {
+		value uint32
+		bits  uint
+		want  int32
+	}{
+		{0x7FFF, 16, 32767},
+		{0x8000, 16, -32768},
+		{0xFFFF, 16, -1},
+		{0x1FFFF, 16, -1}, // Bits above the width are ignored
+		{0x2000, 14, -8192},
+		{0x800000, 24, -8388608},
+		{0x80000000, 32, -2147483648},
+		{1, 1, -1},
+	}
+	for _, tt := range tests {
+		if got := asterix.SignExtend(tt.value, tt.bits); got != tt.want {
+			t.Errorf("SignExtend(%#x, %d) = %d, want %d", tt.value, tt.bits, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// asterix/block_builder.go
+package asterix
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentBlockBuilder collects records from many goroutines into a single
+// data block. Records are spread round-robin over independently locked
+// shards, so producers rarely contend with each other.
+//
+// Records are not ordered: the block produced by Finalize contains every
+// accepted record, but records from different producers appear in arbitrary
+// order. Records submitted by a single goroutine also lose their relative
+// order once there is more than one shard. Producers that need a defined order
+// must carry a sequence in the records and sort them afterwards.
+type ConcurrentBlockBuilder struct {
+	category Category
+	uap      UAP
+	shards   []blockShard
+	next     atomic.Uint64
+
+	errMu sync.Mutex
+	err   error // First rejected record since the last Finalize
+}
+
+// blockShard is padded to a cache line so neighbouring shards do not share one
+type blockShard struct {
+	mu      sync.Mutex
+	records []*Record
+	_       [32]byte
+}
+
+// NewConcurrentBlockBuilder creates a builder for blocks of the given
+// category. shards <= 0 selects one shard per available CPU.
+func NewConcurrentBlockBuilder(category Category, uap UAP, shards int) (*ConcurrentBlockBuilder, error) {
+	// Reuse the block constructor's checks on category and UAP
+	if _, err := NewDataBlock(category, uap); err != nil {
+		return nil, err
+	}
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+
+	return &ConcurrentBlockBuilder{
+		category: category,
+		uap:      uap,
+		shards:   make([]blockShard, shards),
+	}, nil
+}
+
+// Add submits a record. It is safe to call from multiple goroutines. A record
+// of the wrong category is rejected and the error is also reported by the
+// next Finalize.
+func (b *ConcurrentBlockBuilder) Add(record *Record) error {
+	if record == nil {
+		return fmt.Errorf("%w: record cannot be nil", ErrInvalidMessage)
+	}
+	if record.category != b.category {
+		err := fmt.Errorf("%w: record category %d does not match block category %d",
+			ErrInvalidCategory, record.category, b.category)
+		b.errMu.Lock()
+		if b.err == nil {
+			b.err = err
+		}
+		b.errMu.Unlock()
+		return err
+	}
+
+	shard := &b.shards[b.next.Add(1)%uint64(len(b.shards))]
+	shard.mu.Lock()
+	shard.records = append(shard.records, record)
+	shard.mu.Unlock()
+	return nil
+}
+
+// Finalize returns a data block with every record accepted so far and resets
+// the builder for reuse. If any record was rejected since the previous
+// Finalize, the error is returned instead and the accepted records are
+// discarded. Records added concurrently with Finalize end up in either this
+// block or the next one.
+func (b *ConcurrentBlockBuilder) Finalize() (*DataBlock, error) {
+	block, err := NewDataBlock(b.category, b.uap)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range b.shards {
+		shard := &b.shards[i]
+		shard.mu.Lock()
+		block.records = append(block.records, shard.records...)
+		shard.records = nil
+		shard.mu.Unlock()
+	}
+
+	b.errMu.Lock()
+	err = b.err
+	b.err = nil
+	b.errMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("building block: %w", err)
+	}
+
+	return block, nil
+}
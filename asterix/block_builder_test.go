@@ -0,0 +1,85 @@
+// asterix/block_builder_test.go
+package asterix_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat048"
+)
+
+func TestConcurrentBlockBuilder(t *testing.T) {
+	uap := newCat021UAP(t)
+	builder, err := asterix.NewConcurrentBlockBuilder(asterix.Cat021, uap, 4)
+	if err != nil {
+		t.Fatalf("NewConcurrentBlockBuilder() error = %v", err)
+	}
+
+	const producers, perProducer = 8, 500
+	records := make([][]*asterix.Record, producers)
+	for p := range records {
+		for i := 0; i < perProducer; i++ {
+			records[p] = append(records[p], newCat021Record(t, uap, 25, uint8(p+1), uint32(i)))
+		}
+	}
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for _, record := range records[p] {
+				if err := builder.Add(record); err != nil {
+					t.Errorf("Add() error = %v", err)
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	block, err := builder.Finalize()
+	if err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+	if block.Length() != producers*perProducer {
+		t.Errorf("Length() = %d, want %d", block.Length(), producers*perProducer)
+	}
+	if _, err := block.Encode(); err != nil {
+		t.Errorf("Encode() error = %v", err)
+	}
+
+	// Finalize drained the builder
+	empty, err := builder.Finalize()
+	if err != nil || empty.Length() != 0 {
+		t.Errorf("second Finalize() = %d records, %v; want 0, nil", empty.Length(), err)
+	}
+}
+
+func TestConcurrentBlockBuilder_CategoryMismatch(t *testing.T) {
+	uap := newCat021UAP(t)
+	builder, err := asterix.NewConcurrentBlockBuilder(asterix.Cat021, uap, 0)
+	if err != nil {
+		t.Fatalf("NewConcurrentBlockBuilder() error = %v", err)
+	}
+
+	uap048, err := cat048.NewUAP(cat048.Version132)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	foreign, err := asterix.NewRecord(asterix.Cat048, uap048)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+
+	if err := builder.Add(newCat021Record(t, uap, 25, 1, 1)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := builder.Add(foreign); !errors.Is(err, asterix.ErrInvalidCategory) {
+		t.Errorf("Add(Cat048) error = %v, want %v", err, asterix.ErrInvalidCategory)
+	}
+	if _, err := builder.Finalize(); !errors.Is(err, asterix.ErrInvalidCategory) {
+		t.Errorf("Finalize() error = %v, want %v", err, asterix.ErrInvalidCategory)
+	}
+}
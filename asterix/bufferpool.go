@@ -0,0 +1,136 @@
+// asterix/bufferpool.go
+package asterix
+
+import (
+	"bytes"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// BufferPool recycles the scratch buffers used to encode records and blocks
+// and to read the blocks of a stream. It is safe for concurrent use. Get and
+// Put do not allocate once the pool holds enough buffers.
+type BufferPool struct {
+	pool sync.Pool
+
+	gets atomic.Uint64
+	puts atomic.Uint64
+	news atomic.Uint64
+
+	maxCap int // Set by WithMaxBufferCap
+
+	// Set by NewBufferPoolWithTracking
+	tracking    bool
+	outstanding atomic.Int64
+	stacks      sync.Map // *bytes.Buffer -> stack of the Get, see captureStacks
+}
+
+// BufferPoolOption configures a BufferPool
+type BufferPoolOption func(*BufferPool)
+
+// WithMaxBufferCap makes Put drop buffers whose capacity exceeds n bytes
+// instead of keeping them, so that a single oversized block does not hold
+// on to a huge buffer for the life of the pool. By default, or with n of 0
+// or less, the capacity is unlimited.
+func WithMaxBufferCap(n int) BufferPoolOption {
+	return func(p *BufferPool) {
+		p.maxCap = n
+	}
+}
+
+// NewBufferPool creates an empty buffer pool
+func NewBufferPool(opts ...BufferPoolOption) *BufferPool {
+	return NewBufferPoolWithSize(0, opts...)
+}
+
+// NewBufferPoolWithSize creates an empty buffer pool whose new buffers start
+// with a capacity of initial bytes, so that encoding large blocks, such as
+// Cat062 blocks of many tracks, does not grow and copy them repeatedly.
+// A size of 0 or less leaves new buffers empty, as NewBufferPool does.
+func NewBufferPoolWithSize(initial int, opts ...BufferPoolOption) *BufferPool {
+	p := &BufferPool{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.pool.New = func() any {
+		p.news.Add(1)
+		if initial <= 0 {
+			return new(bytes.Buffer)
+		}
+		return bytes.NewBuffer(make([]byte, 0, initial))
+	}
+	return p
+}
+
+// Stats returns the number of calls to Get and Put and the number of buffers
+// the pool had to allocate because none was free
+func (p *BufferPool) Stats() (gets, puts, news uint64) {
+	return p.gets.Load(), p.puts.Load(), p.news.Load()
+}
+
+// NewBufferPoolWithTracking creates a buffer pool that counts the buffers
+// taken by Get and not yet given back by Put, to find leaks in load tests.
+// Built with the gobelix_poolstacks tag, it also records the stack of every
+// outstanding Get for OutstandingStacks. Tracking costs an atomic operation
+// per call, and the stacks an allocation per Get.
+func NewBufferPoolWithTracking(opts ...BufferPoolOption) *BufferPool {
+	p := NewBufferPool(opts...)
+	p.tracking = true
+	return p
+}
+
+// Get returns an empty buffer from the pool
+func (p *BufferPool) Get() *bytes.Buffer {
+	p.gets.Add(1)
+	buf := p.pool.Get().(*bytes.Buffer)
+	buf.Reset()
+	if p.tracking {
+		p.outstanding.Add(1)
+		if captureStacks {
+			p.stacks.Store(buf, string(debug.Stack()))
+		}
+	}
+	return buf
+}
+
+// Put returns a buffer to the pool, or drops it if it is larger than
+// WithMaxBufferCap allows. The buffer must not be used afterwards.
+func (p *BufferPool) Put(buf *bytes.Buffer) {
+	if buf == nil {
+		return
+	}
+	p.puts.Add(1)
+	if p.tracking {
+		p.outstanding.Add(-1)
+		if captureStacks {
+			p.stacks.Delete(buf)
+		}
+	}
+	if p.maxCap > 0 && buf.Cap() > p.maxCap {
+		return
+	}
+	p.pool.Put(buf)
+}
+
+// OutstandingCount returns the number of buffers taken by Get and not given
+// back by Put. It is always 0 unless the pool was created with
+// NewBufferPoolWithTracking.
+func (p *BufferPool) OutstandingCount() int64 {
+	return p.outstanding.Load()
+}
+
+// OutstandingStacks returns the stack traces of the Get calls whose buffers
+// are outstanding. It returns nil unless the pool tracks allocations and the
+// package was built with the gobelix_poolstacks tag.
+func (p *BufferPool) OutstandingStacks() []string {
+	var stacks []string
+	p.stacks.Range(func(_, stack any) bool {
+		stacks = append(stacks, stack.(string))
+		return true
+	})
+	return stacks
+}
+
+// scratchBuffers holds the buffers the package uses internally
+var scratchBuffers = NewBufferPool()
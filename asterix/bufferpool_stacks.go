@@ -0,0 +1,8 @@
+// asterix/bufferpool_stacks.go
+
+//go:build gobelix_poolstacks
+
+package asterix
+
+// captureStacks makes tracking buffer pools record the stack of every Get
+const captureStacks = true
@@ -0,0 +1,179 @@
+// asterix/bufferpool_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+func TestBufferPool_Tracking(t *testing.T) {
+	pool := asterix.NewBufferPoolWithTracking()
+
+	var bufs []*bytes.Buffer
+	for i := 0; i < 3; i++ {
+		buf := pool.Get()
+		buf.WriteString("leak")
+		bufs = append(bufs, buf)
+	}
+	if got := pool.OutstandingCount(); got != 3 {
+		t.Fatalf("OutstandingCount() = %d, want 3", got)
+	}
+	// Stacks are only captured with the gobelix_poolstacks build tag
+	if stacks := pool.OutstandingStacks(); stacks != nil && len(stacks) != 3 {
+		t.Errorf("OutstandingStacks() = %d stacks, want 3", len(stacks))
+	}
+
+	for _, buf := range bufs {
+		pool.Put(buf)
+	}
+	if got := pool.OutstandingCount(); got != 0 {
+		t.Errorf("OutstandingCount() after Put = %d, want 0", got)
+	}
+	if stacks := pool.OutstandingStacks(); len(stacks) != 0 {
+		t.Errorf("OutstandingStacks() after Put = %d stacks, want none", len(stacks))
+	}
+
+	// Recycled buffers come back empty
+	buf := pool.Get()
+	if buf.Len() != 0 {
+		t.Errorf("Get() returned a buffer holding %q", buf.String())
+	}
+	pool.Put(buf)
+}
+
+func TestBufferPool_NoTrackingAllocs(t *testing.T) {
+	pool := asterix.NewBufferPool()
+	pool.Put(pool.Get())
+
+	allocs := testing.AllocsPerRun(100, func() {
+		pool.Put(pool.Get())
+	})
+	if allocs != 0 {
+		t.Errorf("Get/Put allocated %v times per run, want 0", allocs)
+	}
+	if got := pool.OutstandingCount(); got != 0 {
+		t.Errorf("OutstandingCount() without tracking = %d, want 0", got)
+	}
+}
+
+func TestBufferPool_Stats(t *testing.T) {
+	pool := asterix.NewBufferPoolWithSize(4096)
+
+	a, b := pool.Get(), pool.Get()
+	if a.Cap() < 4096 {
+		t.Errorf("Get() capacity = %d, want at least 4096", a.Cap())
+	}
+	pool.Put(a)
+	pool.Put(b)
+	pool.Put(nil)
+	if gets, puts, news := pool.Stats(); gets != 2 || puts != 2 || news != 2 {
+		t.Errorf("Stats() = %d gets, %d puts, %d news, want 2, 2, 2", gets, puts, news)
+	}
+
+	// A recycled buffer does not count as new; sync.Pool may still drop
+	// buffers, so only the upper bound is exact
+	pool.Put(pool.Get())
+	if gets, puts, news := pool.Stats(); gets != 3 || puts != 3 || news > 3 {
+		t.Errorf("Stats() = %d gets, %d puts, %d news, want 3, 3 and at most 3", gets, puts, news)
+	}
+}
+
+// BenchmarkBufferPool_LargeBlock encodes a block of 200 Cat062-sized records
+// into a buffer from a cold pool, as after a GC has emptied it
+func BenchmarkBufferPool_LargeBlock(b *testing.B) {
+	record := bytes.Repeat([]byte{0xA5}, 60)
+	for _, bench := range []struct {
+		name    string
+		newPool func() *asterix.BufferPool
+	}{
+		{"default", func() *asterix.BufferPool { return asterix.NewBufferPool() }},
+		{"presized", func() *asterix.BufferPool { return asterix.NewBufferPoolWithSize(16 * 1024) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				pool := bench.newPool()
+				buf := pool.Get()
+				for r := 0; r < 200; r++ {
+					buf.Write(record)
+				}
+				pool.Put(buf)
+			}
+		})
+	}
+}
+
+func TestBufferPool_MaxBufferCap(t *testing.T) {
+	pool := asterix.NewBufferPool(asterix.WithMaxBufferCap(1024))
+
+	big := pool.Get()
+	big.Write(make([]byte, 64*1024))
+	pool.Put(big)
+
+	buf := pool.Get()
+	if buf == big || buf.Cap() > 1024 {
+		t.Errorf("Get() after an over-cap Put returned a buffer of capacity %d, want a fresh small one", buf.Cap())
+	}
+	if gets, puts, news := pool.Stats(); gets != 2 || puts != 1 || news != 2 {
+		t.Errorf("Stats() = %d gets, %d puts, %d news, want 2, 1, 2", gets, puts, news)
+	}
+}
+
+func TestBufferPool_EncodeAndStream(t *testing.T) {
+	uap := newCat021UAP(t)
+	pool := asterix.NewBufferPoolWithTracking()
+
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	block.SetBufferPool(pool)
+	for i := uint32(0); i < 3; i++ {
+		if err := block.AddRecord(newCat021Record(t, uap, 25, 100, 0x3C6586+i)); err != nil {
+			t.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+
+	// Returned blocks are copies, not the pooled buffers
+	first, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := bytes.Clone(first)
+	if _, err := block.EncodeChunked(0); err != nil {
+		t.Fatalf("EncodeChunked() error = %v", err)
+	}
+	separate, err := block.EncodeSeparate()
+	if err != nil {
+		t.Fatalf("EncodeSeparate() error = %v", err)
+	}
+	if !bytes.Equal(first, want) {
+		t.Errorf("Encode() result changed after later encodes")
+	}
+	if len(separate) != 3 || bytes.Equal(separate[0], separate[2]) {
+		t.Errorf("EncodeSeparate() = % X, want 3 distinct blocks", separate)
+	}
+
+	dec, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(uap), asterix.WithDecoderBufferPool(pool))
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+	stream := append(append([]byte(nil), first...), separate[1]...)
+	var records int
+	err = dec.StreamDecode(bytes.NewReader(stream), func(b *asterix.DataBlock) error {
+		records += b.Length()
+		return nil
+	})
+	if err != nil || records != 4 {
+		t.Errorf("StreamDecode() = %d records, error %v, want 4 records", records, err)
+	}
+
+	if gets, _, _ := pool.Stats(); gets != 5 {
+		t.Errorf("Stats() = %d gets, want 5: one per Encode and EncodeSeparate, two for EncodeChunked, one for the stream", gets)
+	}
+	if got := pool.OutstandingCount(); got != 0 {
+		t.Errorf("OutstandingCount() = %d, want 0", got)
+	}
+}
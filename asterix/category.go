@@ -8,10 +8,18 @@ type Category uint8
 
 // Define known categories
 const (
+	Cat001 Category = 1
+	Cat002 Category = 2
+	Cat004 Category = 4
+	Cat020 Category = 20
 	Cat021 Category = 21
+	Cat023 Category = 23
+	Cat030 Category = 30
+	Cat034 Category = 34
 	Cat048 Category = 48
 	Cat062 Category = 62
 	Cat063 Category = 63
+	Cat065 Category = 65
 )
 
 func (c Category) String() string {
@@ -20,9 +28,17 @@ func (c Category) String() string {
 
 func (c Category) IsValid() bool {
 	switch c {
-	case Cat021, Cat048, Cat062, Cat063:
+	case Cat001, Cat002, Cat004, Cat020, Cat021, Cat023, Cat030, Cat034, Cat048, Cat062, Cat063, Cat065:
 		return true
 	default:
 		return false
 	}
 }
+
+// IsReserved reports whether c is a reserved category byte that no
+// application may use. Category 000 is not assigned by EUROCONTROL, so a
+// block starting with a zero byte is taken as misframed data rather than as
+// a block of an unsupported category.
+func (c Category) IsReserved() bool {
+	return c == 0
+}
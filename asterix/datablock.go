@@ -4,15 +4,21 @@ package asterix
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 )
 
+// MaxBlockLength is the largest data block the 16-bit length field can describe
+const MaxBlockLength = 0xFFFF
+
 // DataBlock represents a complete ASTERIX message
 type DataBlock struct {
-	category Category
-	records  []*Record
-	uap      UAP
+	category   Category
+	records    []*Record
+	uap        UAP
+	maxFXDepth int         // Set by SetMaxFXDepth, 0 for DefaultMaxFXDepth
+	buffers    *BufferPool // Set by SetBufferPool, nil for the package's pool
 }
 
 // NewDataBlock creates a new ASTERIX data block
@@ -35,6 +41,30 @@ func NewDataBlock(category Category, uap UAP) (*DataBlock, error) {
 	}, nil
 }
 
+// SetMaxFXDepth sets the maximum number of octets Decode accepts in the FX
+// chain of an extended item, the primary subfield of a compound item or any
+// FX chain an FXDepthLimiter item reads, like WithDecoderMaxDepth does for a
+// Decoder. A value of 0 or less restores DefaultMaxFXDepth.
+func (db *DataBlock) SetMaxFXDepth(octets int) {
+	db.maxFXDepth = max(octets, 0)
+}
+
+// SetBufferPool makes the encode methods take their scratch buffers from
+// pool instead of the package's own pool. The blocks they return are copied
+// out of the buffers, which go back to the pool before returning. A nil pool
+// restores the default.
+func (db *DataBlock) SetBufferPool(pool *BufferPool) {
+	db.buffers = pool
+}
+
+// bufferPool returns the pool set by SetBufferPool, or the package's pool
+func (db *DataBlock) bufferPool() *BufferPool {
+	if db.buffers != nil {
+		return db.buffers
+	}
+	return scratchBuffers
+}
+
 // AddRecord adds a record to the data block
 func (db *DataBlock) AddRecord(record *Record) error {
 	if record == nil {
@@ -49,14 +79,68 @@ func (db *DataBlock) AddRecord(record *Record) error {
 	return nil
 }
 
+// Merge appends copies of the records of other, which must have the same
+// category and a UAP of the same version. Merging into a block whose UAP is
+// not blockable fails if the result would hold more than one record. The
+// records are copied by encoding and decoding them, so other stays
+// independent of db; if any record fails to copy, db is left unchanged.
+func (db *DataBlock) Merge(other *DataBlock) error {
+	if other == nil {
+		return fmt.Errorf("%w: block cannot be nil", ErrInvalidMessage)
+	}
+	if other.category != db.category {
+		return fmt.Errorf("%w: block category %d does not match block category %d",
+			ErrInvalidCategory, other.category, db.category)
+	}
+	if other.uap.Version() != db.uap.Version() {
+		return fmt.Errorf("%w: UAP version %s does not match UAP version %s",
+			ErrInvalidMessage, other.uap.Version(), db.uap.Version())
+	}
+	if total := len(db.records) + len(other.records); total > 1 && !isBlockable(db.uap) {
+		return fmt.Errorf("%w: category %d is not blockable, merge would give %d records",
+			ErrInvalidMessage, db.category, total)
+	}
+
+	copies := make([]*Record, 0, len(other.records))
+	for i, record := range other.records {
+		c, err := record.clone()
+		if err != nil {
+			return fmt.Errorf("copying record %d: %w", i, err)
+		}
+		copies = append(copies, c)
+	}
+	db.records = append(db.records, copies...)
+	return nil
+}
+
 // Records returns all records in the data block
 func (db *DataBlock) Records() []*Record {
 	return db.records
 }
 
-// Encode serializes the data block according to ASTERIX specification
+// Equal reports whether both blocks have the same category and the same
+// records in the same order, compared with Record.Equal
+func (db *DataBlock) Equal(other *DataBlock) bool {
+	if db == nil || other == nil {
+		return db == other
+	}
+	if db.category != other.category || len(db.records) != len(other.records) {
+		return false
+	}
+	for i, record := range db.records {
+		if !record.Equal(other.records[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Encode serializes the data block according to ASTERIX specification.
+// A block without records encodes to the 3-byte header only.
 func (db *DataBlock) Encode() ([]byte, error) {
-	buf := new(bytes.Buffer)
+	pool := db.bufferPool()
+	buf := pool.Get()
+	defer pool.Put(buf)
 
 	// Write category
 	if err := buf.WriteByte(byte(db.category)); err != nil {
@@ -76,11 +160,102 @@ func (db *DataBlock) Encode() ([]byte, error) {
 		}
 	}
 
-	// Update length
+	// The length field is 16 bits wide; never emit a wrapped value
 	data := buf.Bytes()
+	if len(data) > MaxBlockLength {
+		return nil, fmt.Errorf("%w: encoded block is %d bytes, exceeds maximum of %d (use EncodeChunked)",
+			ErrInvalidLength, len(data), MaxBlockLength)
+	}
 	binary.BigEndian.PutUint16(data[1:3], uint16(len(data)))
 
-	return data, nil
+	return bytes.Clone(data), nil
+}
+
+// EncodeTo encodes the data block and writes it to w in a single Write
+func (db *DataBlock) EncodeTo(w io.Writer) (int, error) {
+	data, err := db.Encode()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	if err != nil {
+		return n, fmt.Errorf("writing data block: %w", err)
+	}
+	return n, nil
+}
+
+// EncodeChunked serializes the records into as many data blocks as needed so
+// that none exceeds maxLength bytes. A maxLength of 0 or above MaxBlockLength
+// selects MaxBlockLength. Record order is preserved.
+func (db *DataBlock) EncodeChunked(maxLength int) ([][]byte, error) {
+	if maxLength <= 0 || maxLength > MaxBlockLength {
+		maxLength = MaxBlockLength
+	}
+
+	pool := db.bufferPool()
+	buf, record := pool.Get(), pool.Get()
+	defer pool.Put(buf)
+	defer pool.Put(record)
+
+	var blocks [][]byte
+	start := func() {
+		buf.Reset()
+		buf.WriteByte(byte(db.category))
+		buf.Write([]byte{0, 0})
+	}
+	flush := func() {
+		data := buf.Bytes()
+		binary.BigEndian.PutUint16(data[1:3], uint16(len(data)))
+		blocks = append(blocks, bytes.Clone(data))
+	}
+
+	start()
+	for i, r := range db.records {
+		record.Reset()
+		if _, err := r.Encode(record); err != nil {
+			return nil, fmt.Errorf("encoding record %d: %w", i, err)
+		}
+		if 3+record.Len() > maxLength {
+			return nil, fmt.Errorf("%w: record %d is %d bytes, does not fit in a block of %d",
+				ErrInvalidLength, i, record.Len(), maxLength)
+		}
+		if buf.Len()+record.Len() > maxLength {
+			flush()
+			start()
+		}
+		buf.Write(record.Bytes())
+	}
+	flush()
+
+	return blocks, nil
+}
+
+// EncodeSeparate serializes every record into a standalone data block of
+// its own, for transports and receivers that expect single-record blocks.
+// Record order is preserved; a block without records yields no blocks.
+func (db *DataBlock) EncodeSeparate() ([][]byte, error) {
+	pool := db.bufferPool()
+	buf := pool.Get()
+	defer pool.Put(buf)
+
+	blocks := make([][]byte, 0, len(db.records))
+	for i, r := range db.records {
+		buf.Reset()
+		buf.WriteByte(byte(db.category))
+		buf.Write([]byte{0, 0})
+		if _, err := r.Encode(buf); err != nil {
+			return nil, fmt.Errorf("encoding record %d: %w", i, err)
+		}
+
+		data := buf.Bytes()
+		if len(data) > MaxBlockLength {
+			return nil, fmt.Errorf("%w: record %d encodes to a block of %d bytes, exceeds maximum of %d",
+				ErrInvalidLength, i, len(data), MaxBlockLength)
+		}
+		binary.BigEndian.PutUint16(data[1:3], uint16(len(data)))
+		blocks = append(blocks, bytes.Clone(data))
+	}
+	return blocks, nil
 }
 
 // Decode parses a complete ASTERIX data block
@@ -98,6 +273,10 @@ func (db *DataBlock) Decode(data []byte) error {
 
 	// Check length
 	length := binary.BigEndian.Uint16(data[1:3])
+	if length < 3 {
+		return fmt.Errorf("%w: declared length %d is shorter than the header",
+			ErrInvalidLength, length)
+	}
 	if int(length) != len(data) {
 		return fmt.Errorf("%w: expected %d, got %d",
 			ErrInvalidLength, length, len(data))
@@ -106,8 +285,15 @@ func (db *DataBlock) Decode(data []byte) error {
 	// Clear existing records
 	db.records = db.records[:0]
 
+	// A header-only block is a legal empty block (used as keepalive by some systems)
+	if length == 3 {
+		return nil
+	}
+
 	// Read records
-	buf := bytes.NewBuffer(data[3:]) // Skip CAT/LEN
+	body := data[3:] // Skip CAT/LEN
+	buf := bytes.NewBuffer(body)
+	consumed := 0
 	for buf.Len() > 0 {
 		// Check if there's enough data for at least an FSPEC byte
 		if buf.Len() == 0 {
@@ -118,23 +304,76 @@ func (db *DataBlock) Decode(data []byte) error {
 		if err != nil {
 			return fmt.Errorf("creating record: %w", err)
 		}
+		record.SetMaxFXDepth(db.maxFXDepth)
 
 		// Try to decode the record
-		_, err = record.Decode(buf)
+		remaining := buf.Len()
+		n, err := record.Decode(buf)
 		if err != nil {
 			// If we hit EOF while processing the last record, we can ignore it
 			if err == io.EOF && buf.Len() == 0 {
 				break
 			}
-			return fmt.Errorf("decoding record: %w", err)
+			var de *DecodeError
+			if errors.As(err, &de) {
+				de.WithRecord(len(db.records)).WithPosition(3+consumed+de.Position, len(data))
+			}
+			// Bytes that cannot start a record after at least one good one
+			// are most likely left over by an item decoder that consumed
+			// less than it should have
+			if len(db.records) > 0 && trailing(body[consumed:]) {
+				return fmt.Errorf("%w: %d of %d body bytes not consumed by %d records: %w",
+					ErrTrailingBytes, remaining, len(body), len(db.records), err)
+			}
+			return fmt.Errorf("decoding record %d: %w", len(db.records), err)
 		}
 
+		// Item decoders must report exactly what they took from the buffer
+		if taken := remaining - buf.Len(); n != taken {
+			return fmt.Errorf("%w: record %d reported %d bytes but consumed %d",
+				ErrInvalidLength, len(db.records), n, taken)
+		}
+		consumed += n
+
 		db.records = append(db.records, record)
 	}
 
 	return nil
 }
 
+// trailing reports whether the bytes left in a block body cannot start a
+// record: zero padding, whose FSPEC marks no items, or an FSPEC whose FX
+// chain runs past the end of the body
+func trailing(rest []byte) bool {
+	padding, open := true, true
+	for _, b := range rest {
+		padding = padding && b == 0
+		open = open && b&0x01 != 0
+	}
+	return padding || open
+}
+
+// Validate checks every record in the data block and that the records
+// belong to the block's category. A block whose UAP is not blockable must
+// not hold more than one record. The first failure is returned with the
+// index of the offending record.
+func (db *DataBlock) Validate() error {
+	for i, record := range db.records {
+		if record.category != db.category {
+			return fmt.Errorf("record %d: %w: record category %d does not match block category %d",
+				i, ErrInvalidCategory, record.category, db.category)
+		}
+		if i > 0 && !isBlockable(db.uap) {
+			return fmt.Errorf("record %d: %w: category %d is not blockable, block holds %d records",
+				i, ErrInvalidMessage, db.category, len(db.records))
+		}
+		if err := record.Validate(); err != nil {
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 // Clear removes all records from the data block
 func (db *DataBlock) Clear() {
 	db.records = db.records[:0]
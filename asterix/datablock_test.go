@@ -0,0 +1,396 @@
+// asterix/datablock_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat021"
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	"github.com/davidkohl/gobelix/cat/cat048"
+	"github.com/davidkohl/gobelix/cat/cat062"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestDataBlock_EmptyBlockRoundTrip(t *testing.T) {
+	uap, err := cat021.NewUAP(cat021.Version26)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	expected := []byte{21, 0x00, 0x03}
+	if !bytes.Equal(encoded, expected) {
+		t.Fatalf("Encode() = % X, want % X", encoded, expected)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if decoded.Length() != 0 {
+		t.Errorf("Decode() records = %d, want 0", decoded.Length())
+	}
+
+	decoder, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	msg, err := decoder.Decode(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	if msg.Category != asterix.Cat021 {
+		t.Errorf("Decoder.Decode() category = %v, want %v", msg.Category, asterix.Cat021)
+	}
+	if msg.GetRecordCount() != 0 {
+		t.Errorf("Decoder.Decode() records = %d, want 0", msg.GetRecordCount())
+	}
+}
+
+func TestDataBlock_RejectsShortLength(t *testing.T) {
+	uap, err := cat021.NewUAP(cat021.Version26)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+
+	if err := block.Decode([]byte{21, 0x00, 0x02}); !errors.Is(err, asterix.ErrInvalidLength) {
+		t.Errorf("Decode() error = %v, want %v", err, asterix.ErrInvalidLength)
+	}
+	if err := block.Decode([]byte{21, 0x00}); !errors.Is(err, asterix.ErrInvalidMessage) {
+		t.Errorf("Decode() error = %v, want %v", err, asterix.ErrInvalidMessage)
+	}
+}
+
+func TestDataBlock_EncodeLengthOverflow(t *testing.T) {
+	uap := newCat021UAP(t)
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+
+	// Each record encodes to 7 bytes, so this exceeds the 16-bit length field
+	const count = 10000
+	for i := 0; i < count; i++ {
+		if err := block.AddRecord(newCat021Record(t, uap, 25, 100, uint32(i))); err != nil {
+			t.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+
+	if _, err := block.Encode(); !errors.Is(err, asterix.ErrInvalidLength) {
+		t.Fatalf("Encode() error = %v, want %v", err, asterix.ErrInvalidLength)
+	}
+
+	chunks, err := block.EncodeChunked(0)
+	if err != nil {
+		t.Fatalf("EncodeChunked() error = %v", err)
+	}
+	if len(chunks) != 2 {
+		t.Fatalf("EncodeChunked() = %d blocks, want 2", len(chunks))
+	}
+	total := 0
+	for i, chunk := range chunks {
+		if len(chunk) > asterix.MaxBlockLength {
+			t.Errorf("block %d is %d bytes, exceeds %d", i, len(chunk), asterix.MaxBlockLength)
+		}
+		decoded, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		if err := decoded.Decode(chunk); err != nil {
+			t.Fatalf("Decode(block %d) error = %v", i, err)
+		}
+		total += decoded.Length()
+	}
+	if total != count {
+		t.Errorf("decoded %d records across chunks, want %d", total, count)
+	}
+}
+
+func TestDataBlock_DecodeTrailingBytes(t *testing.T) {
+	uap := newCat021UAP(t)
+	record := encodeRecord(t, newCat021Record(t, uap, 25, 100, 0xABCDEF))
+
+	// One extra byte beyond what the single record consumes
+	body := append(append([]byte(nil), record...), 0x00)
+	data := append([]byte{21, 0x00, byte(3 + len(body))}, body...)
+
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	err = block.Decode(data)
+	if !errors.Is(err, asterix.ErrTrailingBytes) {
+		t.Fatalf("Decode() error = %v, want %v", err, asterix.ErrTrailingBytes)
+	}
+	if want := fmt.Sprintf("1 of %d body bytes", len(body)); !strings.Contains(err.Error(), want) {
+		t.Errorf("Decode() error = %q, want the byte delta reported", err)
+	}
+
+	// A second record that is cut short is a decoding error of its own
+	body = append(append([]byte(nil), record...), record[:len(record)-1]...)
+	data = append([]byte{21, 0x00, byte(3 + len(body))}, body...)
+	err = block.Decode(data)
+	var de *asterix.DecodeError
+	if errors.Is(err, asterix.ErrTrailingBytes) || !errors.As(err, &de) || de.Record != 1 {
+		t.Errorf("Decode() error = %v, want a *DecodeError for record 1", err)
+	}
+
+	// Without the extra byte the same block decodes cleanly
+	data = append([]byte{21, 0x00, byte(3 + len(record))}, record...)
+	if err := block.Decode(data); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if block.Length() != 1 {
+		t.Errorf("Decode() records = %d, want 1", block.Length())
+	}
+}
+
+func TestDataBlock_Equal(t *testing.T) {
+	uap := newCat021UAP(t)
+	newBlock := func(addresses ...uint32) *asterix.DataBlock {
+		t.Helper()
+		block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		for _, address := range addresses {
+			if err := block.AddRecord(newCat021Record(t, uap, 25, 100, address)); err != nil {
+				t.Fatalf("AddRecord() error = %v", err)
+			}
+		}
+		return block
+	}
+
+	tests := []struct {
+		name string
+		a, b *asterix.DataBlock
+		want bool
+	}{
+		{"equal", newBlock(0x000001, 0x000002), newBlock(0x000001, 0x000002), true},
+		{"empty", newBlock(), newBlock(), true},
+		{"record order", newBlock(0x000001, 0x000002), newBlock(0x000002, 0x000001), false},
+		{"one item differs", newBlock(0x000001, 0x000002), newBlock(0x000001, 0x000003), false},
+		{"record count", newBlock(0x000001), newBlock(0x000001, 0x000002), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+			if got := tt.b.Equal(tt.a); got != tt.want {
+				t.Errorf("Equal() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// An extra item in one record makes the blocks differ
+	a, b := newBlock(0x000001), newBlock(0x000001)
+	if err := b.Records()[0].SetDataItem("I021/161", &v26.TrackNumber{Value: 7}); err != nil {
+		t.Fatalf("SetDataItem() error = %v", err)
+	}
+	if a.Equal(b) {
+		t.Errorf("Equal() ignored an extra item")
+	}
+}
+
+func TestDataBlock_EncodeSeparate(t *testing.T) {
+	uap := newCat021UAP(t)
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	for i := uint32(1); i <= 3; i++ {
+		if err := block.AddRecord(newCat021Record(t, uap, 25, 100, i)); err != nil {
+			t.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+
+	blocks, err := block.EncodeSeparate()
+	if err != nil {
+		t.Fatalf("EncodeSeparate() error = %v", err)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("EncodeSeparate() = %d blocks, want 3", len(blocks))
+	}
+	for i, data := range blocks {
+		decoded, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		if err := decoded.Decode(data); err != nil {
+			t.Fatalf("Decode(block %d) error = %v", i, err)
+		}
+		if decoded.Length() != 1 {
+			t.Fatalf("block %d holds %d records, want 1", i, decoded.Length())
+		}
+		if !decoded.Records()[0].Equal(block.Records()[i]) {
+			t.Errorf("block %d record differs from source record %d", i, i)
+		}
+	}
+
+	empty, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if blocks, err := empty.EncodeSeparate(); err != nil || len(blocks) != 0 {
+		t.Errorf("EncodeSeparate() of empty block = %d blocks, %v, want none", len(blocks), err)
+	}
+}
+
+// unblockableUAP wraps a UAP to declare its category not blockable
+type unblockableUAP struct{ asterix.UAP }
+
+func (unblockableUAP) Blockable() bool { return false }
+
+func TestDataBlock_Merge(t *testing.T) {
+	uap := newCat021UAP(t)
+	newBlock := func(uap asterix.UAP, addresses ...uint32) *asterix.DataBlock {
+		t.Helper()
+		block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		for _, address := range addresses {
+			if err := block.AddRecord(newCat021Record(t, uap, 25, 100, address)); err != nil {
+				t.Fatalf("AddRecord() error = %v", err)
+			}
+		}
+		return block
+	}
+
+	block, other := newBlock(uap, 0x000001), newBlock(uap, 0x000002, 0x000003)
+	if err := block.Merge(other); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if !block.Equal(newBlock(uap, 0x000001, 0x000002, 0x000003)) {
+		t.Error("Merge() did not append the records in order")
+	}
+
+	// The merged records are copies; changing the source leaves them alone
+	if err := other.Records()[0].SetDataItem("I021/080", &v26.TargetAddress{Address: 0xFFFFFF}); err != nil {
+		t.Fatalf("SetDataItem() error = %v", err)
+	}
+	if addr, _ := block.Records()[1].GetField("I021/080", "Address"); addr != uint32(0x000002) {
+		t.Errorf("merged record address = %v after changing the source, want 2", addr)
+	}
+
+	cat048UAP, err := cat048.NewUAP(cat048.Version132)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	radar, err := asterix.NewDataBlock(asterix.Cat048, cat048UAP)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.Merge(radar); !errors.Is(err, asterix.ErrInvalidCategory) {
+		t.Errorf("Merge(Cat048) error = %v, want %v", err, asterix.ErrInvalidCategory)
+	}
+
+	single := unblockableUAP{uap}
+	if err := newBlock(single).Merge(newBlock(single, 0x000001)); err != nil {
+		t.Errorf("Merge() into an empty unblockable block error = %v", err)
+	}
+	if err := newBlock(single, 0x000001).Merge(newBlock(single, 0x000002)); !errors.Is(err, asterix.ErrInvalidMessage) {
+		t.Errorf("Merge() of unblockable blocks error = %v, want %v", err, asterix.ErrInvalidMessage)
+	}
+}
+
+func TestDataBlock_Validate(t *testing.T) {
+	uap := newCat021UAP(t)
+	newBlock := func(uap asterix.UAP, records ...*asterix.Record) *asterix.DataBlock {
+		t.Helper()
+		block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		for _, record := range records {
+			if err := block.AddRecord(record); err != nil {
+				t.Fatalf("AddRecord() error = %v", err)
+			}
+		}
+		return block
+	}
+
+	valid := newBlock(uap, newCat021Record(t, uap, 25, 100, 1), newCat021Record(t, uap, 25, 100, 2))
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	incomplete, err := asterix.NewRecord(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	if err := incomplete.SetDataItem("I021/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100}); err != nil {
+		t.Fatalf("SetDataItem() error = %v", err)
+	}
+	err = newBlock(uap, newCat021Record(t, uap, 25, 100, 1), incomplete).Validate()
+	if !errors.Is(err, asterix.ErrMandatoryField) || !strings.HasPrefix(err.Error(), "record 1: ") {
+		t.Errorf("Validate() with a record missing mandatory items error = %v, want %v for record 1",
+			err, asterix.ErrMandatoryField)
+	}
+
+	single := unblockableUAP{uap}
+	if err := newBlock(single, newCat021Record(t, uap, 25, 100, 1)).Validate(); err != nil {
+		t.Errorf("Validate() of a single record unblockable block error = %v", err)
+	}
+	err = newBlock(single, newCat021Record(t, uap, 25, 100, 1), newCat021Record(t, uap, 25, 100, 2)).Validate()
+	if !errors.Is(err, asterix.ErrInvalidMessage) || !strings.HasPrefix(err.Error(), "record 1: ") {
+		t.Errorf("Validate() of an unblockable block with two records error = %v, want %v for record 1",
+			err, asterix.ErrInvalidMessage)
+	}
+}
+
+func TestDataBlock_DecodeErrorContext(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	data := []byte{
+		62, 0x00, 0x0C, // CAT, LEN
+		0x91, 0x1C, // FSPEC: 010, 070, 380, 040, 080
+		0x01, 0x02, // I062/010
+		0x00, 0x00, 0x80, // I062/070
+		0x80, 0xAB, // I062/380: target address cut after its first octet
+	}
+	block, err := asterix.NewDataBlock(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+
+	err = block.Decode(data)
+	var de *asterix.DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Decode() error = %v, want a *DecodeError", err)
+	}
+	if de.Category != asterix.Cat062 || de.Record != 0 || de.DataItem != "I062/380" || de.Position != 10 {
+		t.Errorf("DecodeError = category %d, record %d, item %s, offset %d, want 62, 0, I062/380, 10",
+			de.Category, de.Record, de.DataItem, de.Position)
+	}
+	if !asterix.IsDecodeError(err) {
+		t.Errorf("IsDecodeError(%v) = false", err)
+	}
+	if want := "record 0, item I062/380, at byte 10/12"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Decode() error = %q, want it to contain %q", err, want)
+	}
+}
@@ -17,6 +17,32 @@ type DataItem interface {
 	Validate() error
 }
 
+// RawRetainer is implemented by data items that keep the bytes they were
+// decoded from and replay them verbatim on Encode
+type RawRetainer interface {
+	// DiscardRaw drops the bytes retained from the last Decode so that
+	// Encode serializes the typed fields instead of replaying the original
+	// bytes, for example after the typed fields were changed
+	DiscardRaw()
+}
+
+// RawBytesProvider is implemented by data items that can return the exact
+// bytes they were decoded from, e.g. to forward an item unmodified. ok is
+// false when the item holds no such bytes, because it was built from typed
+// fields or its bytes were discarded. The slice belongs to the item and must
+// not be modified.
+type RawBytesProvider interface {
+	RawBytes() (raw []byte, ok bool)
+}
+
+// Resetter is implemented by data items that can be cleared for decoding
+// into again, as Record.DecodeInto and WithRecordReuse do. Reset must leave
+// the item as the UAP's CreateDataItem returns it. Items without the method
+// are created anew for each record.
+type Resetter interface {
+	Reset()
+}
+
 // ItemType indicates how a data item should be processed
 type ItemType uint8
 
@@ -25,6 +51,7 @@ const (
 	Extended
 	Repetitive
 	Compound
+	Explicit // First octet gives the length of the item, itself included
 )
 
 // DataField describes a field in the UAP
@@ -33,6 +60,6 @@ type DataField struct {
 	DataItem    string // e.g., "021/010"
 	Description string
 	Type        ItemType
-	Length      uint8 // For fixed length items
+	Length      uint8 // Octets of a fixed item or of the first part of an extended one; element size of a repetitive one
 	Mandatory   bool
 }
@@ -3,22 +3,49 @@ package asterix
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"slices"
+	"sync/atomic"
 	"time"
 )
 
 // Decoder handles decoding of ASTERIX data
 type Decoder struct {
-	decoders map[Category]*CategoryDecoder
+	decoders       map[Category]*CategoryDecoder
+	maxFXDepth     int
+	postProcessors map[Category]func(*DataBlock) error
+	timeCheck      *timeChecker
+	reserved       map[Category]bool
+	metrics        *decodeMetrics
+	lenient        bool
+	scratch        map[Category]*recordScratch // Set by WithRecordReuse
+	buffers        *BufferPool                 // Frames of StreamDecode
+
+	messages atomic.Uint64
+	records  atomic.Uint64
+	errors   atomic.Uint64
+}
+
+// DecoderStats holds the counters of a Decoder
+type DecoderStats struct {
+	Messages uint64 // Messages decoded successfully
+	Records  uint64 // Records contained in those messages
+	Errors   uint64 // Decode calls that returned an error
 }
 
 // CategoryDecoder holds pre-compiled information for decoding a specific category
 type CategoryDecoder struct {
-	category   Category
-	fieldSpecs []FieldSpec
-	uap        UAP
+	category     Category
+	fieldSpecs   []FieldSpec
+	uap          UAP
+	maxFXDepth   int
+	sourceFilter func(sac, sic uint8) bool
+	lengths      map[string]int // Set by WithItemLengthOverride
 }
 
 // FieldSpec contains pre-compiled field information
@@ -27,15 +54,46 @@ type FieldSpec struct {
 	DataItem string
 	Type     ItemType
 	Length   uint8
+
+	overridden bool // Length set by WithItemLengthOverride
 }
 
 // NewDecoder creates a decoder with the provided UAPs
 func NewDecoder(uaps ...UAP) (*Decoder, error) {
+	return NewDecoderWithOptions(WithUAPs(uaps...))
+}
+
+// NewDecoderWithOptions creates a decoder configured by the given options
+func NewDecoderWithOptions(opts ...DecoderOption) (*Decoder, error) {
+	cfg := decoderConfig{
+		maxFXDepth: DefaultMaxFXDepth,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	d := &Decoder{
-		decoders: make(map[Category]*CategoryDecoder),
+		decoders:       make(map[Category]*CategoryDecoder),
+		maxFXDepth:     cfg.maxFXDepth,
+		postProcessors: cfg.postProcessors,
+		reserved:       cfg.reserved,
+		lenient:        cfg.lenient,
+		buffers:        cfg.buffers,
+	}
+	if d.buffers == nil {
+		d.buffers = scratchBuffers
+	}
+	if cfg.metrics {
+		d.metrics = newDecodeMetrics()
+	}
+	if cfg.reuse {
+		d.scratch = make(map[Category]*recordScratch)
+	}
+	if cfg.timeTolerance != nil {
+		d.timeCheck = newTimeChecker(*cfg.timeTolerance)
 	}
 
-	for _, uap := range uaps {
+	for _, uap := range cfg.uaps {
 		if uap == nil {
 			return nil, fmt.Errorf("%w: UAP cannot be nil", ErrInvalidMessage)
 		}
@@ -44,6 +102,11 @@ func NewDecoder(uaps ...UAP) (*Decoder, error) {
 		if err != nil {
 			return nil, fmt.Errorf("creating decoder for category %v: %w", uap.Category(), err)
 		}
+		cd.maxFXDepth = d.maxFXDepth
+		cd.sourceFilter = cfg.sourceFilter
+		if err := cd.overrideLengths(cfg.lengths[uap.Category()]); err != nil {
+			return nil, fmt.Errorf("category %v: %w", uap.Category(), err)
+		}
 		d.decoders[uap.Category()] = cd
 	}
 
@@ -58,25 +121,193 @@ func newCategoryDecoder(uap UAP) (*CategoryDecoder, error) {
 	}
 
 	// Pre-compile field specifications
+	cd.fieldSpecs = compileFieldSpecs(uap)
+
+	return cd, nil
+}
+
+// compileFieldSpecs returns the field specifications of the UAP's fields
+func compileFieldSpecs(uap UAP) []FieldSpec {
+	var specs []FieldSpec
 	for _, field := range uap.Fields() {
-		spec := FieldSpec{
+		specs = append(specs, FieldSpec{
 			FRN:      field.FRN,
 			DataItem: field.DataItem,
 			Type:     field.Type,
 			Length:   field.Length,
+		})
+	}
+	return specs
+}
+
+// overrideLengths turns the given items into fixed length items of the
+// given lengths, in the UAP's fields and in the profile a UAPSelector
+// chooses for each record
+func (cd *CategoryDecoder) overrideLengths(lengths map[string]int) error {
+	_, selector := cd.uap.(UAPSelector)
+	for id, length := range lengths {
+		if length < 1 || length > 255 {
+			return fmt.Errorf("%w: override of %d octets for %s", ErrInvalidLength, length, id)
+		}
+		// Items outside the shared fields of a selector may be in a profile
+		if !selector && !slices.ContainsFunc(cd.fieldSpecs, func(spec FieldSpec) bool {
+			return spec.DataItem == id
+		}) {
+			return fmt.Errorf("%w: length override for %s", ErrUnknownDataItem, id)
 		}
-		cd.fieldSpecs = append(cd.fieldSpecs, spec)
 	}
+	if len(lengths) > 0 {
+		cd.lengths = lengths
+		cd.fieldSpecs = cd.compileSpecs(cd.uap)
+	}
+	return nil
+}
 
-	return cd, nil
+// compileSpecs returns the field specifications of the UAP's fields with the
+// decoder's length overrides applied
+func (cd *CategoryDecoder) compileSpecs(uap UAP) []FieldSpec {
+	specs := compileFieldSpecs(uap)
+	for i := range specs {
+		if length, ok := cd.lengths[specs[i].DataItem]; ok {
+			specs[i].Type = Fixed
+			specs[i].Length = uint8(length)
+			specs[i].overridden = true
+		}
+	}
+	return specs
+}
+
+// Fork returns a decoder for use in another goroutine. The child shares the
+// parent's pre-compiled category decoders, which are read-only, and block
+// post-processors, which must therefore be safe for concurrent use. It holds
+// its own snapshot of the category table taken at fork time and keeps its own
+// statistics and, with WithTimeMonotonicCheck, its own time tracking and,
+// with WithRecordReuse, its own scratch records. The counters of WithMetrics
+// are shared with the parent.
+func (d *Decoder) Fork() *Decoder {
+	child := &Decoder{
+		decoders:       make(map[Category]*CategoryDecoder, len(d.decoders)),
+		maxFXDepth:     d.maxFXDepth,
+		postProcessors: d.postProcessors,
+		reserved:       d.reserved,
+		metrics:        d.metrics,
+		lenient:        d.lenient,
+		buffers:        d.buffers,
+	}
+	if d.timeCheck != nil {
+		child.timeCheck = newTimeChecker(d.timeCheck.tolerance)
+	}
+	if d.scratch != nil {
+		child.scratch = make(map[Category]*recordScratch)
+	}
+	for cat, cd := range d.decoders {
+		child.decoders[cat] = cd
+	}
+	return child
+}
+
+// Stats returns a snapshot of the decoder's counters
+func (d *Decoder) Stats() DecoderStats {
+	return DecoderStats{
+		Messages: d.messages.Load(),
+		Records:  d.records.Load(),
+		Errors:   d.errors.Load(),
+	}
+}
+
+// Metrics returns a snapshot of the per-category counters, or nil unless
+// the decoder was created with WithMetrics
+func (d *Decoder) Metrics() map[Category]DecodeStats {
+	if d.metrics == nil {
+		return nil
+	}
+	return d.metrics.snapshot()
+}
+
+// TimeRegressions returns the records found going backward in time since the
+// last call, oldest first, and clears them. It returns nil unless the decoder
+// was created with WithTimeMonotonicCheck. At most 1024 regressions are held
+// between calls; later ones are dropped.
+func (d *Decoder) TimeRegressions() []TimeRegression {
+	if d.timeCheck == nil {
+		return nil
+	}
+	return d.timeCheck.drain()
+}
+
+// Probe reads the header of the data block at the start of data and returns
+// its category and total length without decoding the body. It fails if the
+// header is truncated, the category is reserved (see Category.IsReserved) or
+// the declared length does not fit in data.
+func Probe(data []byte) (Category, int, error) {
+	if len(data) < 3 {
+		return 0, 0, fmt.Errorf("%w: %d bytes left for a 3-byte header", ErrInvalidLength, len(data))
+	}
+	cat := Category(data[0])
+	length := int(binary.BigEndian.Uint16(data[1:3]))
+	if cat.IsReserved() {
+		return cat, length, fmt.Errorf("%w: %d", ErrReservedCategory, cat)
+	}
+	if length < 3 {
+		return cat, length, fmt.Errorf("%w: invalid length %d", ErrInvalidLength, length)
+	}
+	if length > len(data) {
+		return cat, length, fmt.Errorf("%w: block declares %d bytes, %d available",
+			ErrInvalidLength, length, len(data))
+	}
+	return cat, length, nil
+}
+
+// PreflightCategories walks the block headers of a stream of concatenated
+// data blocks and reports, in order of first appearance, the categories for
+// which the decoder has no UAP registered. Block bodies are not decoded. On a
+// malformed header, including one of a category the decoder treats as
+// reserved, the categories found up to that point are returned along with
+// the error.
+func (d *Decoder) PreflightCategories(data []byte) (missing []Category, err error) {
+	seen := make(map[Category]bool)
+	for offset := 0; offset < len(data); {
+		cat, length, err := Probe(data[offset:])
+		if err != nil {
+			return missing, fmt.Errorf("block at offset %d: %w", offset, err)
+		}
+		_, registered := d.decoders[cat]
+		if !registered && d.reserved[cat] {
+			return missing, fmt.Errorf("block at offset %d: %w: %d", offset, ErrReservedCategory, cat)
+		}
+		if !registered && !seen[cat] {
+			seen[cat] = true
+			missing = append(missing, cat)
+		}
+		offset += length
+	}
+	return missing, nil
 }
 
-// Decode processes raw ASTERIX data
 // Decode reads ASTERIX data from an io.Reader and returns a corresponding AsterixMessage
 func (d *Decoder) Decode(reader io.Reader) (*AsterixMessage, error) {
-	// Read the first 3 bytes to determine category and length
 	header := make([]byte, 3)
+	msg, err := d.decode(reader, header)
+	if err != nil {
+		d.errors.Add(1)
+		if d.metrics != nil && header[0] != 0 {
+			d.metrics.failed(Category(header[0]))
+		}
+		return nil, err
+	}
+	d.messages.Add(1)
+	d.records.Add(uint64(len(msg.records)))
+	if d.metrics != nil {
+		d.metrics.decoded(msg)
+	}
+	return msg, nil
+}
+
+// decode processes raw ASTERIX data, reading its first 3 bytes into header
+func (d *Decoder) decode(reader io.Reader, header []byte) (*AsterixMessage, error) {
+	// Read the first 3 bytes to determine category and length
 	if _, err := io.ReadFull(reader, header); err != nil {
+		header[0] = 0 // no category to count the failure against
 		return nil, fmt.Errorf("reading message header: %w", err)
 	}
 
@@ -90,7 +321,13 @@ func (d *Decoder) Decode(reader io.Reader) (*AsterixMessage, error) {
 
 	// Find matching decoder
 	cd, exists := d.decoders[cat]
-	if !exists {
+	switch {
+	case exists:
+	case cat.IsReserved() || d.reserved[cat]:
+		return nil, fmt.Errorf("%w: %d", ErrReservedCategory, cat)
+	case cat.IsValid():
+		return nil, fmt.Errorf("%w: %d is supported but has no UAP registered", ErrUnknownCategory, cat)
+	default:
 		return nil, fmt.Errorf("%w: %d", ErrUnknownCategory, cat)
 	}
 
@@ -109,85 +346,402 @@ func (d *Decoder) Decode(reader io.Reader) (*AsterixMessage, error) {
 		uap:        cd.uap,
 	}
 
+	// A header-only block is a legal empty message (keepalive)
+	if length == 3 {
+		msg.records = make([]map[string]DataItem, 0)
+		return msg, nil
+	}
+
 	// Decode records
-	records, err := cd.decode(bytes.NewBuffer(data[3:]))
+	var scratch *recordScratch
+	if d.scratch != nil {
+		scratch = d.scratch[cat]
+		if scratch == nil {
+			scratch = &recordScratch{}
+			d.scratch[cat] = scratch
+		}
+	}
+	buf := bytes.NewBuffer(data[3:])
+	records, err := cd.decode(buf, scratch)
 	if err != nil {
 		return nil, fmt.Errorf("decoding records: %w", err)
 	}
 
 	msg.records = records
+
+	if fn, ok := d.postProcessors[cat]; ok {
+		if err := postProcess(msg, cd.uap, fn); err != nil {
+			return nil, fmt.Errorf("post-processing %s block: %w", cat, err)
+		}
+	}
+	if d.timeCheck != nil {
+		d.timeCheck.check(msg, cd.uap)
+	}
 	return msg, nil
 }
 
-// decode processes data for a specific category
-func (cd *CategoryDecoder) decode(buf *bytes.Buffer) ([]map[string]DataItem, error) {
-	var results []map[string]DataItem
+// recordScratch holds the item maps a decoder created WithRecordReuse
+// decodes the records of one category into, one per record index
+type recordScratch struct {
+	items []map[string]DataItem
+	spare []map[string]DataItem
+}
+
+// record empties the item map of record i for decoding, moving its items to
+// the spare map of the record, and returns both
+func (s *recordScratch) record(i int) (items, spare map[string]DataItem) {
+	for len(s.items) <= i {
+		s.items = append(s.items, make(map[string]DataItem))
+		s.spare = append(s.spare, make(map[string]DataItem))
+	}
+	items, spare = s.items[i], s.spare[i]
+	for id, item := range items {
+		spare[id] = item
+	}
+	clear(items)
+	return items, spare
+}
+
+// postProcess runs fn on the message's records wrapped in a DataBlock and
+// copies the resulting records back into the message
+func postProcess(msg *AsterixMessage, uap UAP, fn func(*DataBlock) error) error {
+	block, err := messageBlock(msg, uap)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(block); err != nil {
+		return err
+	}
+
+	msg.records = msg.records[:0]
+	for _, record := range block.records {
+		msg.records = append(msg.records, record.items)
+	}
+	return nil
+}
+
+// messageBlock wraps the message's records in a DataBlock. The records share
+// their items with the message.
+func messageBlock(msg *AsterixMessage, uap UAP) (*DataBlock, error) {
+	block, err := NewDataBlock(msg.Category, uap)
+	if err != nil {
+		return nil, err
+	}
+	for _, items := range msg.records {
+		record, err := NewRecord(msg.Category, profileOf(uap, items))
+		if err != nil {
+			return nil, err
+		}
+		record.items = items
+		for _, field := range record.uap.Fields() {
+			if _, exists := items[field.DataItem]; exists {
+				if err := record.fspec.SetFRN(field.FRN); err != nil {
+					return nil, err
+				}
+			}
+		}
+		block.records = append(block.records, record)
+	}
+	return block, nil
+}
+
+// DecodeAllFrom decodes concatenated data blocks from r with dec until r is
+// exhausted and returns them in order. Reaching EOF between two blocks ends
+// the stream cleanly; EOF within a block header or body is an error wrapping
+// io.ErrUnexpectedEOF. On any error the blocks decoded before it are returned
+// along with it.
+func DecodeAllFrom(dec *Decoder, r io.Reader) ([]*DataBlock, error) {
+	var blocks []*DataBlock
+	err := dec.StreamDecode(r, func(block *DataBlock) error {
+		blocks = append(blocks, block)
+		return nil
+	})
+	return blocks, err
+}
+
+// StreamDecode decodes concatenated data blocks from r and passes each to cb
+// in order, as StreamDecodeContext does without a context.
+func (d *Decoder) StreamDecode(r io.Reader, cb func(*DataBlock) error) error {
+	return d.StreamDecodeContext(context.Background(), r, cb)
+}
 
-	for buf.Len() > 0 {
-		// Check for at least one byte for FSPEC
-		if buf.Len() < 1 {
-			break // End of data reached
+// StreamDecodeContext decodes concatenated data blocks from r and passes
+// each to cb in order until r is exhausted, a block fails to decode or cb
+// returns an error. Reaching EOF between two blocks ends the stream cleanly
+// and returns nil; EOF within a block is an error wrapping
+// io.ErrUnexpectedEOF.
+//
+// With WithLenientMode a block that is framed correctly but fails to decode
+// is skipped and the stream goes on with the next block; the returned error
+// then joins a *MessageError for every skipped block with the error that
+// ended the stream, if any.
+//
+// ctx is checked before each block is read, so once it is done cb is not
+// called again and the returned error wraps ctx.Err() and reports how many
+// blocks were delivered. A Read blocked inside r is not interrupted; close
+// the reader to stop it.
+func (d *Decoder) StreamDecodeContext(ctx context.Context, r io.Reader, cb func(*DataBlock) error) error {
+	header := make([]byte, 3)
+	frame := d.buffers.Get()
+	defer d.buffers.Put(frame)
+	var skipped []error
+	delivered, offset := 0, 0
+	for n := 0; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(skipped, fmt.Errorf("stream stopped after %d blocks: %w", delivered, err))...)
+		}
+		data, err := readFrame(r, header, frame)
+		if err == io.EOF {
+			return errors.Join(skipped...)
+		}
+		if err != nil {
+			return errors.Join(append(skipped, fmt.Errorf("block %d: %w", n, err))...)
 		}
 
-		items, err := cd.decodeRecord(buf)
+		block, err := d.decodeFrame(data)
+		if err != nil {
+			if !d.lenient {
+				return fmt.Errorf("block %d: %w", n, err)
+			}
+			skipped = append(skipped, &MessageError{Index: n, Offset: offset, Err: err})
+			offset += len(data)
+			continue
+		}
+		offset += len(data)
+		if err := cb(block); err != nil {
+			return errors.Join(append(skipped, fmt.Errorf("block %d: %w", n, err))...)
+		}
+		delivered++
+	}
+}
+
+// errStopIteration ends the stream of DecodeReader when the loop breaks
+var errStopIteration = errors.New("iteration stopped")
+
+// DecodeReader returns an iterator over the data blocks decoded from r, as
+// StreamDecode delivers them. A hard error, or with WithLenientMode the
+// joined errors of the skipped blocks, is yielded once with a nil block
+// and ends the iteration:
+//
+//	for block, err := range dec.DecodeReader(conn) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (d *Decoder) DecodeReader(r io.Reader) iter.Seq2[*DataBlock, error] {
+	return func(yield func(*DataBlock, error) bool) {
+		err := d.StreamDecode(r, func(block *DataBlock) error {
+			if !yield(block, nil) {
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopIteration) {
+			yield(nil, err)
+		}
+	}
+}
+
+// MessageError reports a data block that WithLenientMode skipped in a stream
+type MessageError struct {
+	Index  int   // Position of the block in the stream, counting from 0
+	Offset int   // Offset of the block's first byte in the stream
+	Err    error // Why the block failed to decode
+}
+
+func (e *MessageError) Error() string {
+	return fmt.Sprintf("block %d at offset %d skipped: %v", e.Index, e.Offset, e.Err)
+}
+
+func (e *MessageError) Unwrap() error {
+	return e.Err
+}
+
+// readFrame reads the next length-delimited data block of r into frame, using
+// header as scratch space for its first three bytes. The returned bytes are
+// those of frame and valid until its next use. It returns io.EOF, unwrapped,
+// only when r ends before the block starts.
+func readFrame(r io.Reader, header []byte, frame *bytes.Buffer) ([]byte, error) {
+	if _, err := io.ReadFull(r, header); err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	length := int(binary.BigEndian.Uint16(header[1:3]))
+	if length < 3 {
+		return nil, fmt.Errorf("%w: invalid length %d", ErrInvalidLength, length)
+	}
+	frame.Reset()
+	frame.Grow(length)
+	data := frame.AvailableBuffer()[:length]
+	copy(data, header)
+	if _, err := io.ReadFull(r, data[3:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("reading %d byte body: %w", length-3, err)
+	}
+	return data, nil
+}
+
+// decodeFrame decodes one data block read by readFrame. The block does not
+// refer to data, which Decode copies.
+func (d *Decoder) decodeFrame(data []byte) (*DataBlock, error) {
+	msg, err := d.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return messageBlock(msg, msg.uap)
+}
+
+// decode processes data for a specific category. A record that fails to
+// decode is reported as a *DecodeError with its index in the block and the
+// offset of the failing item from the start of the block.
+func (cd *CategoryDecoder) decode(buf *bytes.Buffer, scratch *recordScratch) ([]map[string]DataItem, error) {
+	var results []map[string]DataItem
+
+	size := 3 + buf.Len() // Length of the block, including CAT/LEN
+	for index := 0; buf.Len() > 0; index++ {
+		var items, spare map[string]DataItem
+		if scratch != nil {
+			items, spare = scratch.record(len(results))
+		} else {
+			items = make(map[string]DataItem)
+		}
+		start := size - buf.Len()
+		items, err := cd.decodeRecord(buf, items, spare)
 		if err != nil {
 			// Handle EOF while processing the last record
 			if err == io.EOF && buf.Len() == 0 {
 				break
 			}
+			var de *DecodeError
+			if errors.As(err, &de) {
+				de.WithRecord(index).WithPosition(start+de.Position, size)
+			}
 			return nil, err
 		}
+		if items == nil {
+			continue // Dropped by the source filter
+		}
 		results = append(results, items)
 	}
 
 	return results, nil
 }
 
-// decodeRecord processes a single ASTERIX record
-func (cd *CategoryDecoder) decodeRecord(buf *bytes.Buffer) (map[string]DataItem, error) {
+// decodeRecord processes a single ASTERIX record into the empty map items,
+// reusing the items of spare. It returns nil items without error when the
+// source filter drops the record. Errors are *DecodeError values positioned
+// from the start of the record.
+func (cd *CategoryDecoder) decodeRecord(buf *bytes.Buffer, items, spare map[string]DataItem) (map[string]DataItem, error) {
 	if buf.Len() == 0 {
 		return nil, io.EOF
 	}
 
 	// Read FSPEC
+	start := buf.Len()
 	fspec := NewFSPEC()
 	if _, err := fspec.Decode(buf); err != nil {
-		return nil, fmt.Errorf("decoding FSPEC: %w", err)
+		return nil, NewDecodeError(cd.category, "decoding FSPEC", err)
 	}
 
 	// Decode fields using pre-compiled specs
-	items := make(map[string]DataItem)
-	for _, spec := range cd.fieldSpecs {
-		if !fspec.GetFRN(spec.FRN) {
+	if err := cd.decodeSpecs(buf, fspec, cd.fieldSpecs, cd.uap, items, spare, 0, start); err != nil {
+		return nil, err
+	}
+
+	// The profile of a selector UAP lays out the rest of the record
+	if sel, ok := cd.uap.(UAPSelector); ok {
+		profile, err := sel.SelectUAP(items)
+		if err != nil {
+			return nil, NewDecodeError(cd.category, "selecting UAP", err).
+				WithPosition(start-buf.Len(), 0)
+		}
+		after := cd.fieldSpecs[len(cd.fieldSpecs)-1].FRN
+		if err := cd.decodeSpecs(buf, fspec, cd.compileSpecs(profile), profile, items, spare, after, start); err != nil {
+			return nil, err
+		}
+	}
+
+	if cd.sourceFilter != nil {
+		for _, item := range items {
+			if src, ok := item.(SourceProvider); ok {
+				if sac, sic := src.Source(); !cd.sourceFilter(sac, sic) {
+					return nil, nil
+				}
+			}
+		}
+	}
+	return items, nil
+}
+
+// decodeSpecs decodes into items the fields of specs above FRN after that
+// are marked in the FSPEC, taking them from spare or creating them with uap.
+// start is the length of buf at the start of the record, so that a failing
+// item is reported as a *DecodeError at its offset in the record.
+func (cd *CategoryDecoder) decodeSpecs(buf *bytes.Buffer, fspec *FSPEC, specs []FieldSpec, uap UAP,
+	items, spare map[string]DataItem, after uint8, start int) error {
+	var pos int // Offset of the current item in the record
+	fail := func(id string, err error) error {
+		return NewDecodeError(cd.category, "", err).WithDataItem(id).WithPosition(pos, 0)
+	}
+	for _, spec := range specs {
+		if spec.FRN <= after || !fspec.GetFRN(spec.FRN) {
 			continue
 		}
+		pos = start - buf.Len()
 
 		// For fixed length items, check if we have enough bytes
 		if spec.Type == Fixed && buf.Len() < int(spec.Length) {
-			return nil, fmt.Errorf("buffer too short for %s: need %d bytes, have %d",
-				spec.DataItem, spec.Length, buf.Len())
+			return fail(spec.DataItem, fmt.Errorf("%w: need %d bytes, have %d",
+				ErrBufferTooShort, spec.Length, buf.Len()))
+		}
+
+		// Bound the FX chain of extended items and compound primary subfields
+		if spec.Type == Extended || spec.Type == Compound {
+			if err := CheckFXChain(buf.Bytes(), cd.maxFXDepth); err != nil {
+				return fail(spec.DataItem, err)
+			}
 		}
 
-		item, err := cd.uap.CreateDataItem(spec.DataItem)
+		item, ok := reuseItem(spare, spec.DataItem)
+		var err error
+		if !ok {
+			item, err = uap.CreateDataItem(spec.DataItem)
+		}
 		if err != nil {
 			if spec.Type == Fixed {
 				// For fixed length items, we can skip even unknown ones
 				if buf.Len() < int(spec.Length) {
-					return nil, fmt.Errorf("buffer too short to skip %s: need %d bytes, have %d",
-						spec.DataItem, spec.Length, buf.Len())
+					return fail(spec.DataItem, fmt.Errorf("%w: need %d bytes to skip, have %d",
+						ErrBufferTooShort, spec.Length, buf.Len()))
 				}
 				buf.Next(int(spec.Length))
 				continue
 			}
-			return nil, fmt.Errorf("creating item %s: %w", spec.DataItem, err)
+			return fail(spec.DataItem, fmt.Errorf("creating item: %w", err))
 		}
 
-		if _, err := item.Decode(buf); err != nil {
-			return nil, fmt.Errorf("decoding %s: %w", spec.DataItem, err)
+		if spec.overridden {
+			// Decode from exactly the overridden octets, dropping the rest
+			limitFXDepth(item, cd.maxFXDepth)
+			octets := bytes.NewBuffer(buf.Next(int(spec.Length)))
+			if _, err := item.Decode(octets); err != nil {
+				return fail(spec.DataItem, fmt.Errorf("overridden length %d: %w", spec.Length, err))
+			}
+		} else {
+			limitFXDepth(item, cd.maxFXDepth)
+			if _, err := item.Decode(buf); err != nil {
+				return fail(spec.DataItem, err)
+			}
 		}
 
 		items[spec.DataItem] = item
 	}
-
-	return items, nil
+	return nil
 }
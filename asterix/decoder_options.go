@@ -0,0 +1,176 @@
+// asterix/decoder_options.go
+package asterix
+
+import "time"
+
+// DecoderOption configures a Decoder created by NewDecoderWithOptions
+type DecoderOption func(*decoderConfig)
+
+// decoderConfig collects option values before the Decoder is built
+type decoderConfig struct {
+	uaps           []UAP
+	maxFXDepth     int
+	postProcessors map[Category]func(*DataBlock) error
+	sourceFilter   func(sac, sic uint8) bool
+	lengths        map[Category]map[string]int
+	timeTolerance  *time.Duration
+	reserved       map[Category]bool
+	metrics        bool
+	lenient        bool
+	reuse          bool
+	buffers        *BufferPool
+}
+
+// WithUAPs registers the given UAPs with the decoder
+func WithUAPs(uaps ...UAP) DecoderOption {
+	return func(c *decoderConfig) {
+		c.uaps = append(c.uaps, uaps...)
+	}
+}
+
+// WithDecoderMaxDepth sets the maximum number of octets accepted in the FX
+// chain of an extended item or the primary subfield of a compound item, and
+// in any FX chain an FXDepthLimiter item reads, such as the extents of a
+// compound subfield. Records exceeding it fail with ErrFXDepthExceeded.
+// A value of 0 or less restores DefaultMaxFXDepth.
+func WithDecoderMaxDepth(octets int) DecoderOption {
+	return func(c *decoderConfig) {
+		if octets <= 0 {
+			octets = DefaultMaxFXDepth
+		}
+		c.maxFXDepth = octets
+	}
+}
+
+// WithBlockPostProcessor registers fn to run on every decoded block of the
+// given category that carries records, before Decode returns it. fn may
+// mutate the block, e.g. set items on its records or add and remove records,
+// and the message reflects the changes in its records; RawMessage keeps the
+// bytes as received. An error from fn fails the Decode. Registering a second
+// processor for a category replaces the first.
+func WithBlockPostProcessor(cat Category, fn func(*DataBlock) error) DecoderOption {
+	return func(c *decoderConfig) {
+		if c.postProcessors == nil {
+			c.postProcessors = make(map[Category]func(*DataBlock) error)
+		}
+		c.postProcessors[cat] = fn
+	}
+}
+
+// WithSourceFilter drops, during decode, every record whose data source
+// identifier is rejected by keep. The remaining items of a dropped record are
+// still decoded to consume its bytes, but are not kept, so dropped records
+// never appear in the message or the record statistics. Records without an
+// item implementing SourceProvider are always kept.
+func WithSourceFilter(keep func(sac, sic uint8) bool) DecoderOption {
+	return func(c *decoderConfig) {
+		c.sourceFilter = keep
+	}
+}
+
+// WithItemLengthOverride makes the decoder read exactly length octets for
+// itemID in blocks of cat, whatever the UAP declares. It works around senders
+// known to emit an item with a non-standard length. The item is decoded from
+// the start of those octets and any it does not consume are dropped; an
+// unimplemented item is skipped. The override applies to extended items too,
+// turning them into fixed length items.
+//
+// The override defeats the framing checks of the UAP: on a feed that does not
+// have the defect, every record carrying the item is misaligned and fails to
+// decode or, worse, decodes to wrong values. Use it only for feeds known to
+// be affected. NewDecoderWithOptions rejects lengths outside 1-255 and items
+// the UAP of cat does not define. For a UAPSelector the override also applies
+// to the profile chosen for each record, so items outside the shared fields
+// are accepted unchecked.
+//
+// Only blocks decoded by this Decoder and its forks see the override.
+// Record.Decode and DataBlock.Decode use the lengths of the UAP, and records
+// are always encoded at the lengths their items produce, so relaying decoded
+// records emits a conforming feed.
+func WithItemLengthOverride(cat Category, itemID string, length int) DecoderOption {
+	return func(c *decoderConfig) {
+		if c.lengths == nil {
+			c.lengths = make(map[Category]map[string]int)
+		}
+		if c.lengths[cat] == nil {
+			c.lengths[cat] = make(map[string]int)
+		}
+		c.lengths[cat][itemID] = length
+	}
+}
+
+// WithTimeMonotonicCheck makes the decoder track the time of day of the
+// records of every category and data source and report those going backward
+// by more than tolerance, for feeds that are expected to be ordered. The
+// wrap at midnight is not a regression. Regressions do not fail the Decode;
+// they are collected and returned by Decoder.TimeRegressions. Records
+// without a time item or a data source identifier are not checked.
+func WithTimeMonotonicCheck(tolerance time.Duration) DecoderOption {
+	return func(c *decoderConfig) {
+		c.timeTolerance = &tolerance
+	}
+}
+
+// WithReservedCategories makes the decoder treat the given categories like
+// category 000, failing their blocks with ErrReservedCategory instead of
+// ErrUnknownCategory. Use it for categories that cannot appear on a feed,
+// e.g. the local use range 241-255, so that such bytes are reported as
+// misframed data. Categories with a registered UAP are not affected.
+func WithReservedCategories(cats ...Category) DecoderOption {
+	return func(c *decoderConfig) {
+		if c.reserved == nil {
+			c.reserved = make(map[Category]bool)
+		}
+		for _, cat := range cats {
+			c.reserved[cat] = true
+		}
+	}
+}
+
+// WithMetrics makes the decoder count, per category, the messages, records
+// and octets it decodes and the messages that fail, as returned by
+// Decoder.Metrics. A message whose header cannot be read has no category and
+// is not counted. Forks of the decoder add to the same counters.
+func WithMetrics() DecoderOption {
+	return func(c *decoderConfig) {
+		c.metrics = true
+	}
+}
+
+// WithLenientMode makes StreamDecode, StreamDecodeContext and DecodeAllFrom
+// skip a data block that fails to decode and go on with the next one, whose
+// start the failing block's length field still gives. The failures are
+// returned together, each as a *MessageError, after the stream ends. A
+// broken length field or a truncated block still ends the stream, because
+// the next block cannot be found. Decode itself is not affected.
+func WithLenientMode() DecoderOption {
+	return func(c *decoderConfig) {
+		c.lenient = true
+	}
+}
+
+// WithRecordReuse makes the decoder keep the records it decodes for each
+// category and decode the next block of the category into them, reusing
+// the items implementing Resetter of a data item present again (see
+// Record.DecodeInto), to avoid allocating them anew in steady state. The
+// records of a message, or of a block from StreamDecode, are therefore only
+// valid until the decoder decodes the next block of the same category; copy
+// what must outlive it, and do not collect blocks with DecodeAllFrom. A
+// decoder with record reuse must not be used by several goroutines at once;
+// give each a Fork.
+func WithRecordReuse() DecoderOption {
+	return func(c *decoderConfig) {
+		c.reuse = true
+	}
+}
+
+// WithDecoderBufferPool makes the decoder read the blocks of StreamDecode,
+// DecodeReader and Replayer.Play into buffers taken from pool instead of the
+// package's own pool, for example to bound their capacity with
+// WithMaxBufferCap or watch them with NewBufferPoolWithTracking. A nil pool
+// keeps the default.
+func WithDecoderBufferPool(pool *BufferPool) DecoderOption {
+	return func(c *decoderConfig) {
+		c.buffers = pool
+	}
+}
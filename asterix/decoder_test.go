@@ -0,0 +1,972 @@
+// asterix/decoder_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v11 "github.com/davidkohl/gobelix/cat/cat001/dataitems/v11"
+	cat001uap "github.com/davidkohl/gobelix/cat/cat001/uap"
+	"github.com/davidkohl/gobelix/cat/cat021"
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	"github.com/davidkohl/gobelix/cat/cat048"
+	v132 "github.com/davidkohl/gobelix/cat/cat048/dataitems/v132"
+	"github.com/davidkohl/gobelix/cat/cat062"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestDecoder_MaxFXDepth(t *testing.T) {
+	uap, err := cat021.NewUAP(cat021.Version26)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	// FSPEC: I021/010 and I021/040, followed by an I021/040 whose FX chain
+	// never terminates within the configured depth
+	body := []byte{0xC0, 0x01, 0x02}
+	for i := 0; i < 6; i++ {
+		body = append(body, 0x01)
+	}
+	body = append(body, 0x00)
+	msg := append([]byte{21, 0x00, byte(3 + len(body))}, body...)
+
+	decoder, err := asterix.NewDecoderWithOptions(
+		asterix.WithUAPs(uap),
+		asterix.WithDecoderMaxDepth(4),
+	)
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+
+	_, err = decoder.Decode(bytes.NewReader(msg))
+	if !errors.Is(err, asterix.ErrFXDepthExceeded) {
+		t.Fatalf("Decode() error = %v, want %v", err, asterix.ErrFXDepthExceeded)
+	}
+}
+
+// The configured depth bounds FX chains nested inside an item too, in every
+// decode path
+func TestMaxFXDepth_NestedChain(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	data := []byte{
+		62, 0x00, 0x13, // CAT, LEN
+		0x91, 0x1C, // FSPEC: 010, 070, 380, 040, 080
+		0x01, 0x02, // I062/010
+		0x00, 0x00, 0x80, // I062/070
+		0x01, 0x80, 0x01, 0x01, 0x01, 0x00, // I062/380: trajectory intent status with three extents
+		0x00, 0x2A, // I062/040
+		0x00, // I062/080
+	}
+
+	for _, depth := range []int{0, 3} {
+		decoder, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(uap), asterix.WithDecoderMaxDepth(depth))
+		if err != nil {
+			t.Fatalf("NewDecoderWithOptions() error = %v", err)
+		}
+		_, decErr := decoder.Decode(bytes.NewReader(data))
+
+		block, _ := asterix.NewDataBlock(asterix.Cat062, uap)
+		block.SetMaxFXDepth(depth)
+		blockErr := block.Decode(data)
+
+		record, _ := asterix.NewRecord(asterix.Cat062, uap)
+		record.SetMaxFXDepth(depth)
+		_, recordErr := record.Decode(bytes.NewBuffer(data[3:]))
+
+		for path, err := range map[string]error{"Decoder": decErr, "DataBlock": blockErr, "Record": recordErr} {
+			if depth == 0 && err != nil {
+				t.Errorf("%s: default depth error = %v", path, err)
+			}
+			if depth == 3 && !errors.Is(err, asterix.ErrFXDepthExceeded) {
+				t.Errorf("%s: depth 3 error = %v, want %v", path, err, asterix.ErrFXDepthExceeded)
+			}
+		}
+	}
+}
+
+func TestReadFXChain(t *testing.T) {
+	octets, err := asterix.ReadFXChain(bytes.NewBuffer([]byte{0x81, 0x41, 0x20, 0xFF}), 0)
+	if err != nil {
+		t.Fatalf("ReadFXChain() error = %v", err)
+	}
+	if !bytes.Equal(octets, []byte{0x81, 0x41, 0x20}) {
+		t.Errorf("ReadFXChain() = % X, want 81 41 20", octets)
+	}
+
+	_, err = asterix.ReadFXChain(bytes.NewBuffer([]byte{0x01, 0x01, 0x01}), 2)
+	if !errors.Is(err, asterix.ErrFXDepthExceeded) {
+		t.Errorf("ReadFXChain() error = %v, want %v", err, asterix.ErrFXDepthExceeded)
+	}
+
+	_, err = asterix.ReadFXChain(bytes.NewBuffer([]byte{0x01}), 0)
+	if !errors.Is(err, asterix.ErrBufferTooShort) {
+		t.Errorf("ReadFXChain() error = %v, want %v", err, asterix.ErrBufferTooShort)
+	}
+
+	// Without a bound of its own a chain is bounded by DefaultMaxFXDepth
+	long := bytes.Repeat([]byte{0x01}, asterix.DefaultMaxFXDepth+1)
+	if _, err := asterix.ReadFXChain(bytes.NewBuffer(long), 0); !errors.Is(err, asterix.ErrFXDepthExceeded) {
+		t.Errorf("ReadFXChain() of %d octets error = %v, want %v", len(long), err, asterix.ErrFXDepthExceeded)
+	}
+}
+
+func TestDecoder_ForkConcurrent(t *testing.T) {
+	uap := newCat021UAP(t)
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := block.AddRecord(newCat021Record(t, uap, 25, 100, uint32(i))); err != nil {
+			t.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	parent, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	const workers, iterations = 8, 200
+	children := make([]*asterix.Decoder, workers)
+	var wg sync.WaitGroup
+	for w := range children {
+		children[w] = parent.Fork()
+		wg.Add(1)
+		go func(d *asterix.Decoder) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				msg, err := d.Decode(bytes.NewReader(encoded))
+				if err != nil {
+					t.Errorf("Decode() error = %v", err)
+					return
+				}
+				if msg.GetRecordCount() != 3 {
+					t.Errorf("Decode() records = %d, want 3", msg.GetRecordCount())
+					return
+				}
+			}
+			// A malformed message counts against this child only
+			if _, err := d.Decode(bytes.NewReader([]byte{21, 0x00, 0x01})); err == nil {
+				t.Error("Decode() accepted a malformed header")
+			}
+		}(children[w])
+	}
+	wg.Wait()
+
+	for w, child := range children {
+		want := asterix.DecoderStats{Messages: iterations, Records: 3 * iterations, Errors: 1}
+		if got := child.Stats(); got != want {
+			t.Errorf("child %d Stats() = %+v, want %+v", w, got, want)
+		}
+	}
+	if got := parent.Stats(); got != (asterix.DecoderStats{}) {
+		t.Errorf("parent Stats() = %+v, want zero", got)
+	}
+}
+
+func TestDecoder_BlockPostProcessor(t *testing.T) {
+	uap := newCat021UAP(t)
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	for i := uint32(1); i <= 3; i++ {
+		if err := block.AddRecord(newCat021Record(t, uap, 25, 100, i)); err != nil {
+			t.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+	data, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	tag := func(b *asterix.DataBlock) error {
+		for _, record := range b.Records() {
+			if err := record.SetDataItem("I021/015", &common.ServiceIdentification{Value: 42}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	decoder, err := asterix.NewDecoderWithOptions(
+		asterix.WithUAPs(uap),
+		asterix.WithBlockPostProcessor(asterix.Cat021, tag),
+	)
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+
+	msg, err := decoder.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if msg.GetRecordCount() != 3 {
+		t.Fatalf("GetRecordCount() = %d, want 3", msg.GetRecordCount())
+	}
+	for i := 0; i < 3; i++ {
+		item, _, ok := msg.GetDataItemFromRecord("I021/015", i)
+		if si, isSI := item.(*common.ServiceIdentification); !ok || !isSI || si.Value != 42 {
+			t.Errorf("record %d: I021/015 = %v, want tag 42", i, item)
+		}
+	}
+
+	errRejected := errors.New("rejected")
+	failing, err := asterix.NewDecoderWithOptions(
+		asterix.WithUAPs(uap),
+		asterix.WithBlockPostProcessor(asterix.Cat021, func(*asterix.DataBlock) error { return errRejected }),
+	)
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+	if _, err := failing.Decode(bytes.NewReader(data)); !errors.Is(err, errRejected) {
+		t.Errorf("Decode() error = %v, want %v", err, errRejected)
+	}
+}
+
+func TestDecoder_PreflightCategories(t *testing.T) {
+	uap := newCat021UAP(t)
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(newCat021Record(t, uap, 25, 100, 1)); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	cat021Block, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	// Cat030 has no UAP; its body is garbage that must not be decoded
+	cat030Block := []byte{30, 0x00, 0x06, 0xFF, 0xFF, 0xFF}
+
+	var stream []byte
+	stream = append(stream, cat021Block...)
+	stream = append(stream, cat030Block...)
+	stream = append(stream, cat021Block...)
+	stream = append(stream, cat030Block...)
+
+	decoder, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	missing, err := decoder.PreflightCategories(stream)
+	if err != nil {
+		t.Fatalf("PreflightCategories() error = %v", err)
+	}
+	if len(missing) != 1 || missing[0] != asterix.Category(30) {
+		t.Errorf("PreflightCategories() = %v, want [CAT030]", missing)
+	}
+
+	// A truncated trailing block is reported with what was found before it
+	missing, err = decoder.PreflightCategories(append(stream, 48, 0x00, 0x10))
+	if !errors.Is(err, asterix.ErrInvalidLength) {
+		t.Errorf("PreflightCategories() error = %v, want %v", err, asterix.ErrInvalidLength)
+	}
+	if len(missing) != 1 {
+		t.Errorf("PreflightCategories() = %v, want [CAT030]", missing)
+	}
+}
+
+func TestDecoder_SourceFilter(t *testing.T) {
+	uap := newCat021UAP(t)
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	// Alternate two sources; record addresses tell them apart
+	for i := uint32(1); i <= 4; i++ {
+		if err := block.AddRecord(newCat021Record(t, uap, 25, uint8(100+i%2), i)); err != nil {
+			t.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+	data, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoder, err := asterix.NewDecoderWithOptions(
+		asterix.WithUAPs(uap),
+		asterix.WithSourceFilter(func(sac, sic uint8) bool { return sac == 25 && sic == 100 }),
+	)
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+
+	msg, err := decoder.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if msg.GetRecordCount() != 2 {
+		t.Fatalf("GetRecordCount() = %d, want 2", msg.GetRecordCount())
+	}
+	for i, want := range []uint32{2, 4} {
+		item, _, ok := msg.GetDataItemFromRecord("I021/080", i)
+		if addr, isAddr := item.(*v26.TargetAddress); !ok || !isAddr || addr.Address != want {
+			t.Errorf("record %d: I021/080 = %v, want address %d", i, item, want)
+		}
+	}
+	if got := decoder.Stats().Records; got != 2 {
+		t.Errorf("Stats().Records = %d, want 2", got)
+	}
+}
+
+func TestDecoder_LookupErrors(t *testing.T) {
+	decoder, err := asterix.NewDecoder(newCat021UAP(t))
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	// Cat048 is not registered with the decoder
+	_, err = decoder.Decode(bytes.NewReader([]byte{48, 0x00, 0x04, 0x00}))
+	if !asterix.IsUnknownCategory(err) || asterix.IsUnsupportedVersion(err) {
+		t.Errorf("Decode(unregistered category) error = %v, want only %v", err, asterix.ErrUnknownCategory)
+	}
+
+	// Cat021 is supported, but not in this edition
+	_, err = cat021.NewUAP("0.23")
+	if !asterix.IsUnsupportedVersion(err) || asterix.IsUnknownCategory(err) {
+		t.Errorf("NewUAP(0.23) error = %v, want only %v", err, asterix.ErrUnsupportedVersion)
+	}
+}
+
+func TestDecoder_ItemLengthOverride(t *testing.T) {
+	uap := newCat021UAP(t)
+	rec := encodeRecord(t, newCat021Record(t, uap, 25, 100, 0xABCDEF))
+
+	// A faulty sender pads I021/040, which follows FSPEC and I021/010, with
+	// an extra octet that has nothing to do with its FX bit
+	const at = 2 + 2 + 1
+	body := append(append(append([]byte{}, rec[:at]...), 0x00), rec[at:]...)
+	data := append([]byte{byte(asterix.Cat021), 0x00, byte(3 + len(body))}, body...)
+
+	decoder, err := asterix.NewDecoderWithOptions(
+		asterix.WithUAPs(uap),
+		asterix.WithItemLengthOverride(asterix.Cat021, "I021/040", 2),
+	)
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+	msg, err := decoder.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	item, _, ok := msg.GetDataItemFromRecord("I021/040", 0)
+	if trd, isTRD := item.(*v26.TargetReportDescriptor); !ok || !isTRD || trd.ATP != 1 {
+		t.Errorf("I021/040 = %v, want ATP 1", item)
+	}
+	item, _, ok = msg.GetDataItemFromRecord("I021/080", 0)
+	if addr, isAddr := item.(*v26.TargetAddress); !ok || !isAddr || addr.Address != 0xABCDEF {
+		t.Errorf("I021/080 = %v, want address ABCDEF", item)
+	}
+
+	// Without the override the record is misaligned
+	plain, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	if _, err := plain.Decode(bytes.NewReader(data)); err == nil {
+		t.Error("Decode() without override succeeded, want error")
+	}
+
+	for _, length := range []int{0, 256} {
+		_, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(uap),
+			asterix.WithItemLengthOverride(asterix.Cat021, "I021/040", length))
+		if !errors.Is(err, asterix.ErrInvalidLength) {
+			t.Errorf("override length %d: error = %v, want %v", length, err, asterix.ErrInvalidLength)
+		}
+	}
+	_, err = asterix.NewDecoderWithOptions(asterix.WithUAPs(uap),
+		asterix.WithItemLengthOverride(asterix.Cat021, "I021/999", 2))
+	if !errors.Is(err, asterix.ErrUnknownDataItem) {
+		t.Errorf("override of unknown item: error = %v, want %v", err, asterix.ErrUnknownDataItem)
+	}
+
+	// The override reaches the profile a UAPSelector chooses: I001/040 is
+	// not among the fields Category 001 plots and tracks share
+	uap001, err := cat001uap.NewUAP001()
+	if err != nil {
+		t.Fatalf("NewUAP001() error = %v", err)
+	}
+	decoder, err = asterix.NewDecoderWithOptions(
+		asterix.WithUAPs(uap001),
+		asterix.WithItemLengthOverride(asterix.Cat001, "I001/040", 5),
+	)
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+	data = []byte{
+		1, 0x00, 0x0C, // CAT, LEN
+		0xE0,       // FSPEC: 010, 020, 040
+		0x01, 0x02, // I001/010
+		0x00,                         // I001/020: plot
+		0x01, 0x00, 0x40, 0x00, 0xFF, // I001/040, padded with a stray octet
+	}
+	msg, err = decoder.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Decode() Cat001 error = %v", err)
+	}
+	if n := msg.GetRecordCount(); n != 1 {
+		t.Fatalf("Decode() Cat001 records = %d, want 1", n)
+	}
+	item, _, ok = msg.GetDataItemFromRecord("I001/040", 0)
+	if pos, isPos := item.(*v11.MeasuredPosition); !ok || !isPos || pos.RHO != 2 || pos.THETA != 90 {
+		t.Errorf("I001/040 = %v, want RHO 2, THETA 90", item)
+	}
+}
+
+func TestDecoder_TimeMonotonicCheck(t *testing.T) {
+	uap := newCat021UAP(t)
+	encodeAt := func(sic uint8, seconds ...float64) []byte {
+		t.Helper()
+		block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		for i, s := range seconds {
+			record := newCat021Record(t, uap, 25, sic, uint32(i+1))
+			if err := record.SetDataItem("I021/071", &v26.TimeOfApplicabilityPosition{Time: s}); err != nil {
+				t.Fatalf("SetDataItem() error = %v", err)
+			}
+			if err := block.AddRecord(record); err != nil {
+				t.Fatalf("AddRecord() error = %v", err)
+			}
+		}
+		data, err := block.Encode()
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		return data
+	}
+
+	decoder, err := asterix.NewDecoderWithOptions(
+		asterix.WithUAPs(uap),
+		asterix.WithTimeMonotonicCheck(500*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+
+	blocks := [][]byte{
+		// Jitter within the tolerance and the midnight wrap are fine
+		encodeAt(100, 86399, 86398.75, 0.5),
+		// SIC 101 is tracked separately
+		encodeAt(101, 10),
+		// The last record goes back two seconds
+		encodeAt(100, 1, 3, 1),
+	}
+	for i, data := range blocks {
+		if _, err := decoder.Decode(bytes.NewReader(data)); err != nil {
+			t.Fatalf("Decode(block %d) error = %v", i, err)
+		}
+	}
+
+	got := decoder.TimeRegressions()
+	want := []asterix.TimeRegression{{
+		Category: asterix.Cat021, SAC: 25, SIC: 100, Record: 2,
+		Previous: 3 * time.Second, Current: time.Second,
+	}}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Fatalf("TimeRegressions() = %+v, want %+v", got, want)
+	}
+	if r := got[0].Regression(); r != 2*time.Second {
+		t.Errorf("Regression() = %v, want 2s", r)
+	}
+	if again := decoder.TimeRegressions(); len(again) != 0 {
+		t.Errorf("TimeRegressions() after drain = %+v, want none", again)
+	}
+}
+
+func TestDecoder_ReservedCategory(t *testing.T) {
+	decoder, err := asterix.NewDecoderWithOptions(
+		asterix.WithUAPs(newCat021UAP(t)),
+		asterix.WithReservedCategories(250),
+	)
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		cat      byte
+		reserved bool
+	}{
+		{"category 000", 0, true},
+		{"configured reserved", 250, true},
+		{"supported but unregistered", 48, false},
+		{"unsupported", 34, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte{tt.cat, 0x00, 0x04, 0x80}
+			_, err := decoder.Decode(bytes.NewReader(data))
+			if asterix.IsReservedCategory(err) != tt.reserved || asterix.IsUnknownCategory(err) == tt.reserved {
+				t.Errorf("Decode() error = %v, want reserved = %v", err, tt.reserved)
+			}
+
+			_, err = decoder.PreflightCategories(data)
+			if asterix.IsReservedCategory(err) != tt.reserved {
+				t.Errorf("PreflightCategories() error = %v, want reserved = %v", err, tt.reserved)
+			}
+		})
+	}
+
+	// Probe itself only knows category 000
+	if _, _, err := asterix.Probe([]byte{0, 0x00, 0x04, 0x80}); !asterix.IsReservedCategory(err) {
+		t.Errorf("Probe(category 000) error = %v, want %v", err, asterix.ErrReservedCategory)
+	}
+	if _, _, err := asterix.Probe([]byte{250, 0x00, 0x04, 0x80}); err != nil {
+		t.Errorf("Probe(category 250) error = %v", err)
+	}
+}
+
+func TestDecodeAllFrom(t *testing.T) {
+	uap := newCat021UAP(t)
+	var stream []byte
+	for i := uint32(1); i <= 2; i++ {
+		block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		for j := uint32(0); j < i; j++ {
+			if err := block.AddRecord(newCat021Record(t, uap, 25, 100, i*10+j)); err != nil {
+				t.Fatalf("AddRecord() error = %v", err)
+			}
+		}
+		data, err := block.Encode()
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		stream = append(stream, data...)
+	}
+
+	decoder, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	blocks, err := asterix.DecodeAllFrom(decoder, bytes.NewReader(stream))
+	if err != nil {
+		t.Fatalf("DecodeAllFrom() error = %v", err)
+	}
+	if len(blocks) != 2 || blocks[0].Length() != 1 || blocks[1].Length() != 2 {
+		t.Fatalf("DecodeAllFrom() = %d blocks, want blocks of 1 and 2 records", len(blocks))
+	}
+	item, _, _ := blocks[1].Records()[1].GetDataItem("I021/080")
+	if addr, ok := item.(*v26.TargetAddress); !ok || addr.Address != 21 {
+		t.Errorf("block 1 record 1 I021/080 = %v, want address 21", item)
+	}
+
+	truncated := map[string][]byte{
+		"partial header": append(append([]byte(nil), stream...), 21, 0x00),
+		"partial body":   append(append([]byte(nil), stream...), stream[:7]...),
+	}
+	for name, data := range truncated {
+		blocks, err := asterix.DecodeAllFrom(decoder, bytes.NewReader(data))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%s: DecodeAllFrom() error = %v, want %v", name, err, io.ErrUnexpectedEOF)
+		}
+		if len(blocks) != 2 {
+			t.Errorf("%s: DecodeAllFrom() = %d blocks, want the 2 complete ones", name, len(blocks))
+		}
+	}
+}
+
+func TestDecoder_StreamDecodeContext(t *testing.T) {
+	uap := newCat021UAP(t)
+	var stream []byte
+	for i := uint32(1); i <= 5; i++ {
+		stream = append(stream, encodeCat021Block(t, uap, i)...)
+	}
+	decoder, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	err = decoder.StreamDecodeContext(ctx, bytes.NewReader(stream), func(*asterix.DataBlock) error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("StreamDecodeContext() error = %v, want %v", err, context.Canceled)
+	}
+	if calls != 2 {
+		t.Errorf("callback called %d times, want 2 before cancellation", calls)
+	}
+	if want := "after 2 blocks"; !strings.Contains(err.Error(), want) {
+		t.Errorf("StreamDecodeContext() error = %q, want the delivered count", err)
+	}
+
+	calls = 0
+	if err := decoder.StreamDecode(bytes.NewReader(stream), func(*asterix.DataBlock) error {
+		calls++
+		return nil
+	}); err != nil || calls != 5 {
+		t.Errorf("StreamDecode() = %d blocks, %v, want 5 blocks", calls, err)
+	}
+}
+
+func TestDecoder_LenientMode(t *testing.T) {
+	uap := newCat021UAP(t)
+	first, last := encodeCat021Block(t, uap, 1), encodeCat021Block(t, uap, 3)
+	corrupt := []byte{21, 0x00, 0x05, 0xFF, 0xFF} // FSPEC runs past the block
+	stream := append(append(append([]byte(nil), first...), corrupt...), last...)
+
+	strict, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	if blocks, err := asterix.DecodeAllFrom(strict, bytes.NewReader(stream)); err == nil || len(blocks) != 1 {
+		t.Errorf("DecodeAllFrom() without lenient mode = %d blocks, %v, want 1 block and an error", len(blocks), err)
+	}
+
+	lenient, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(uap), asterix.WithLenientMode())
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+	blocks, err := asterix.DecodeAllFrom(lenient, bytes.NewReader(stream))
+	if len(blocks) != 2 {
+		t.Fatalf("DecodeAllFrom() = %d blocks, want the 2 flanking ones", len(blocks))
+	}
+	for i, want := range []uint32{1, 3} {
+		if addr, _ := blocks[i].Records()[0].GetField("I021/080", "Address"); addr != want {
+			t.Errorf("block %d address = %v, want %d", i, addr, want)
+		}
+	}
+	var skipped *asterix.MessageError
+	if !errors.As(err, &skipped) {
+		t.Fatalf("DecodeAllFrom() error = %v, want a *MessageError", err)
+	}
+	if skipped.Index != 1 || skipped.Offset != len(first) {
+		t.Errorf("MessageError = block %d at offset %d, want block 1 at offset %d", skipped.Index, skipped.Offset, len(first))
+	}
+}
+
+func TestDecoder_DecodeReader(t *testing.T) {
+	uap := newCat021UAP(t)
+	var stream []byte
+	for i := uint32(1); i <= 3; i++ {
+		stream = append(stream, encodeCat021Block(t, uap, i)...)
+	}
+	decoder, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	var addrs []any
+	for block, err := range decoder.DecodeReader(bytes.NewReader(stream)) {
+		if err != nil {
+			t.Fatalf("DecodeReader() error = %v", err)
+		}
+		addr, _ := block.Records()[0].GetField("I021/080", "Address")
+		addrs = append(addrs, addr)
+	}
+	if want := []any{uint32(1), uint32(2), uint32(3)}; !reflect.DeepEqual(addrs, want) {
+		t.Errorf("DecodeReader() addresses = %v, want %v", addrs, want)
+	}
+
+	// Breaking out of the loop stops the stream without an error
+	n := 0
+	for range decoder.DecodeReader(bytes.NewReader(stream)) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("loop ran %d times after break, want 1", n)
+	}
+
+	// A corrupt block ends the iteration with its error
+	corrupt := append(append(append([]byte(nil), stream[:len(stream)/3]...), 21, 0x00, 0x05, 0xFF, 0xFF), stream...)
+	var blocks int
+	var errs []error
+	for block, err := range decoder.DecodeReader(bytes.NewReader(corrupt)) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if block == nil {
+			t.Fatal("DecodeReader() yielded a nil block without an error")
+		}
+		blocks++
+	}
+	if blocks != 1 || len(errs) != 1 || !asterix.IsDecodeError(errs[0]) {
+		t.Errorf("DecodeReader() = %d blocks, errors %v, want 1 block and one decode error", blocks, errs)
+	}
+}
+
+// encodeCat021Block encodes a block of records with the given target addresses
+func encodeCat021Block(tb testing.TB, uap asterix.UAP, addrs ...uint32) []byte {
+	tb.Helper()
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		tb.Fatalf("NewDataBlock() error = %v", err)
+	}
+	for _, addr := range addrs {
+		if err := block.AddRecord(newCat021Record(tb, uap, 25, 100, addr)); err != nil {
+			tb.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+	data, err := block.Encode()
+	if err != nil {
+		tb.Fatalf("Encode() error = %v", err)
+	}
+	return data
+}
+
+func TestDecoder_Cat048MinimalRecord(t *testing.T) {
+	uap, err := cat048.NewUAP(cat048.LatestVersion())
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	items := map[string]asterix.DataItem{
+		"I048/010": &common.DataSourceIdentifier{SAC: 25, SIC: 10},
+		"I048/140": &v132.TimeOfDay{Time: 43200.5},
+		"I048/020": &v132.TargetReportDescriptor{TYP: 2},
+		"I048/040": &v132.MeasuredPosition{RHO: 42.5, THETA: 90},
+		"I048/070": &v132.Mode3ACode{Code: 1234},
+		"I048/090": &v132.FlightLevel{Level: 350},
+	}
+	record, err := asterix.NewRecord(asterix.Cat048, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	for id, item := range items {
+		if err := record.SetDataItem(id, item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", id, err)
+		}
+	}
+	block, err := asterix.NewDataBlock(asterix.Cat048, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(record); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	data, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoder, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(uap))
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+	msg, err := decoder.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if msg.Category != asterix.Cat048 || msg.GetRecordCount() != 1 {
+		t.Fatalf("Decode() = category %d with %d records, want one Cat048 record", msg.Category, msg.GetRecordCount())
+	}
+	for id, want := range items {
+		got, _, ok := msg.GetDataItemFromRecord(id, 0)
+		if !ok {
+			t.Fatalf("%s missing from decoded record", id)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %+v, want %+v", id, got, want)
+		}
+	}
+}
+
+func TestDecoder_DecodeErrorContext(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	data := []byte{
+		62, 0x00, 0x1A, // CAT, LEN
+		0x91, 0x1C, // FSPEC: 010, 070, 380, 040, 080
+		0x01, 0x02, // I062/010
+		0x00, 0x00, 0x80, // I062/070
+		0x80, 0xAB, 0xCD, 0xEF, // I062/380: target address
+		0x00, 0x2A, // I062/040
+		0x00,       // I062/080
+		0x91, 0x1C, // Second record
+		0x01, 0x02,
+		0x00, 0x00, 0x80,
+		0x80, 0xAB, // I062/380: target address cut after its first octet
+	}
+	decoder, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	_, decodeErr := decoder.Decode(bytes.NewReader(data))
+	streamErr := decoder.StreamDecode(bytes.NewReader(data), func(*asterix.DataBlock) error { return nil })
+	for name, err := range map[string]error{"Decode": decodeErr, "StreamDecode": streamErr} {
+		var de *asterix.DecodeError
+		if !errors.As(err, &de) {
+			t.Fatalf("%s() error = %v, want a *DecodeError", name, err)
+		}
+		if de.Record != 1 || de.DataItem != "I062/380" || de.Position != 24 || de.BufferSize != 26 {
+			t.Errorf("%s() DecodeError = record %d, item %s, at byte %d/%d, want 1, I062/380, 24/26",
+				name, de.Record, de.DataItem, de.Position, de.BufferSize)
+		}
+		if want := "record 1, item I062/380, at byte 24/26"; !strings.Contains(err.Error(), want) {
+			t.Errorf("%s() error = %q, want it to contain %q", name, err, want)
+		}
+	}
+}
+
+func TestDecoder_RecordReuse(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	records := cat062Records(t, uap)
+	frame := func(records [][]byte) []byte {
+		body := slices.Concat(records...)
+		return append([]byte{byte(asterix.Cat062), 0, byte(3 + len(body))}, body...)
+	}
+	first := frame(records)
+	// The second block holds the records in reverse, so that reused items
+	// meet a different record each time
+	slices.Reverse(records)
+	second := frame(records)
+
+	fresh, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(uap))
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+	reused, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(uap), asterix.WithRecordReuse())
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+	for i, data := range [][]byte{first, second, first, second} {
+		want, err := fresh.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("block %d: Decode() error = %v", i, err)
+		}
+		got, err := reused.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("block %d: Decode() with reuse error = %v", i, err)
+		}
+		if got.GetRecordCount() != want.GetRecordCount() {
+			t.Fatalf("block %d: %d records, want %d", i, got.GetRecordCount(), want.GetRecordCount())
+		}
+		for rid := 0; rid < want.GetRecordCount(); rid++ {
+			for _, field := range uap.Fields() {
+				gotItem, _, _ := got.GetDataItemFromRecord(field.DataItem, rid)
+				wantItem, _, _ := want.GetDataItemFromRecord(field.DataItem, rid)
+				if !reflect.DeepEqual(gotItem, wantItem) {
+					t.Errorf("block %d record %d: %s = %+v, want %+v", i, rid, field.DataItem, gotItem, wantItem)
+				}
+			}
+		}
+	}
+}
+
+// repeatedCat062Block encodes a Cat062 block of 8 tracks carrying the items
+// of a typical system track update
+func repeatedCat062Block(tb testing.TB) (asterix.UAP, []byte) {
+	tb.Helper()
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		tb.Fatalf("NewUAP() error = %v", err)
+	}
+	block, err := asterix.NewDataBlock(asterix.Cat062, uap)
+	if err != nil {
+		tb.Fatalf("NewDataBlock() error = %v", err)
+	}
+	for i := 0; i < 8; i++ {
+		record, err := asterix.NewRecord(asterix.Cat062, uap)
+		if err != nil {
+			tb.Fatalf("NewRecord() error = %v", err)
+		}
+		addr, ident := uint32(0x3C6586+i), "DLH4AB"
+		record.SetDataItem("I062/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100})
+		record.SetDataItem("I062/040", &v117.TrackNumber{Value: uint16(i)})
+		record.SetDataItem("I062/070", &v117.TimeOfTrackInformation{Time: 3600})
+		record.SetDataItem("I062/080", &v117.TrackStatus{})
+		record.SetDataItem("I062/105", &v117.CalculatedPositionWGS84{Latitude: 50.1, Longitude: 8.6})
+		record.SetDataItem("I062/380", &v117.AircraftDerivedData{
+			TargetAddress:        &addr,
+			TargetIdentification: &ident,
+			ModeSMBData: []v117.ModeSMB{
+				{BDS1: 4, BDS2: 0, Data: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}},
+				{BDS1: 5, BDS2: 0, Data: []byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17}},
+			},
+		})
+		if err := block.AddRecord(record); err != nil {
+			tb.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+	data, err := block.Encode()
+	if err != nil {
+		tb.Fatalf("Encode() error = %v", err)
+	}
+	return uap, data
+}
+
+// With record reuse, the items of a repeated block are decoded into those of
+// the previous one, so only what they hold by pointer is allocated anew
+func TestDecoder_RecordReuseAllocs(t *testing.T) {
+	uap, data := repeatedCat062Block(t)
+	allocs := func(opts ...asterix.DecoderOption) float64 {
+		decoder, err := asterix.NewDecoderWithOptions(append([]asterix.DecoderOption{asterix.WithUAPs(uap)}, opts...)...)
+		if err != nil {
+			t.Fatalf("NewDecoderWithOptions() error = %v", err)
+		}
+		return testing.AllocsPerRun(50, func() {
+			if _, err := decoder.Decode(bytes.NewReader(data)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	// Each of the 8 records carries 6 items; reuse must save at least one
+	// allocation per item
+	fresh, reuse := allocs(), allocs(asterix.WithRecordReuse())
+	if reuse > fresh-8*6 {
+		t.Errorf("allocations per block = %v with reuse, %v without, want at least %d fewer", reuse, fresh, 8*6)
+	}
+}
+
+func BenchmarkDecoder_RepeatedBlock(b *testing.B) {
+	uap, data := repeatedCat062Block(b)
+
+	for _, bc := range []struct {
+		name string
+		opts []asterix.DecoderOption
+	}{
+		{"fresh", nil},
+		{"reuse", []asterix.DecoderOption{asterix.WithRecordReuse()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			decoder, err := asterix.NewDecoderWithOptions(append([]asterix.DecoderOption{asterix.WithUAPs(uap)}, bc.opts...)...)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := decoder.Decode(bytes.NewReader(data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// asterix/dedup.go
+package asterix
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// DedupWriter encodes data blocks to an underlying writer, dropping records
+// whose checksum was already written within a configurable time window.
+// Block framing is preserved: each input block yields at most one output
+// block, and a block whose records were all dropped is not written at all.
+type DedupWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	window time.Duration
+	seen   map[uint64]time.Time
+	order  []seenRecord // Admitted checksums, oldest first, from head on
+	head   int
+	now    func() time.Time
+
+	recordsSeen    uint64
+	recordsWritten uint64
+	recordsDropped uint64
+}
+
+// seenRecord is a checksum and the time it was admitted at
+type seenRecord struct {
+	sum uint64
+	at  time.Time
+}
+
+// NewDedupWriter creates a DedupWriter writing to w. A record is considered a
+// duplicate if an identical record was written less than window ago.
+func NewDedupWriter(w io.Writer, window time.Duration) *DedupWriter {
+	return &DedupWriter{
+		w:      w,
+		window: window,
+		seen:   make(map[uint64]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Add filters the block's records and writes the remaining ones as a single
+// encoded block
+func (d *DedupWriter) Add(block *DataBlock) error {
+	if block == nil {
+		return fmt.Errorf("%w: block cannot be nil", ErrInvalidMessage)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.expire(now)
+
+	out, err := NewDataBlock(block.category, block.uap)
+	if err != nil {
+		return fmt.Errorf("creating output block: %w", err)
+	}
+
+	var admitted []uint64
+	for i, record := range block.records {
+		d.recordsSeen++
+
+		sum, err := record.Checksum()
+		if err != nil {
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+		if _, dup := d.seen[sum]; dup {
+			d.recordsDropped++
+			continue
+		}
+
+		if err := out.AddRecord(record); err != nil {
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+		d.seen[sum] = now
+		d.order = append(d.order, seenRecord{sum: sum, at: now})
+		admitted = append(admitted, sum)
+	}
+
+	if out.Length() == 0 {
+		return nil
+	}
+
+	data, err := out.Encode()
+	if err != nil {
+		d.forget(admitted)
+		return fmt.Errorf("encoding block: %w", err)
+	}
+	if _, err := d.w.Write(data); err != nil {
+		d.forget(admitted)
+		return fmt.Errorf("writing block: %w", err)
+	}
+
+	d.recordsWritten += uint64(len(admitted))
+	return nil
+}
+
+// Stats returns the number of records seen, written and dropped as duplicates
+func (d *DedupWriter) Stats() (seen, written, dropped uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.recordsSeen, d.recordsWritten, d.recordsDropped
+}
+
+// expire removes checksums older than the window, walking them from the
+// oldest so that only expired ones are visited. Entries of checksums that
+// were forgotten, or admitted again since, no longer match seen and are
+// dropped from the order only.
+func (d *DedupWriter) expire(now time.Time) {
+	for d.head < len(d.order) && now.Sub(d.order[d.head].at) >= d.window {
+		r := d.order[d.head]
+		if at, ok := d.seen[r.sum]; ok && at.Equal(r.at) {
+			delete(d.seen, r.sum)
+		}
+		d.head++
+	}
+
+	// Reclaim the expired prefix once it is the larger part of the slice
+	if d.head > len(d.order)/2 {
+		d.order = append(d.order[:0], d.order[d.head:]...)
+		d.head = 0
+	}
+}
+
+// forget removes checksums of records that were not actually written. Their
+// entries in the order are left for expire to drop.
+func (d *DedupWriter) forget(sums []uint64) {
+	for _, sum := range sums {
+		delete(d.seen, sum)
+	}
+}
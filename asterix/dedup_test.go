@@ -0,0 +1,76 @@
+// asterix/dedup_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+func TestDedupWriter(t *testing.T) {
+	uap := newCat021UAP(t)
+	out := new(bytes.Buffer)
+	w := asterix.NewDedupWriter(out, time.Minute)
+
+	first, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	first.AddRecord(newCat021Record(t, uap, 1, 1, 0x000001))
+	first.AddRecord(newCat021Record(t, uap, 1, 1, 0x000002))
+	first.AddRecord(newCat021Record(t, uap, 1, 1, 0x000001))
+
+	// Every record of the second block was already written
+	second, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	second.AddRecord(newCat021Record(t, uap, 1, 1, 0x000002))
+
+	for _, block := range []*asterix.DataBlock{first, second} {
+		if err := w.Add(block); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(out.Bytes()); err != nil {
+		t.Fatalf("Decode() of output error = %v (output must be exactly one block)", err)
+	}
+	if decoded.Length() != 2 {
+		t.Errorf("output records = %d, want 2", decoded.Length())
+	}
+
+	seen, written, dropped := w.Stats()
+	if seen != 4 || written != 2 || dropped != 2 {
+		t.Errorf("Stats() = %d/%d/%d, want 4/2/2", seen, written, dropped)
+	}
+}
+
+func TestDedupWriter_Expiry(t *testing.T) {
+	uap := newCat021UAP(t)
+	out := new(bytes.Buffer)
+
+	// With an empty window, checksums expire before the next block
+	w := asterix.NewDedupWriter(out, 0)
+	for i := 0; i < 3; i++ {
+		block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		block.AddRecord(newCat021Record(t, uap, 1, 1, 0x000001))
+		if err := w.Add(block); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	seen, written, dropped := w.Stats()
+	if seen != 3 || written != 3 || dropped != 0 {
+		t.Errorf("Stats() = %d/%d/%d, want 3/3/0", seen, written, dropped)
+	}
+}
@@ -0,0 +1,110 @@
+// asterix/describe.go
+package asterix
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// hexDumpWidth is the number of bytes per line of a HexDump
+const hexDumpWidth = 16
+
+// ItemView pairs a data item's UAP metadata with its rendered value
+type ItemView struct {
+	FRN         int
+	ID          string
+	Description string
+	Value       string // String() of the item, or %v when it has none
+	Raw         []byte // Encoded bytes of the item; nil if it failed to encode
+}
+
+// Describe returns a view of every present item in FRN order, intended for
+// front-ends that display decoded records generically.
+func (r *Record) Describe() []ItemView {
+	views := make([]ItemView, 0, len(r.items))
+	buf := new(bytes.Buffer)
+
+	for _, field := range r.uap.Fields() {
+		item, exists := r.items[field.DataItem]
+		if !exists {
+			continue
+		}
+
+		view := ItemView{
+			FRN:         int(field.FRN),
+			ID:          field.DataItem,
+			Description: field.Description,
+		}
+		if s, ok := item.(fmt.Stringer); ok {
+			view.Value = s.String()
+		} else {
+			view.Value = fmt.Sprintf("%v", item)
+		}
+
+		buf.Reset()
+		if _, err := item.Encode(buf); err == nil {
+			view.Raw = append([]byte(nil), buf.Bytes()...)
+		}
+
+		views = append(views, view)
+	}
+
+	return views
+}
+
+// HexDump returns a hex listing of the record's encoding with a marker such
+// as [I021/010] at each item boundary. Each line starts with the offset of
+// its first byte; items longer than 16 bytes continue on further lines. The
+// record is not validated against its UAP, so incomplete records can be
+// dumped too; items that fail to encode are listed with their error.
+func (r *Record) HexDump() string {
+	type segment struct {
+		label string
+		data  []byte
+		err   error
+	}
+
+	var segments []segment
+	buf := new(bytes.Buffer)
+	if _, err := r.fspec.Encode(buf); err != nil {
+		segments = append(segments, segment{label: "FSPEC", err: err})
+	} else {
+		segments = append(segments, segment{label: "FSPEC", data: append([]byte(nil), buf.Bytes()...)})
+	}
+	for _, id := range layoutOf(r).items {
+		item, exists := r.items[id]
+		if !exists {
+			segments = append(segments, segment{label: id, err: fmt.Errorf("marked in FSPEC but not present")})
+			continue
+		}
+		buf.Reset()
+		if _, err := item.Encode(buf); err != nil {
+			segments = append(segments, segment{label: id, err: err})
+			continue
+		}
+		segments = append(segments, segment{label: id, data: append([]byte(nil), buf.Bytes()...)})
+	}
+
+	width := 0
+	for _, seg := range segments {
+		width = max(width, len(seg.label)+2)
+	}
+
+	var sb strings.Builder
+	offset := 0
+	for _, seg := range segments {
+		marker := "[" + seg.label + "]"
+		if seg.err != nil {
+			fmt.Fprintf(&sb, "%04X  %-*s  encode error: %v\n", offset, width, marker, seg.err)
+			continue
+		}
+		for start := 0; start < len(seg.data); start += hexDumpWidth {
+			end := min(start+hexDumpWidth, len(seg.data))
+			fmt.Fprintf(&sb, "%04X  %-*s  % X\n", offset+start, width, marker, seg.data[start:end])
+			marker = ""
+		}
+		offset += len(seg.data)
+	}
+	return sb.String()
+}
@@ -49,19 +49,20 @@ func newCategoryEncoder(uap UAP) (*CategoryEncoder, error) {
 	}
 
 	// Pre-compile field specifications
-	for _, field := range uap.Fields() {
-		spec := FieldSpec{
-			FRN:      field.FRN,
-			DataItem: field.DataItem,
-			Type:     field.Type,
-			Length:   field.Length,
-		}
-		ce.fieldSpecs = append(ce.fieldSpecs, spec)
-	}
+	ce.fieldSpecs = compileFieldSpecs(uap)
 
 	return ce, nil
 }
 
+// specsFor returns the field specifications laying out a record with the
+// given items, those of the selected profile for a UAPSelector
+func (ce *CategoryEncoder) specsFor(items map[string]DataItem) []FieldSpec {
+	if _, ok := ce.uap.(UAPSelector); ok {
+		return compileFieldSpecs(profileOf(ce.uap, items))
+	}
+	return ce.fieldSpecs
+}
+
 // Encode writes an AsterixMessage to an io.Writer
 func (e *Encoder) Encode(writer io.Writer, msg *AsterixMessage) error {
 	// Validate message
@@ -76,7 +77,8 @@ func (e *Encoder) Encode(writer io.Writer, msg *AsterixMessage) error {
 	}
 
 	// Serialize message
-	buf := new(bytes.Buffer)
+	buf := scratchBuffers.Get()
+	defer scratchBuffers.Put(buf)
 
 	// Write category
 	if err := buf.WriteByte(byte(msg.Category)); err != nil {
@@ -90,9 +92,11 @@ func (e *Encoder) Encode(writer io.Writer, msg *AsterixMessage) error {
 
 	// Encode each record
 	for i, record := range msg.records {
+		specs := ce.specsFor(record)
+
 		// Create FSPEC
 		fspec := NewFSPEC()
-		for _, spec := range ce.fieldSpecs {
+		for _, spec := range specs {
 			if _, exists := record[spec.DataItem]; exists {
 				if err := fspec.SetFRN(spec.FRN); err != nil {
 					return fmt.Errorf("record %d: setting FRN for %s: %w",
@@ -107,7 +111,7 @@ func (e *Encoder) Encode(writer io.Writer, msg *AsterixMessage) error {
 		}
 
 		// Write items in FRN order
-		for _, spec := range ce.fieldSpecs {
+		for _, spec := range specs {
 			item, exists := record[spec.DataItem]
 			if !exists {
 				continue
@@ -127,6 +131,10 @@ func (e *Encoder) Encode(writer io.Writer, msg *AsterixMessage) error {
 
 	// Update length field
 	data := buf.Bytes()
+	if len(data) > MaxBlockLength {
+		return fmt.Errorf("%w: encoded block is %d bytes, exceeds maximum of %d",
+			ErrInvalidLength, len(data), MaxBlockLength)
+	}
 	binary.BigEndian.PutUint16(data[1:3], uint16(len(data)))
 
 	// Write to output
@@ -141,7 +149,8 @@ func (e *Encoder) EncodeBatch(cat Category, records []map[string]DataItem) ([]by
 		return nil, fmt.Errorf("%w: %d", ErrUnknownCategory, cat)
 	}
 
-	buf := new(bytes.Buffer)
+	buf := scratchBuffers.Get()
+	defer scratchBuffers.Put(buf)
 
 	// Write category
 	if err := buf.WriteByte(byte(ce.category)); err != nil {
@@ -155,9 +164,11 @@ func (e *Encoder) EncodeBatch(cat Category, records []map[string]DataItem) ([]by
 
 	// Encode each record
 	for i, items := range records {
+		specs := ce.specsFor(items)
+
 		// Create FSPEC
 		fspec := NewFSPEC()
-		for _, spec := range ce.fieldSpecs {
+		for _, spec := range specs {
 			if _, exists := items[spec.DataItem]; exists {
 				if err := fspec.SetFRN(spec.FRN); err != nil {
 					return nil, fmt.Errorf("record %d: setting FRN for %s: %w",
@@ -172,7 +183,7 @@ func (e *Encoder) EncodeBatch(cat Category, records []map[string]DataItem) ([]by
 		}
 
 		// Write items in FRN order
-		for _, spec := range ce.fieldSpecs {
+		for _, spec := range specs {
 			item, exists := items[spec.DataItem]
 			if !exists {
 				continue
@@ -192,7 +203,11 @@ func (e *Encoder) EncodeBatch(cat Category, records []map[string]DataItem) ([]by
 
 	// Update length
 	data := buf.Bytes()
+	if len(data) > MaxBlockLength {
+		return nil, fmt.Errorf("%w: encoded block is %d bytes, exceeds maximum of %d",
+			ErrInvalidLength, len(data), MaxBlockLength)
+	}
 	binary.BigEndian.PutUint16(data[1:3], uint16(len(data)))
 
-	return data, nil
+	return bytes.Clone(data), nil
 }
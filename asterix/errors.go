@@ -2,6 +2,7 @@
 package asterix
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -19,10 +20,20 @@ var (
 	ErrUAPNotDefined   = fmt.Errorf("UAP not defined for category")
 	ErrUnknownCategory = fmt.Errorf("unknown category")
 
+	// ErrReservedCategory is returned for a category byte that no data block
+	// may carry, which usually means the data is misframed
+	ErrReservedCategory = fmt.Errorf("reserved category")
+
+	// ErrUnsupportedVersion is returned when a category is known but no UAP
+	// is available for the requested edition
+	ErrUnsupportedVersion = fmt.Errorf("unsupported UAP version")
+
 	// Additional error types for better error handling
 	ErrBufferTooShort  = fmt.Errorf("buffer too short")
 	ErrCorruptData     = fmt.Errorf("corrupt or malformed data")
 	ErrDecodingFailure = fmt.Errorf("failed to decode data")
+	ErrFXDepthExceeded = fmt.Errorf("FX extension depth exceeded")
+	ErrTrailingBytes   = fmt.Errorf("trailing bytes in data block")
 )
 
 // ValidationError provides detailed context for validation failures
@@ -42,13 +53,17 @@ func (e *ValidationError) Unwrap() error {
 	return ErrInvalidField
 }
 
-// DecodeError provides rich context about where a decoding error occurred
+// DecodeError provides rich context about where a decoding error occurred.
+// Record.Decode reports the offset of the failing item from the start of the
+// record; DataBlock.Decode and the Decoder turn it into an offset from the
+// start of the block and fill in the record index.
 type DecodeError struct {
 	Category   Category
 	Message    string
-	DataItem   string
-	Position   int
-	BufferSize int
+	Record     int    // Index of the record in its block, -1 when not known
+	DataItem   string // ID of the item being decoded, if any
+	Position   int    // Offset of the item, or of the record without an item
+	BufferSize int    // Length of the data Position is an offset into
 	Cause      error
 }
 
@@ -56,6 +71,10 @@ func (e *DecodeError) Error() string {
 	var builder strings.Builder
 	builder.WriteString(fmt.Sprintf("decoding error in Category %d", e.Category))
 
+	if e.Record >= 0 {
+		builder.WriteString(fmt.Sprintf(", record %d", e.Record))
+	}
+
 	if e.DataItem != "" {
 		builder.WriteString(fmt.Sprintf(", item %s", e.DataItem))
 	}
@@ -79,17 +98,44 @@ func (e *DecodeError) Unwrap() error {
 	return e.Cause
 }
 
-// IsDecodeError checks if an error is or wraps a DecodeError
+// IsDecodeError checks if an error is or wraps a DecodeError, or otherwise
+// reports a decoding failure
 func IsDecodeError(err error) bool {
-	return err != nil && (strings.Contains(err.Error(), "decoding") ||
+	var de *DecodeError
+	return errors.As(err, &de) || err != nil && (strings.Contains(err.Error(), "decoding") ||
 		strings.Contains(err.Error(), "decode"))
 }
 
+// IsUnknownCategory reports whether err is or wraps ErrUnknownCategory, i.e.
+// no UAP is registered for the category at all
+func IsUnknownCategory(err error) bool {
+	return errors.Is(err, ErrUnknownCategory)
+}
+
+// IsReservedCategory reports whether err is or wraps ErrReservedCategory,
+// i.e. the category byte is reserved and the block cannot be valid
+func IsReservedCategory(err error) bool {
+	return errors.Is(err, ErrReservedCategory)
+}
+
+// IsUnsupportedVersion reports whether err is or wraps ErrUnsupportedVersion,
+// i.e. the category is supported but not in the requested edition
+func IsUnsupportedVersion(err error) bool {
+	return errors.Is(err, ErrUnsupportedVersion)
+}
+
+// IsMandatoryFieldMissing reports whether err is or wraps ErrMandatoryField,
+// i.e. a record lacks an item its UAP requires
+func IsMandatoryFieldMissing(err error) bool {
+	return errors.Is(err, ErrMandatoryField)
+}
+
 // NewDecodeError creates a new DecodeError with the given parameters
 func NewDecodeError(category Category, message string, cause error) *DecodeError {
 	return &DecodeError{
 		Category: category,
 		Message:  message,
+		Record:   -1,
 		Cause:    cause,
 	}
 }
@@ -100,6 +146,12 @@ func (e *DecodeError) WithDataItem(dataItem string) *DecodeError {
 	return e
 }
 
+// WithRecord adds the index of the record to a DecodeError
+func (e *DecodeError) WithRecord(index int) *DecodeError {
+	e.Record = index
+	return e
+}
+
 // WithPosition adds position context to a DecodeError
 func (e *DecodeError) WithPosition(pos, bufSize int) *DecodeError {
 	e.Position = pos
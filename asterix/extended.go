@@ -0,0 +1,84 @@
+// asterix/extended.go
+package asterix
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// DefaultMaxFXDepth is the default maximum number of octets accepted in a
+// single FX-extended chain (extended items and compound primary subfields).
+// Real-world items rarely need more than 4 octets, so this is generous while
+// still bounding the work done on malformed or hostile input.
+const DefaultMaxFXDepth = 16
+
+// ReadFXChain reads octets from the buffer until one with the FX bit (bit 1)
+// cleared is found. It returns all octets read, including the terminating one.
+// An error wrapping ErrFXDepthExceeded is returned if the chain is longer
+// than maxOctets. A maxOctets of 0 or less applies DefaultMaxFXDepth.
+func ReadFXChain(buf *bytes.Buffer, maxOctets int) ([]byte, error) {
+	if maxOctets <= 0 {
+		maxOctets = DefaultMaxFXDepth
+	}
+
+	octets := make([]byte, 0, 4)
+	for {
+		if len(octets) >= maxOctets {
+			return octets, fmt.Errorf("%w: chain longer than %d octets",
+				ErrFXDepthExceeded, maxOctets)
+		}
+
+		b, err := buf.ReadByte()
+		if err != nil {
+			return octets, fmt.Errorf("%w: FX chain truncated after %d octets",
+				ErrBufferTooShort, len(octets))
+		}
+		octets = append(octets, b)
+
+		if b&0x01 == 0 {
+			return octets, nil
+		}
+	}
+}
+
+// CheckFXChain inspects data without consuming it and verifies that the FX
+// chain starting at data[0] terminates within maxOctets octets. A chain that
+// is merely truncated is not reported; that is left to the item decoder.
+// A maxOctets of 0 or less applies DefaultMaxFXDepth.
+func CheckFXChain(data []byte, maxOctets int) error {
+	if maxOctets <= 0 {
+		maxOctets = DefaultMaxFXDepth
+	}
+
+	for i, b := range data {
+		if b&0x01 == 0 {
+			return nil
+		}
+		if i+1 >= maxOctets {
+			return fmt.Errorf("%w: chain longer than %d octets",
+				ErrFXDepthExceeded, maxOctets)
+		}
+	}
+	return nil
+}
+
+// FXDepthLimiter is implemented by a data item whose Decode reads an FX
+// chain of unbounded length, such as an extended item or the extents of a
+// compound subfield. Decoders configured with WithDecoderMaxDepth or
+// DataBlock.SetMaxFXDepth pass their depth to such items before decoding
+// them; the item bounds its chains with ReadFXChain(buf, octets).
+type FXDepthLimiter interface {
+	SetMaxFXDepth(octets int)
+}
+
+// limitFXDepth passes octets to item if it is an FXDepthLimiter. The default
+// depth is not passed, so that items keep their zero value unless a decoder
+// was configured otherwise.
+func limitFXDepth(item DataItem, octets int) {
+	if octets <= 0 || octets == DefaultMaxFXDepth {
+		return
+	}
+	if l, ok := item.(FXDepthLimiter); ok {
+		l.SetMaxFXDepth(octets)
+	}
+}
@@ -0,0 +1,54 @@
+// asterix/geo/distance_test.go
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix/geo"
+)
+
+func TestHaversine(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		wantKm                 float64
+	}{
+		{"London-Paris", 51.5074, -0.1278, 48.8566, 2.3522, 343.5},
+		{"Frankfurt-Munich", 50.1109, 8.6821, 48.1351, 11.5820, 304.0},
+		{"New York-Los Angeles", 40.7128, -74.0060, 34.0522, -118.2437, 3936},
+		{"Sydney-Tokyo", -33.8688, 151.2093, 35.6762, 139.6503, 7823},
+		{"same point", 50, 8, 50, 8, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := geo.Haversine(tt.lat1, tt.lon1, tt.lat2, tt.lon2) / 1000
+			if math.Abs(got-tt.wantKm) > tt.wantKm*0.005+0.001 {
+				t.Errorf("Haversine() = %.1f km, want %.1f km", got, tt.wantKm)
+			}
+			if back := geo.Haversine(tt.lat2, tt.lon2, tt.lat1, tt.lon1) / 1000; math.Abs(back-got) > 1e-9 {
+				t.Errorf("Haversine() not symmetric: %.6f vs %.6f km", got, back)
+			}
+		})
+	}
+}
+
+func TestBearing(t *testing.T) {
+	tests := []struct {
+		name       string
+		lat2, lon2 float64
+		want       float64
+	}{
+		{"north", 1, 0, 0},
+		{"east", 0, 1, 90},
+		{"south", -1, 0, 180},
+		{"west", 0, -1, 270},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := geo.Bearing(0, 0, tt.lat2, tt.lon2); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Bearing() = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
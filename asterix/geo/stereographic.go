@@ -0,0 +1,81 @@
+// asterix/geo/stereographic.go
+
+// Package geo provides the coordinate conversions needed to place sensor
+// reports given in local co-ordinates on a WGS-84 map.
+package geo
+
+import "math"
+
+// WGS-84 ellipsoid parameters
+const (
+	SemiMajorAxis = 6378137.0
+	Flattening    = 1 / 298.257223563
+)
+
+// Stereographic is an oblique stereographic projection tangent at a
+// reference point, typically the radar site. Local co-ordinates are metres
+// east (x) and north (y) of the reference.
+//
+// The ellipsoid is approximated by a sphere with the Gaussian radius of
+// curvature at the reference latitude, which keeps errors well below the
+// resolution of radar plots within typical surveillance ranges.
+type Stereographic struct {
+	Latitude  float64 // Reference latitude (degrees)
+	Longitude float64 // Reference longitude (degrees)
+}
+
+// Radius returns the Gaussian radius of curvature at the reference latitude
+// in metres.
+func (s Stereographic) Radius() float64 {
+	e2 := Flattening * (2 - Flattening)
+	sinPhi := math.Sin(s.Latitude * math.Pi / 180)
+	w := 1 - e2*sinPhi*sinPhi
+	return SemiMajorAxis * math.Sqrt(1-e2) / w
+}
+
+// ToLatLon converts local co-ordinates in metres to WGS-84 latitude and
+// longitude in degrees.
+func (s Stereographic) ToLatLon(x, y float64) (lat, lon float64) {
+	phi0 := s.Latitude * math.Pi / 180
+	lambda0 := s.Longitude * math.Pi / 180
+
+	rho := math.Hypot(x, y)
+	if rho == 0 {
+		return s.Latitude, s.Longitude
+	}
+
+	c := 2 * math.Atan(rho/(2*s.Radius()))
+	sinC, cosC := math.Sincos(c)
+	sinPhi0, cosPhi0 := math.Sincos(phi0)
+
+	phi := math.Asin(cosC*sinPhi0 + y*sinC*cosPhi0/rho)
+	lambda := lambda0 + math.Atan2(x*sinC, rho*cosPhi0*cosC-y*sinPhi0*sinC)
+
+	return phi * 180 / math.Pi, normalizeLongitude(lambda * 180 / math.Pi)
+}
+
+// FromLatLon converts WGS-84 latitude and longitude in degrees to local
+// co-ordinates in metres.
+func (s Stereographic) FromLatLon(lat, lon float64) (x, y float64) {
+	phi0 := s.Latitude * math.Pi / 180
+	phi := lat * math.Pi / 180
+	dLambda := (lon - s.Longitude) * math.Pi / 180
+
+	sinPhi0, cosPhi0 := math.Sincos(phi0)
+	sinPhi, cosPhi := math.Sincos(phi)
+	sinDL, cosDL := math.Sincos(dLambda)
+
+	k := 2 * s.Radius() / (1 + sinPhi0*sinPhi + cosPhi0*cosPhi*cosDL)
+	x = k * cosPhi * sinDL
+	y = k * (cosPhi0*sinPhi - sinPhi0*cosPhi*cosDL)
+	return x, y
+}
+
+// normalizeLongitude wraps a longitude into [-180, 180)
+func normalizeLongitude(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
@@ -0,0 +1,45 @@
+// asterix/geo/stereographic_test.go
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix/geo"
+)
+
+func TestStereographic_RoundTrip(t *testing.T) {
+	ref := geo.Stereographic{Latitude: 50.0379, Longitude: 8.5622}
+
+	points := []struct{ x, y float64 }{
+		{0, 0},
+		{100000, 0},
+		{0, -150000},
+		{-200000, 250000},
+		{370000, -370000},
+	}
+	for _, p := range points {
+		lat, lon := ref.ToLatLon(p.x, p.y)
+		x, y := ref.FromLatLon(lat, lon)
+		if math.Abs(x-p.x) > 1e-6 || math.Abs(y-p.y) > 1e-6 {
+			t.Errorf("round trip (%g, %g) -> (%g, %g) -> (%g, %g)", p.x, p.y, lat, lon, x, y)
+		}
+	}
+}
+
+func TestStereographic_ToLatLon(t *testing.T) {
+	ref := geo.Stereographic{Latitude: 50, Longitude: 8}
+
+	// Along the meridian the projected distance is 2R·tan(c/2)
+	y := 100000.0
+	lat, lon := ref.ToLatLon(0, y)
+	want := 50 + 2*math.Atan(y/(2*ref.Radius()))*180/math.Pi
+	if math.Abs(lat-want) > 1e-9 || math.Abs(lon-8) > 1e-9 {
+		t.Errorf("ToLatLon(0, %g) = (%g, %g), want (%g, 8)", y, lat, lon, want)
+	}
+
+	// Gaussian radius at 50°N
+	if r := ref.Radius(); math.Abs(r-6381823) > 1 {
+		t.Errorf("Radius() = %f, want ≈ 6381823", r)
+	}
+}
@@ -0,0 +1,134 @@
+// asterix/ident/ident.go
+
+// Package ident converts aircraft identifications to and from the 6-bit
+// character set of ICAO Annex 10 Vol IV, as carried in I021/170, I048/240,
+// I062/245 and subfield #2 of I062/380.
+package ident
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+const (
+	// Length is the number of characters of an identification
+	Length = 8
+	// Size is the number of octets the packed characters occupy
+	Size = 6
+)
+
+// Fill is the character the table shows for reserved codes. It never
+// appears in a valid identification.
+const Fill = '#'
+
+// table maps each 6-bit code to its character. Only codes 1-26 (A-Z), 32
+// (space) and 48-57 (0-9) are assigned; all others are reserved.
+const table = "#ABCDEFGHIJKLMNOPQRSTUVWXYZ##### ###############0123456789######"
+
+// codes is the inverse of table, holding code+1 so that zero means
+// unassigned
+var codes = func() (c [128]byte) {
+	for code := 0; code < len(table); code++ {
+		if table[code] != Fill {
+			c[table[code]] = byte(code) + 1
+		}
+	}
+	return c
+}()
+
+// Char returns the character of a 6-bit code. ok is false for reserved
+// codes and for values above 63.
+func Char(code byte) (c byte, ok bool) {
+	if int(code) >= len(table) || table[code] == Fill {
+		return Fill, false
+	}
+	return table[code], true
+}
+
+// Code returns the 6-bit code of c. ok is false for characters outside the
+// set, which includes Fill and lower case letters.
+func Code(c byte) (code byte, ok bool) {
+	if int(c) >= len(codes) || codes[c] == 0 {
+		return 0, false
+	}
+	return codes[c] - 1, true
+}
+
+// Unpack splits 6 octets into 8 codes, most significant bits first
+func Unpack(data [Size]byte) (codes [Length]byte) {
+	for i := 0; i < Length; i += 4 {
+		b := data[i/4*3:]
+		codes[i] = b[0] >> 2
+		codes[i+1] = (b[0]&0x03)<<4 | b[1]>>4
+		codes[i+2] = (b[1]&0x0F)<<2 | b[2]>>6
+		codes[i+3] = b[2] & 0x3F
+	}
+	return codes
+}
+
+// Pack joins 8 codes into 6 octets. Only the low 6 bits of each code are
+// used.
+func Pack(codes [Length]byte) (data [Size]byte) {
+	for i := 0; i < Length; i += 4 {
+		b := data[i/4*3:]
+		b[0] = codes[i]<<2 | (codes[i+1]&0x3F)>>4
+		b[1] = codes[i+1]<<4 | (codes[i+2]&0x3F)>>2
+		b[2] = codes[i+2]<<6 | codes[i+3]&0x3F
+	}
+	return data
+}
+
+// Decode returns the identification packed in the first 6 octets of data,
+// without its trailing space padding. A reserved code anywhere, padding
+// included, is an error wrapping asterix.ErrCorruptData.
+func Decode(data []byte) (string, error) {
+	if len(data) < Size {
+		return "", fmt.Errorf("%w: identification needs %d bytes, got %d", asterix.ErrBufferTooShort, Size, len(data))
+	}
+
+	var chars [Length]byte
+	for i, code := range Unpack([Size]byte(data)) {
+		c, ok := Char(code)
+		if !ok {
+			return "", fmt.Errorf("%w: reserved character code %d at position %d (raw bytes: %X)",
+				asterix.ErrCorruptData, code, i, data[:Size])
+		}
+		chars[i] = c
+	}
+	return strings.TrimRight(string(chars[:]), " "), nil
+}
+
+// Encode packs s into 6 octets, padding it with spaces to 8 characters
+func Encode(s string) ([Size]byte, error) {
+	if err := Validate(s); err != nil {
+		return [Size]byte{}, err
+	}
+
+	var chars [Length]byte
+	for i := range chars {
+		chars[i] = codes[' '] - 1
+		if i < len(s) {
+			chars[i] = codes[s[i]] - 1
+		}
+	}
+	return Pack(chars), nil
+}
+
+// Validate reports whether s fits in 8 characters of the set: upper case
+// letters, digits and spaces. Errors wrap asterix.ErrInvalidField.
+func Validate(s string) error {
+	if len(s) > Length {
+		return fmt.Errorf("%w: identification %q longer than %d characters", asterix.ErrInvalidField, s, Length)
+	}
+	for i, r := range s {
+		if r > 0x7F {
+			return fmt.Errorf("%w: invalid character '%c' at position %d", asterix.ErrInvalidField, r, i)
+		}
+		if _, ok := Code(byte(r)); !ok {
+			return fmt.Errorf("%w: invalid character '%c' at position %d", asterix.ErrInvalidField, r, i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// asterix/ident/ident_test.go
+package ident_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/ident"
+)
+
+// TestCharTable decodes every 6-bit code in every position and encodes the
+// assigned ones back to the same bytes
+func TestCharTable(t *testing.T) {
+	assigned := 0
+	for code := byte(0); code < 64; code++ {
+		c, ok := ident.Char(code)
+		want := code >= 1 && code <= 26 || code == 32 || code >= 48 && code <= 57
+		if ok != want {
+			t.Fatalf("Char(%d) ok = %v, want %v", code, ok, want)
+		}
+
+		for pos := 0; pos < ident.Length; pos++ {
+			var codes [ident.Length]byte
+			for i := range codes {
+				codes[i] = 32
+			}
+			codes[pos] = code
+			data := ident.Pack(codes)
+			if got := ident.Unpack(data); got != codes {
+				t.Fatalf("Unpack(Pack(%v)) = %v", codes, got)
+			}
+
+			s, err := ident.Decode(data[:])
+			if !ok {
+				if !errors.Is(err, asterix.ErrCorruptData) {
+					t.Fatalf("Decode of reserved code %d at %d: err = %v, want ErrCorruptData", code, pos, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("Decode of code %d at %d: %v", code, pos, err)
+			}
+			back, err := ident.Encode(s)
+			if err != nil {
+				t.Fatalf("Encode(%q): %v", s, err)
+			}
+			if back != data {
+				t.Fatalf("Encode(%q) = % X, want % X", s, back, data)
+			}
+		}
+
+		if ok {
+			assigned++
+			if got, _ := ident.Code(c); got != code {
+				t.Errorf("Code(%q) = %d, want %d", c, got, code)
+			}
+		}
+	}
+	if assigned != 37 {
+		t.Errorf("%d assigned codes, want 37", assigned)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	got, err := ident.Encode("DLH4AB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [ident.Size]byte{0x10, 0xC2, 0x34, 0x04, 0x28, 0x20}
+	if got != want {
+		t.Errorf("Encode(DLH4AB) = % X, want % X", got, want)
+	}
+
+	for _, s := range []string{"DLH#1", "dlh4ab", "DLH-4AB", "DLH4ABCDE", "ÄLH"} {
+		if _, err := ident.Encode(s); !errors.Is(err, asterix.ErrInvalidField) {
+			t.Errorf("Encode(%q) err = %v, want ErrInvalidField", s, err)
+		}
+	}
+}
+
+func TestDecodeShort(t *testing.T) {
+	if _, err := ident.Decode([]byte{0x10, 0xC2, 0x34}); !errors.Is(err, asterix.ErrBufferTooShort) {
+		t.Errorf("err = %v, want ErrBufferTooShort", err)
+	}
+}
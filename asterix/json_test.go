@@ -0,0 +1,260 @@
+// asterix/json_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat048"
+	v132 "github.com/davidkohl/gobelix/cat/cat048/dataitems/v132"
+	"github.com/davidkohl/gobelix/cat/cat062"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// checkGolden compares got with testdata/name, rewriting the file with -update
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("writing %s: %v", path, err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("JSON differs from %s:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func marshalBlock(t *testing.T, block *asterix.DataBlock) []byte {
+	t.Helper()
+	got, err := json.MarshalIndent(block, "", "  ")
+	if err != nil {
+		t.Fatalf("MarshalIndent() error = %v", err)
+	}
+	return append(got, '\n')
+}
+
+func TestDataBlock_MarshalJSON_Cat021(t *testing.T) {
+	uap := newCat021UAP(t)
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	for _, addr := range []uint32{0x3C6544, 0x4CA2B1} {
+		if err := block.AddRecord(newCat021Record(t, uap, 25, 10, addr)); err != nil {
+			t.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+
+	checkGolden(t, "cat021_block.json", marshalBlock(t, block))
+}
+
+func TestDataBlock_MarshalJSON_Cat062(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	data := []byte{
+		62, 0x00, 0x11, // CAT, LEN
+		0x91, 0x1C, // FSPEC: 010, 070, 380, 040, 080
+		0x01, 0x02, // I062/010
+		0x00, 0x00, 0x80, // I062/070
+		0x80, 0xAB, 0xCD, 0xEF, // I062/380 target address
+		0x00, 0x2A, // I062/040
+		0x00, // I062/080
+	}
+	block, err := asterix.NewDataBlock(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.Decode(data); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	got := marshalBlock(t, block)
+	checkGolden(t, "cat062_block.json", got)
+
+	// Items keep FRN order, which a map would lose
+	order := []string{"I062/010", "I062/070", "I062/380", "I062/040", "I062/080"}
+	last := -1
+	for _, id := range order {
+		i := bytes.Index(got, []byte(`"`+id+`"`))
+		if i < last {
+			t.Errorf("%s out of FRN order", id)
+		}
+		last = i
+	}
+}
+
+func TestNewBlockFromJSON_RoundTrip(t *testing.T) {
+	uap := newCat021UAP(t)
+	fixture := []byte(`{
+		"category": 21,
+		"length": 19,
+		"records": [
+			{"I021/010": {"SAC": 25, "SIC": 10}, "I021/040": {"ATP": 1}, "I021/080": {"Address": 3958084}},
+			{"I021/010": "190A", "I021/040": "20", "I021/080": "4C A2 B1"}
+		]
+	}`)
+
+	block, err := asterix.NewBlockFromJSON(fixture, uap)
+	if err != nil {
+		t.Fatalf("NewBlockFromJSON() error = %v", err)
+	}
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !decoded.Equal(block) {
+		t.Errorf("decoded block differs from the JSON fixture")
+	}
+
+	// The golden Cat021 block is the same pair of records
+	want, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	for _, addr := range []uint32{0x3C6544, 0x4CA2B1} {
+		if err := want.AddRecord(newCat021Record(t, uap, 25, 10, addr)); err != nil {
+			t.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+	if !decoded.Equal(want) {
+		t.Errorf("decoded block differs from the records it was written from")
+	}
+}
+
+func TestNewBlockFromJSON_Errors(t *testing.T) {
+	uap := newCat021UAP(t)
+	tests := []struct {
+		name string
+		json string
+		want error
+	}{
+		{"unknown item", `{"records": [{"I021/010": "190A", "I021/040": "20", "I021/080": "3C6544", "I021/999": "00"}]}`,
+			asterix.ErrUnknownDataItem},
+		{"missing mandatory item", `{"records": [{"I021/010": "190A", "I021/040": "20"}]}`,
+			asterix.ErrMandatoryField},
+		{"wrong category", `{"category": 62, "records": []}`, asterix.ErrInvalidCategory},
+		{"undecodable value", `{"records": [{"I021/010": "SAC: 25, SIC: 10", "I021/040": "20", "I021/080": "3C6544"}]}`,
+			asterix.ErrInvalidField},
+		{"wrong length", `{"length": 5, "records": [{"I021/010": "190A", "I021/040": "20", "I021/080": "3C6544"}]}`,
+			asterix.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := asterix.NewBlockFromJSON([]byte(tt.json), uap)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("NewBlockFromJSON() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	_, err := asterix.NewBlockFromJSON([]byte(`{"records": [{"I021/010": "190A"}]}`), uap)
+	if !asterix.IsMandatoryFieldMissing(err) {
+		t.Errorf("IsMandatoryFieldMissing(%v) = false", err)
+	}
+}
+
+func TestDataBlock_JSONRoundTrip(t *testing.T) {
+	uap021 := newCat021UAP(t)
+	uap048, err := cat048.NewUAP(cat048.Version132)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	uap062, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	cat048Block, err := asterix.NewDataBlock(asterix.Cat048, uap048)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	record, err := asterix.NewRecord(asterix.Cat048, uap048)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	for id, item := range map[string]asterix.DataItem{
+		"I048/010": &common.DataSourceIdentifier{SAC: 25, SIC: 10},
+		"I048/140": &v132.TimeOfDay{Time: 43200.5},
+		"I048/020": &v132.TargetReportDescriptor{TYP: 5, SIM: true},
+		"I048/040": &v132.MeasuredPosition{RHO: 42.5, THETA: 90},
+		"I048/070": &v132.Mode3ACode{Code: 1234},
+		"I048/090": &v132.FlightLevel{Level: 350},
+		"I048/220": &v132.AircraftAddress{Address: 0x3C6544},
+		"I048/240": &v132.AircraftIdentification{Ident: "DLH4AB"},
+	} {
+		if err := record.SetDataItem(id, item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", id, err)
+		}
+	}
+	if err := cat048Block.AddRecord(record); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	cat048Data, err := cat048Block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	var body []byte
+	for _, record := range cat062Records(t, uap062) {
+		body = append(body, record...)
+	}
+	cat062Data := append([]byte{62, byte((3 + len(body)) >> 8), byte(3 + len(body))}, body...)
+
+	for _, tt := range []struct {
+		name string
+		uap  asterix.UAP
+		data []byte
+	}{
+		{"Cat021", uap021, encodeCat021Block(t, uap021, 0x3C6544, 0x4CA2B1)},
+		{"Cat048", uap048, cat048Data},
+		{"Cat062", uap062, cat062Data},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			block, err := asterix.NewDataBlock(tt.uap.Category(), tt.uap)
+			if err != nil {
+				t.Fatalf("NewDataBlock() error = %v", err)
+			}
+			if err := block.Decode(tt.data); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			doc, err := json.Marshal(block)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			back, err := asterix.NewBlockFromJSON(doc, tt.uap)
+			if err != nil {
+				t.Fatalf("NewBlockFromJSON() error = %v\n%s", err, doc)
+			}
+			encoded, err := back.Encode()
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(encoded, tt.data) {
+				t.Errorf("Encode() after the JSON round trip = % X, want % X", encoded, tt.data)
+			}
+		})
+	}
+}
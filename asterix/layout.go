@@ -0,0 +1,84 @@
+// asterix/layout.go
+package asterix
+
+import (
+	"container/list"
+	"sync"
+)
+
+// maxRecordLayouts bounds the number of layouts cached per UAP. Regular feeds
+// use a handful of item sets per UAP; once the bound is reached, the least
+// recently used layout makes room for the new one.
+const maxRecordLayouts = 256
+
+// recordLayout is the order in which the items of a record are encoded,
+// precomputed for one UAP and one set of present items
+type recordLayout struct {
+	items []string // IDs of the present items in FRN order
+}
+
+// layoutCache holds the layouts of one UAP by the FSPEC of the record, which
+// encodes the set of present items
+type layoutCache struct {
+	mu    sync.Mutex
+	m     map[string]*list.Element
+	order list.List // Of *layoutEntry, most recently used first
+}
+
+type layoutEntry struct {
+	fspec  string
+	layout *recordLayout
+}
+
+// layoutCacher is implemented by UAPs embedding BaseUAP, which caches the
+// layouts of their records
+type layoutCacher interface {
+	recordLayoutCache() *layoutCache
+}
+
+func (u *BaseUAP) recordLayoutCache() *layoutCache {
+	return &u.layouts
+}
+
+// layoutOf returns the encoding layout of the record, computing and caching
+// it on first use of its item set. A record whose items change gets a new
+// FSPEC and therefore a different layout. Layouts of UAPs not built on
+// BaseUAP are computed every time.
+func layoutOf(r *Record) *recordLayout {
+	lc, ok := r.uap.(layoutCacher)
+	if !ok {
+		return computeLayout(r)
+	}
+	c := lc.recordLayoutCache()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.m[string(r.fspec.bits)]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*layoutEntry).layout
+	}
+
+	layout := computeLayout(r)
+	if c.m == nil {
+		c.m = make(map[string]*list.Element)
+	}
+	if c.order.Len() >= maxRecordLayouts {
+		oldest := c.order.Back()
+		delete(c.m, oldest.Value.(*layoutEntry).fspec)
+		c.order.Remove(oldest)
+	}
+	fspec := string(r.fspec.bits)
+	c.m[fspec] = c.order.PushFront(&layoutEntry{fspec: fspec, layout: layout})
+	return layout
+}
+
+// computeLayout lists the UAP fields marked in the record's FSPEC
+func computeLayout(r *Record) *recordLayout {
+	layout := &recordLayout{}
+	for _, field := range r.uap.Fields() {
+		if r.fspec.GetFRN(field.FRN) {
+			layout.items = append(layout.items, field.DataItem)
+		}
+	}
+	return layout
+}
@@ -68,7 +68,7 @@ func (m *AsterixMessage) String() string {
 		sb.WriteString(fmt.Sprintf("Record #%d:\n", i+1))
 
 		if m.uap != nil {
-			fields := m.uap.Fields()
+			fields := profileOf(m.uap, record).Fields()
 
 			// Sort fields by FRN
 			sort.Slice(fields, func(i, j int) bool {
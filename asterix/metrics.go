@@ -0,0 +1,62 @@
+// asterix/metrics.go
+package asterix
+
+import "sync"
+
+// DecodeStats holds the counters of one category, as returned by
+// Decoder.Metrics
+type DecodeStats struct {
+	Messages uint64 // Messages decoded successfully
+	Records  uint64 // Records contained in those messages
+	Bytes    uint64 // Octets of those messages, headers included
+	Errors   uint64 // Messages of the category that failed to decode
+}
+
+// decodeMetrics accumulates DecodeStats per category. It is shared by a
+// decoder and its forks, so every method is safe for concurrent use.
+type decodeMetrics struct {
+	mu    sync.Mutex
+	stats map[Category]*DecodeStats
+}
+
+func newDecodeMetrics() *decodeMetrics {
+	return &decodeMetrics{stats: make(map[Category]*DecodeStats)}
+}
+
+// entry returns the counters of cat; the caller holds m.mu
+func (m *decodeMetrics) entry(cat Category) *DecodeStats {
+	s, ok := m.stats[cat]
+	if !ok {
+		s = &DecodeStats{}
+		m.stats[cat] = s
+	}
+	return s
+}
+
+// decoded counts a message decoded successfully
+func (m *decodeMetrics) decoded(msg *AsterixMessage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.entry(msg.Category)
+	s.Messages++
+	s.Records += uint64(len(msg.records))
+	s.Bytes += uint64(len(msg.RawMessage))
+}
+
+// failed counts a message of cat that failed to decode
+func (m *decodeMetrics) failed(cat Category) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entry(cat).Errors++
+}
+
+// snapshot returns a copy of the counters
+func (m *decodeMetrics) snapshot() map[Category]DecodeStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[Category]DecodeStats, len(m.stats))
+	for cat, s := range m.stats {
+		out[cat] = *s
+	}
+	return out
+}
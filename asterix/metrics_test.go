@@ -0,0 +1,75 @@
+// asterix/metrics_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat062"
+)
+
+func TestDecoder_Metrics(t *testing.T) {
+	cat021UAP := newCat021UAP(t)
+	cat062UAP, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	cat021Block := encodeCat021Block(t, cat021UAP, 1, 2)
+	cat062Block := []byte{
+		62, 0x00, 0x11,
+		0x91, 0x1C,
+		0x01, 0x02, 0x00, 0x00, 0x80, 0x80, 0xAB, 0xCD, 0xEF, 0x00, 0x2A, 0x00,
+	}
+	batch := [][]byte{
+		cat021Block,
+		cat062Block,
+		cat021Block,
+		{21, 0x00, 0x01}, // invalid length
+	}
+
+	parent, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(cat021UAP, cat062UAP), asterix.WithMetrics())
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+
+	// Forks decoding in parallel add to the same counters
+	const workers = 4
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(d *asterix.Decoder) {
+			defer wg.Done()
+			for _, data := range batch {
+				d.Decode(bytes.NewReader(data))
+			}
+		}(parent.Fork())
+	}
+	wg.Wait()
+
+	want := map[asterix.Category]asterix.DecodeStats{
+		asterix.Cat021: {Messages: 2 * workers, Records: 4 * workers, Bytes: uint64(2 * workers * len(cat021Block)), Errors: workers},
+		asterix.Cat062: {Messages: workers, Records: workers, Bytes: uint64(workers * len(cat062Block))},
+	}
+	got := parent.Metrics()
+	if len(got) != len(want) {
+		t.Errorf("Metrics() = %+v, want %+v", got, want)
+	}
+	for cat, w := range want {
+		if got[cat] != w {
+			t.Errorf("Metrics()[%s] = %+v, want %+v", cat, got[cat], w)
+		}
+	}
+
+	plain, err := asterix.NewDecoder(cat021UAP)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	if _, err := plain.Decode(bytes.NewReader(cat021Block)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if m := plain.Metrics(); m != nil {
+		t.Errorf("Metrics() without WithMetrics = %+v, want nil", m)
+	}
+}
@@ -0,0 +1,50 @@
+// asterix/modec/modec.go
+
+// Package modec converts Mode-C replies in Gilham (Gray) notation into
+// pressure altitude.
+package modec
+
+// Altitude decodes a 12-bit Mode-C code into pressure altitude in feet. The
+// code uses the bit order of ASTERIX, e.g. I048/100: C1 A1 C2 A2 C4 A4 B1 D1
+// B2 D2 B4 D4 from bit 12 down to bit 1.
+//
+// ok is false for codes that do not represent an altitude: the 100 ft pulses
+// C1 C2 C4 must not be all clear or form the illegal patterns 1x1, and D1 is
+// never used. Valid codes cover -1200 ft to 126700 ft in 100 ft steps.
+func Altitude(code uint16) (feet int, ok bool) {
+	bit := func(n uint) uint16 { return (code >> n) & 1 }
+	c1, a1, c2, a2, c4, a4 := bit(11), bit(10), bit(9), bit(8), bit(7), bit(6)
+	b1, d1, b2, d2, b4, d4 := bit(5), bit(4), bit(3), bit(2), bit(1), bit(0)
+
+	if code > 0xFFF || d1 != 0 {
+		return 0, false
+	}
+
+	// 500 ft increments: D2 D4 A1 A2 A4 B1 B2 B4 form a Gray code
+	n500 := grayToBinary([]uint16{d2, d4, a1, a2, a4, b1, b2, b4})
+
+	// 100 ft increments: C1 C2 C4 form a reflected code of five states
+	n100 := grayToBinary([]uint16{c1, c2, c4})
+	switch n100 {
+	case 0, 5, 6:
+		return 0, false
+	case 7:
+		n100 = 5
+	}
+
+	// The 100 ft count runs backwards in every odd 500 ft band
+	if n500%2 != 0 {
+		n100 = 6 - n100
+	}
+
+	return n500*500 + n100*100 - 1300, true
+}
+
+// grayToBinary converts Gray code bits, most significant first
+func grayToBinary(bits []uint16) int {
+	n := 0
+	for _, b := range bits {
+		n = n<<1 | (int(b) ^ (n & 1))
+	}
+	return n
+}
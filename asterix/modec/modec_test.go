@@ -0,0 +1,64 @@
+// asterix/modec/modec_test.go
+package modec_test
+
+import (
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix/modec"
+)
+
+func TestAltitude(t *testing.T) {
+	tests := []struct {
+		code uint16
+		feet int
+	}{
+		{0x080, -1200}, // C4
+		{0x200, -1000}, // C2
+		{0x20A, 0},     // C2 B2 B4
+		{0xA0A, 100},   // C1 C2 B2 B4
+		{0x228, 1000},  // C2 B1 B2
+		{0x362, 10000}, // C2 A2 A4 B1 B4
+		{0x661, 35000}, // A1 C2 A4 B1 D4
+		{0x084, 126700},
+	}
+	for _, tt := range tests {
+		feet, ok := modec.Altitude(tt.code)
+		if !ok || feet != tt.feet {
+			t.Errorf("Altitude(%03X) = %d, %v, want %d", tt.code, feet, ok, tt.feet)
+		}
+	}
+}
+
+func TestAltitude_Invalid(t *testing.T) {
+	for _, code := range []uint16{
+		0x000,  // no 100 ft pulse
+		0x00A,  // C1 C2 C4 clear with 500 ft pulses set
+		0x880,  // C1 C4: illegal 100 ft pattern
+		0x090,  // D1 is never used
+		0x1000, // wider than 12 bits
+	} {
+		if feet, ok := modec.Altitude(code); ok {
+			t.Errorf("Altitude(%03X) = %d, want invalid", code, feet)
+		}
+	}
+}
+
+func TestAltitude_OneCodePerAltitude(t *testing.T) {
+	seen := make(map[int]uint16)
+	for code := uint16(0); code < 0x1000; code++ {
+		feet, ok := modec.Altitude(code)
+		if !ok {
+			continue
+		}
+		if feet < -1200 || feet > 126700 || feet%100 != 0 {
+			t.Fatalf("Altitude(%03X) = %d, outside the 100 ft grid", code, feet)
+		}
+		if other, dup := seen[feet]; dup {
+			t.Fatalf("Altitude(%03X) and Altitude(%03X) both decode to %d", code, other, feet)
+		}
+		seen[feet] = code
+	}
+	if want := (126700+1200)/100 + 1; len(seen) != want {
+		t.Errorf("%d altitudes decoded, want %d", len(seen), want)
+	}
+}
@@ -0,0 +1,118 @@
+// asterix/monotonic.go
+package asterix
+
+import (
+	"sync"
+	"time"
+)
+
+// maxTimeRegressions bounds the regressions a Decoder holds between calls to
+// TimeRegressions
+const maxTimeRegressions = 1024
+
+// TimeRegression reports a record whose time of day went backward compared
+// to the previous record of the same category and data source
+type TimeRegression struct {
+	Category Category
+	SAC, SIC uint8
+	Record   int           // Index of the record in its message
+	Previous time.Duration // Time of day of the previous record
+	Current  time.Duration // Time of day of this record
+}
+
+// Regression returns how far the time of day went backward, accounting for
+// the wrap at midnight
+func (r TimeRegression) Regression() time.Duration {
+	return -todDelta(r.Previous, r.Current)
+}
+
+// timeSource identifies the sequence a record's timestamp belongs to
+type timeSource struct {
+	cat      Category
+	sac, sic uint8
+}
+
+// timeChecker tracks the last time of day seen per category and data source
+type timeChecker struct {
+	mu          sync.Mutex
+	tolerance   time.Duration
+	last        map[timeSource]time.Duration
+	regressions []TimeRegression
+}
+
+func newTimeChecker(tolerance time.Duration) *timeChecker {
+	return &timeChecker{
+		tolerance: tolerance,
+		last:      make(map[timeSource]time.Duration),
+	}
+}
+
+// check records a regression for every record of msg whose timestamp goes
+// backward by more than the tolerance. Records without a data source or a
+// time item are ignored.
+func (tc *timeChecker) check(msg *AsterixMessage, uap UAP) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	for i, items := range msg.records {
+		record := &Record{category: msg.Category, items: items, uap: uap}
+		tod, ok := record.Timestamp()
+		if !ok {
+			continue
+		}
+		key, ok := recordTimeSource(record)
+		if !ok {
+			continue
+		}
+
+		if prev, seen := tc.last[key]; seen && todDelta(prev, tod) < -tc.tolerance {
+			if len(tc.regressions) < maxTimeRegressions {
+				tc.regressions = append(tc.regressions, TimeRegression{
+					Category: key.cat,
+					SAC:      key.sac,
+					SIC:      key.sic,
+					Record:   i,
+					Previous: prev,
+					Current:  tod,
+				})
+			}
+		}
+		// Follow the feed even when it jumps back, so that a restarted
+		// source is reported once rather than on every record
+		tc.last[key] = tod
+	}
+}
+
+// drain returns the collected regressions and clears them
+func (tc *timeChecker) drain() []TimeRegression {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	regressions := tc.regressions
+	tc.regressions = nil
+	return regressions
+}
+
+// recordTimeSource returns the data source of the record
+func recordTimeSource(r *Record) (timeSource, bool) {
+	for _, field := range r.uap.Fields() {
+		if src, ok := r.items[field.DataItem].(SourceProvider); ok {
+			sac, sic := src.Source()
+			return timeSource{cat: r.category, sac: sac, sic: sic}, true
+		}
+	}
+	return timeSource{}, false
+}
+
+// todDelta returns cur - prev as times of day, taking the shorter way round
+// midnight
+func todDelta(prev, cur time.Duration) time.Duration {
+	const day = 24 * time.Hour
+	delta := cur - prev
+	switch {
+	case delta > day/2:
+		delta -= day
+	case delta < -day/2:
+		delta += day
+	}
+	return delta
+}
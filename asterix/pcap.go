@@ -0,0 +1,339 @@
+// asterix/pcap.go
+package asterix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Link types of the captures PcapSource understands
+const (
+	linkTypeNull     = 0   // BSD loopback
+	linkTypeEthernet = 1   // Ethernet II
+	linkTypeRaw      = 101 // Raw IPv4 or IPv6
+	linkTypeLinuxSLL = 113 // Linux cooked capture
+	linkTypeIPv4     = 228 // Raw IPv4
+	linkTypeIPv6     = 229 // Raw IPv6
+)
+
+// Magic numbers and block types of the pcap and pcapng formats
+const (
+	pcapMagicMicroseconds  = 0xA1B2C3D4
+	pcapMagicNanoseconds   = 0xA1B23C4D
+	pcapGlobalHeaderLength = 24
+
+	pcapngSectionHeader  = 0x0A0D0D0A
+	pcapngInterface      = 0x00000001
+	pcapngSimplePacket   = 0x00000003
+	pcapngEnhancedPacket = 0x00000006
+	pcapngByteOrderMagic = 0x1A2B3C4D
+	pcapngMinBlockLength = 12
+
+	// pcapMaxPacketLength bounds the allocation for a record or block
+	pcapMaxPacketLength = 1 << 18
+)
+
+// ErrInvalidCapture is returned for a capture file that is neither a valid
+// pcap nor a valid pcapng file
+var ErrInvalidCapture = fmt.Errorf("invalid capture file")
+
+// PcapSource reads the UDP payloads of the packets in a pcap or pcapng
+// capture, which on a surveillance feed are the ASTERIX datagrams. Both byte
+// orders of either format are read, as are Ethernet, Linux cooked, loopback
+// and raw IP link types. Frames that are not IPv4 or IPv6 UDP, IP fragments
+// and packets cut short by the capture's snap length are skipped.
+//
+// A PcapSource is also an io.Reader over the concatenated payloads, so a
+// capture can be decoded with DecodeAllFrom or Decoder.StreamDecode, e.g.
+// in WithLenientMode to get past corrupt datagrams.
+type PcapSource struct {
+	r     io.Reader
+	port  uint16
+	order binary.ByteOrder
+	ng    bool
+
+	linkType   uint32   // Link type of a pcap file
+	interfaces []uint32 // Link type of each pcapng interface
+	pending    []byte   // Payload left over by Read
+}
+
+// NewPcapSource reads the file header of a pcap or pcapng capture from r.
+// Only UDP datagrams sent to port are returned, or all of them if port is 0.
+func NewPcapSource(r io.Reader, port uint16) (*PcapSource, error) {
+	s := &PcapSource{r: r, port: port}
+
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, fmt.Errorf("%w: reading magic number: %v", ErrInvalidCapture, err)
+	}
+	if binary.BigEndian.Uint32(magic[:]) == pcapngSectionHeader {
+		s.ng = true
+		if err := s.readSectionHeader(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	switch {
+	case binary.LittleEndian.Uint32(magic[:]) == pcapMagicMicroseconds,
+		binary.LittleEndian.Uint32(magic[:]) == pcapMagicNanoseconds:
+		s.order = binary.LittleEndian
+	case binary.BigEndian.Uint32(magic[:]) == pcapMagicMicroseconds,
+		binary.BigEndian.Uint32(magic[:]) == pcapMagicNanoseconds:
+		s.order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("%w: unknown magic number % X", ErrInvalidCapture, magic)
+	}
+
+	header := make([]byte, pcapGlobalHeaderLength-4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: reading global header: %v", ErrInvalidCapture, err)
+	}
+	// Version, time zone, sigfigs and snap length precede the link type
+	s.linkType = s.order.Uint32(header[16:20]) & 0x0FFFFFFF
+	return s, nil
+}
+
+// Next returns the payload of the next matching UDP datagram. It returns
+// io.EOF at the end of the capture.
+func (s *PcapSource) Next() ([]byte, error) {
+	for {
+		linkType, frame, err := s.nextFrame()
+		if err != nil {
+			return nil, err
+		}
+		if payload, ok := s.udpPayload(linkType, frame); ok {
+			return payload, nil
+		}
+	}
+}
+
+// Read implements io.Reader over the payloads returned by Next
+func (s *PcapSource) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		payload, err := s.Next()
+		if err != nil {
+			return 0, err
+		}
+		s.pending = payload
+	}
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+// nextFrame returns the link type and captured bytes of the next packet
+// that was captured in full
+func (s *PcapSource) nextFrame() (uint32, []byte, error) {
+	for {
+		var linkType uint32
+		var frame []byte
+		var complete bool
+		var err error
+		if s.ng {
+			linkType, frame, complete, err = s.nextBlock()
+		} else {
+			linkType, frame, complete, err = s.nextRecord()
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		if frame != nil && complete {
+			return linkType, frame, nil
+		}
+	}
+}
+
+// nextRecord reads the next packet record of a pcap file
+func (s *PcapSource) nextRecord() (uint32, []byte, bool, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(s.r, header); err != nil {
+		if err == io.EOF {
+			return 0, nil, false, io.EOF
+		}
+		return 0, nil, false, fmt.Errorf("%w: reading packet header: %v", ErrInvalidCapture, err)
+	}
+	captured := s.order.Uint32(header[8:12])
+	original := s.order.Uint32(header[12:16])
+	if captured > pcapMaxPacketLength {
+		return 0, nil, false, fmt.Errorf("%w: packet of %d bytes", ErrInvalidCapture, captured)
+	}
+	frame := make([]byte, captured)
+	if _, err := io.ReadFull(s.r, frame); err != nil {
+		return 0, nil, false, fmt.Errorf("%w: reading %d byte packet: %v", ErrInvalidCapture, captured, err)
+	}
+	return s.linkType, frame, captured == original, nil
+}
+
+// readSectionHeader reads the rest of a pcapng section header block, whose
+// block type has been read, and resets the interfaces of the section
+func (s *PcapSource) readSectionHeader() error {
+	var head [8]byte
+	if _, err := io.ReadFull(s.r, head[:]); err != nil {
+		return fmt.Errorf("%w: reading section header: %v", ErrInvalidCapture, err)
+	}
+	switch {
+	case binary.LittleEndian.Uint32(head[4:8]) == pcapngByteOrderMagic:
+		s.order = binary.LittleEndian
+	case binary.BigEndian.Uint32(head[4:8]) == pcapngByteOrderMagic:
+		s.order = binary.BigEndian
+	default:
+		return fmt.Errorf("%w: unknown byte order magic % X", ErrInvalidCapture, head[4:8])
+	}
+	if _, err := s.readBody(s.order.Uint32(head[0:4]), 12); err != nil {
+		return err
+	}
+	s.interfaces = s.interfaces[:0]
+	return nil
+}
+
+// nextBlock reads the next block of a pcapng file. Blocks other than packets
+// return a nil frame.
+func (s *PcapSource) nextBlock() (uint32, []byte, bool, error) {
+	var head [8]byte
+	if _, err := io.ReadFull(s.r, head[:4]); err != nil {
+		if err == io.EOF {
+			return 0, nil, false, io.EOF
+		}
+		return 0, nil, false, fmt.Errorf("%w: reading block type: %v", ErrInvalidCapture, err)
+	}
+	if binary.BigEndian.Uint32(head[:4]) == pcapngSectionHeader {
+		return 0, nil, false, s.readSectionHeader()
+	}
+	if _, err := io.ReadFull(s.r, head[4:]); err != nil {
+		return 0, nil, false, fmt.Errorf("%w: reading block length: %v", ErrInvalidCapture, err)
+	}
+	blockType := s.order.Uint32(head[:4])
+	body, err := s.readBody(s.order.Uint32(head[4:8]), 8)
+	if err != nil {
+		return 0, nil, false, err
+	}
+
+	switch blockType {
+	case pcapngInterface:
+		if len(body) < 2 {
+			return 0, nil, false, fmt.Errorf("%w: short interface description block", ErrInvalidCapture)
+		}
+		s.interfaces = append(s.interfaces, uint32(s.order.Uint16(body[0:2])))
+	case pcapngEnhancedPacket:
+		if len(body) < 20 {
+			return 0, nil, false, fmt.Errorf("%w: short enhanced packet block", ErrInvalidCapture)
+		}
+		iface := s.order.Uint32(body[0:4])
+		captured := s.order.Uint32(body[12:16])
+		original := s.order.Uint32(body[16:20])
+		if int(iface) >= len(s.interfaces) || int(captured) > len(body)-20 {
+			return 0, nil, false, fmt.Errorf("%w: malformed enhanced packet block", ErrInvalidCapture)
+		}
+		return s.interfaces[iface], body[20 : 20+captured], captured == original, nil
+	case pcapngSimplePacket:
+		if len(body) < 4 || len(s.interfaces) == 0 {
+			return 0, nil, false, fmt.Errorf("%w: malformed simple packet block", ErrInvalidCapture)
+		}
+		original := s.order.Uint32(body[0:4])
+		frame := body[4:]
+		if int(original) <= len(frame) {
+			return s.interfaces[0], frame[:original], true, nil
+		}
+		return s.interfaces[0], frame, false, nil
+	}
+	return 0, nil, false, nil
+}
+
+// readBody reads the body and trailing length of a pcapng block of the given
+// total length, of which read bytes have been consumed
+func (s *PcapSource) readBody(length uint32, read int) ([]byte, error) {
+	if length < pcapngMinBlockLength || length%4 != 0 || length > pcapMaxPacketLength || int(length) < read+4 {
+		return nil, fmt.Errorf("%w: block length %d", ErrInvalidCapture, length)
+	}
+	rest := make([]byte, int(length)-read)
+	if _, err := io.ReadFull(s.r, rest); err != nil {
+		return nil, fmt.Errorf("%w: reading %d byte block: %v", ErrInvalidCapture, length, err)
+	}
+	if trailer := s.order.Uint32(rest[len(rest)-4:]); trailer != length {
+		return nil, fmt.Errorf("%w: block length %d closed by %d", ErrInvalidCapture, length, trailer)
+	}
+	return rest[:len(rest)-4], nil
+}
+
+// udpPayload returns the payload of frame if it is a UDP datagram to the
+// source's port
+func (s *PcapSource) udpPayload(linkType uint32, frame []byte) ([]byte, bool) {
+	var packet []byte
+	switch linkType {
+	case linkTypeEthernet:
+		if len(frame) < 14 {
+			return nil, false
+		}
+		etherType := binary.BigEndian.Uint16(frame[12:14])
+		packet = frame[14:]
+		for etherType == 0x8100 || etherType == 0x88A8 { // VLAN tags
+			if len(packet) < 4 {
+				return nil, false
+			}
+			etherType, packet = binary.BigEndian.Uint16(packet[2:4]), packet[4:]
+		}
+	case linkTypeLinuxSLL:
+		if len(frame) < 16 {
+			return nil, false
+		}
+		packet = frame[16:]
+	case linkTypeNull:
+		if len(frame) < 4 {
+			return nil, false
+		}
+		packet = frame[4:]
+	case linkTypeRaw, linkTypeIPv4, linkTypeIPv6:
+		packet = frame
+	default:
+		return nil, false
+	}
+	return s.ipPayload(packet)
+}
+
+// ipPayload returns the UDP payload of an IPv4 or IPv6 packet. IPv4
+// fragments and IPv6 packets with extension headers are not reassembled.
+func (s *PcapSource) ipPayload(packet []byte) ([]byte, bool) {
+	if len(packet) < 1 {
+		return nil, false
+	}
+	var udp []byte
+	switch packet[0] >> 4 {
+	case 4:
+		if len(packet) < 20 {
+			return nil, false
+		}
+		ihl := int(packet[0]&0x0F) * 4
+		total := int(binary.BigEndian.Uint16(packet[2:4]))
+		fragment := binary.BigEndian.Uint16(packet[6:8])
+		if packet[9] != 17 || fragment&0x3FFF != 0 || ihl < 20 || total < ihl || total > len(packet) {
+			return nil, false
+		}
+		udp = packet[ihl:total]
+	case 6:
+		if len(packet) < 40 || packet[6] != 17 {
+			return nil, false
+		}
+		length := int(binary.BigEndian.Uint16(packet[4:6]))
+		if 40+length > len(packet) {
+			return nil, false
+		}
+		udp = packet[40 : 40+length]
+	default:
+		return nil, false
+	}
+
+	if len(udp) < 8 {
+		return nil, false
+	}
+	length := int(binary.BigEndian.Uint16(udp[4:6]))
+	if length < 8 || length > len(udp) {
+		return nil, false
+	}
+	if s.port != 0 && binary.BigEndian.Uint16(udp[2:4]) != s.port {
+		return nil, false
+	}
+	return bytes.Clone(udp[8:length]), true
+}
@@ -0,0 +1,171 @@
+// asterix/pcap_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+// udpFrame builds an Ethernet II frame carrying an IPv4 UDP datagram
+func udpFrame(dstPort uint16, payload []byte) []byte {
+	udp := binary.BigEndian.AppendUint16(nil, 40000)
+	udp = binary.BigEndian.AppendUint16(udp, dstPort)
+	udp = binary.BigEndian.AppendUint16(udp, uint16(8+len(payload)))
+	udp = append(append(udp, 0, 0), payload...)
+
+	ip := []byte{0x45, 0x00}
+	ip = binary.BigEndian.AppendUint16(ip, uint16(20+len(udp)))
+	ip = append(ip, 0, 0, 0x40, 0x00, 64, 17, 0, 0, 10, 0, 0, 1, 239, 0, 0, 1)
+
+	eth := []byte{0x01, 0x00, 0x5E, 0x00, 0x00, 0x01, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x08, 0x00}
+	return append(append(eth, ip...), udp...)
+}
+
+// arpFrame is a frame PcapSource must skip
+var arpFrame = append([]byte{
+	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x08, 0x06,
+}, make([]byte, 28)...)
+
+// writePcap builds a classic pcap file of Ethernet frames in the given byte order
+func writePcap(order binary.AppendByteOrder, frames ...[]byte) []byte {
+	file := order.AppendUint32(nil, 0xA1B2C3D4)
+	file = order.AppendUint16(file, 2)
+	file = order.AppendUint16(file, 4)
+	file = append(file, make([]byte, 8)...)
+	file = order.AppendUint32(file, 65535)
+	file = order.AppendUint32(file, 1)
+	for i, frame := range frames {
+		file = order.AppendUint32(file, uint32(1700000000+i))
+		file = order.AppendUint32(file, 0)
+		file = order.AppendUint32(file, uint32(len(frame)))
+		file = order.AppendUint32(file, uint32(len(frame)))
+		file = append(file, frame...)
+	}
+	return file
+}
+
+// writePcapng builds a little-endian pcapng file of Ethernet frames
+func writePcapng(frames ...[]byte) []byte {
+	le := binary.LittleEndian
+	block := func(file []byte, blockType uint32, body []byte) []byte {
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+		file = le.AppendUint32(file, blockType)
+		file = le.AppendUint32(file, uint32(12+len(body)))
+		file = append(file, body...)
+		return le.AppendUint32(file, uint32(12+len(body)))
+	}
+
+	shb := le.AppendUint32(nil, 0x1A2B3C4D)
+	shb = append(shb, 1, 0, 0, 0, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF)
+	file := block(nil, 0x0A0D0D0A, shb)
+	file = block(file, 1, []byte{1, 0, 0, 0, 0, 0, 0, 0})
+	for _, frame := range frames {
+		epb := make([]byte, 12)
+		epb = le.AppendUint32(epb, uint32(len(frame)))
+		epb = le.AppendUint32(epb, uint32(len(frame)))
+		file = block(file, 6, append(epb, frame...))
+	}
+	return file
+}
+
+func TestPcapSource(t *testing.T) {
+	uap := newCat021UAP(t)
+	first, second := encodeCat021Block(t, uap, 0x3C6544), encodeCat021Block(t, uap, 0x4CA2B1, 0x4CA2B2)
+	frames := [][]byte{
+		udpFrame(8600, first),
+		arpFrame,
+		udpFrame(9999, []byte{0xDE, 0xAD}),
+		udpFrame(8600, second),
+	}
+
+	// The committed fixture holds the little-endian capture
+	path := filepath.Join("testdata", "cat021.pcap")
+	if *update {
+		if err := os.WriteFile(path, writePcap(binary.LittleEndian, frames...), 0o644); err != nil {
+			t.Fatalf("writing %s: %v", path, err)
+		}
+	}
+	fixture, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+
+	captures := map[string][]byte{
+		"pcap little-endian": fixture,
+		"pcap big-endian":    writePcap(binary.BigEndian, frames...),
+		"pcapng":             writePcapng(frames...),
+	}
+	for name, capture := range captures {
+		src, err := asterix.NewPcapSource(bytes.NewReader(capture), 8600)
+		if err != nil {
+			t.Fatalf("%s: NewPcapSource() error = %v", name, err)
+		}
+		for i, want := range [][]byte{first, second} {
+			got, err := src.Next()
+			if err != nil {
+				t.Fatalf("%s: Next() %d error = %v", name, i, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s: Next() %d = % X, want % X", name, i, got, want)
+			}
+		}
+		if _, err := src.Next(); err != io.EOF {
+			t.Errorf("%s: Next() at end error = %v, want %v", name, err, io.EOF)
+		}
+	}
+
+	// Without a port filter the stray datagram is returned too
+	src, err := asterix.NewPcapSource(bytes.NewReader(fixture), 0)
+	if err != nil {
+		t.Fatalf("NewPcapSource() error = %v", err)
+	}
+	count := 0
+	for {
+		if _, err := src.Next(); err != nil {
+			break
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Next() without a port filter returned %d payloads, want 3", count)
+	}
+
+	if _, err := asterix.NewPcapSource(bytes.NewReader(first), 0); !errors.Is(err, asterix.ErrInvalidCapture) {
+		t.Errorf("NewPcapSource(Cat021 block) error = %v, want %v", err, asterix.ErrInvalidCapture)
+	}
+}
+
+func TestPcapSource_DecodeAllFrom(t *testing.T) {
+	uap := newCat021UAP(t)
+	capture := writePcap(binary.LittleEndian,
+		udpFrame(8600, encodeCat021Block(t, uap, 1)),
+		udpFrame(8600, []byte{21, 0x00, 0x05, 0xFF, 0xFF}), // corrupt block
+		udpFrame(8600, encodeCat021Block(t, uap, 2, 3)),
+	)
+	src, err := asterix.NewPcapSource(bytes.NewReader(capture), 8600)
+	if err != nil {
+		t.Fatalf("NewPcapSource() error = %v", err)
+	}
+	decoder, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(uap), asterix.WithLenientMode())
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+
+	blocks, err := asterix.DecodeAllFrom(decoder, src)
+	var skipped *asterix.MessageError
+	if !errors.As(err, &skipped) || skipped.Index != 1 {
+		t.Errorf("DecodeAllFrom() error = %v, want block 1 skipped", err)
+	}
+	if len(blocks) != 2 || blocks[0].Length() != 1 || blocks[1].Length() != 2 {
+		t.Errorf("DecodeAllFrom() = %d blocks, want blocks of 1 and 2 records", len(blocks))
+	}
+}
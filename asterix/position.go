@@ -0,0 +1,69 @@
+// asterix/position.go
+package asterix
+
+import "github.com/davidkohl/gobelix/asterix/geo"
+
+// PositionSelector may be implemented by a UAP to choose between several
+// position items depending on whether the target is on the ground. The
+// returned item IDs are tried in order; the first one present in the record
+// wins.
+type PositionSelector interface {
+	PositionItems(onGround bool) []string
+}
+
+// IsOnGround reports the ground status carried by the record's items. The
+// second value is false when no item reports it.
+func (r *Record) IsOnGround() (bool, bool) {
+	for _, field := range r.uap.Fields() {
+		item, exists := r.items[field.DataItem]
+		if !exists {
+			continue
+		}
+		if gp, ok := item.(GroundStatusProvider); ok {
+			if onGround, known := gp.OnGround(); known {
+				return onGround, true
+			}
+		}
+	}
+	return false, false
+}
+
+// BestPosition returns the WGS-84 position most appropriate for the target's
+// ground status. If the UAP implements PositionSelector its preference order
+// is used, with an unknown ground status treated as airborne; otherwise the
+// first position item in UAP order is returned.
+func (r *Record) BestPosition() (lat, lon float64, ok bool) {
+	if selector, isSelector := r.uap.(PositionSelector); isSelector {
+		onGround, _ := r.IsOnGround()
+		for _, id := range selector.PositionItems(onGround) {
+			if pp, isPos := r.items[id].(PositionProvider); isPos {
+				lat, lon = pp.WGS84()
+				return lat, lon, true
+			}
+		}
+		return 0, 0, false
+	}
+
+	for _, field := range r.uap.Fields() {
+		if pp, isPos := r.items[field.DataItem].(PositionProvider); isPos {
+			lat, lon = pp.WGS84()
+			return lat, lon, true
+		}
+	}
+	return 0, 0, false
+}
+
+// RecordDistance returns the great-circle distance in metres between the
+// best positions of two records, as returned by BestPosition. ok is false
+// when either record carries no position.
+func RecordDistance(a, b *Record) (meters float64, ok bool) {
+	lat1, lon1, ok := a.BestPosition()
+	if !ok {
+		return 0, false
+	}
+	lat2, lon2, ok := b.BestPosition()
+	if !ok {
+		return 0, false
+	}
+	return geo.Haversine(lat1, lon1, lat2, lon2), true
+}
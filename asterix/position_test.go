@@ -0,0 +1,118 @@
+// asterix/position_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// newCat021PositionRecord builds a Cat021 record carrying both the nominal and
+// the high resolution position with the given target report descriptor bytes
+func newCat021PositionRecord(t *testing.T, descriptor []byte) *asterix.Record {
+	t.Helper()
+	record := newCat021Record(t, newCat021UAP(t), 25, 100, 0x3C6586)
+
+	trd := &v26.TargetReportDescriptor{}
+	if _, err := trd.Decode(bytes.NewBuffer(descriptor)); err != nil {
+		t.Fatalf("TargetReportDescriptor.Decode() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I021/040", trd},
+		{"I021/130", &common.Position{Latitude: 50.0, Longitude: 8.5}},
+		{"I021/131", &v26.HighResPosition{Latitude: 50.0333, Longitude: 8.5705}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+	return record
+}
+
+func TestRecord_BestPosition(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor []byte
+		wantGround bool
+		wantKnown  bool
+		wantLat    float64
+		wantLon    float64
+	}{
+		{"on ground", []byte{0x21, 0x40}, true, true, 50.0333, 8.5705},
+		{"airborne", []byte{0x21, 0x00}, false, true, 50.0, 8.5},
+		{"ground bit absent", []byte{0x20}, false, false, 50.0, 8.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := newCat021PositionRecord(t, tt.descriptor)
+
+			onGround, known := record.IsOnGround()
+			if onGround != tt.wantGround || known != tt.wantKnown {
+				t.Errorf("IsOnGround() = (%v, %v), want (%v, %v)", onGround, known, tt.wantGround, tt.wantKnown)
+			}
+
+			lat, lon, ok := record.BestPosition()
+			if !ok {
+				t.Fatal("BestPosition() found no position")
+			}
+			if math.Abs(lat-tt.wantLat) > 1e-9 || math.Abs(lon-tt.wantLon) > 1e-9 {
+				t.Errorf("BestPosition() = (%v, %v), want (%v, %v)", lat, lon, tt.wantLat, tt.wantLon)
+			}
+		})
+	}
+}
+
+func TestRecord_BestPosition_Fallback(t *testing.T) {
+	record := newCat021Record(t, newCat021UAP(t), 25, 100, 0x3C6586)
+	if _, _, ok := record.BestPosition(); ok {
+		t.Error("BestPosition() reported a position for a record without one")
+	}
+
+	// Only the nominal position is present, so a ground target falls back to it
+	trd := &v26.TargetReportDescriptor{}
+	if _, err := trd.Decode(bytes.NewBuffer([]byte{0x21, 0x40})); err != nil {
+		t.Fatalf("TargetReportDescriptor.Decode() error = %v", err)
+	}
+	if err := record.SetDataItem("I021/040", trd); err != nil {
+		t.Fatalf("SetDataItem(I021/040) error = %v", err)
+	}
+	if err := record.SetDataItem("I021/130", &common.Position{Latitude: 50.0, Longitude: 8.5}); err != nil {
+		t.Fatalf("SetDataItem(I021/130) error = %v", err)
+	}
+	lat, lon, ok := record.BestPosition()
+	if !ok || lat != 50.0 || lon != 8.5 {
+		t.Errorf("BestPosition() = (%v, %v, %v), want (50, 8.5, true)", lat, lon, ok)
+	}
+}
+
+func TestRecordDistance(t *testing.T) {
+	// Airborne, so both records use the nominal position of 50.0 N 8.5 E
+	a := newCat021PositionRecord(t, []byte{0x21, 0x00})
+	b := newCat021Record(t, newCat021UAP(t), 25, 100, 0x3C6587)
+	if err := b.SetDataItem("I021/130", &common.Position{Latitude: 51.0, Longitude: 8.5}); err != nil {
+		t.Fatalf("SetDataItem() error = %v", err)
+	}
+
+	// One degree of latitude is about 111.2 km
+	meters, ok := asterix.RecordDistance(a, b)
+	if !ok || math.Abs(meters-111195) > 100 {
+		t.Errorf("RecordDistance() = (%.0f, %v), want (~111195, true)", meters, ok)
+	}
+
+	noPos := newCat021Record(t, newCat021UAP(t), 25, 100, 0x3C6588)
+	if _, ok := asterix.RecordDistance(a, noPos); ok {
+		t.Error("RecordDistance() ok for a record without a position")
+	}
+	if _, ok := asterix.RecordDistance(noPos, a); ok {
+		t.Error("RecordDistance() ok for a record without a position")
+	}
+}
@@ -0,0 +1,78 @@
+// asterix/providers.go
+package asterix
+
+import "time"
+
+// TrackNumberProvider is implemented by data items carrying a track number
+type TrackNumberProvider interface {
+	TrackNumber() uint16
+}
+
+// SourceProvider is implemented by data items identifying the data source
+// of a record by its System Area Code and System Identification Code
+type SourceProvider interface {
+	Source() (sac, sic uint8)
+}
+
+// PositionProvider is implemented by data items carrying a WGS-84 position
+type PositionProvider interface {
+	WGS84() (lat, lon float64)
+}
+
+// DetectionTypeProvider is implemented by data items reporting which sensor
+// types detected the plot behind a report, such as I062/340. Both methods
+// return false when the type is unknown or there was no detection.
+type DetectionTypeProvider interface {
+	// IsSSRDerived reports a secondary (SSR or Mode S) detection, possibly
+	// combined with a primary one
+	IsSSRDerived() bool
+	// IsPSROnly reports a primary detection without a secondary one
+	IsPSROnly() bool
+}
+
+// GroundStatusProvider is implemented by data items reporting whether the
+// target is on the ground. known is false when the item does not carry the
+// information, e.g. because an optional extension was absent.
+type GroundStatusProvider interface {
+	OnGround() (onGround, known bool)
+}
+
+// ChangeProvider is implemented by data items carrying a change flag (CH
+// bit) that signals the value differs from the previous update, such as the
+// Mode 3/A code of I062/060. The method is named HasChanged because items
+// expose the flag itself as a Changed field.
+type ChangeProvider interface {
+	HasChanged() bool
+}
+
+// TrackStatusProvider is implemented by data items reporting the lifecycle
+// status of a track, such as I062/080.
+type TrackStatusProvider interface {
+	// Confirmed reports whether the track is confirmed rather than tentative
+	Confirmed() bool
+	// Coasted reports whether the track is being extrapolated because its
+	// last update is older than the coasting threshold
+	Coasted() bool
+}
+
+// TimedRecord is implemented by data items carrying a time of day that can
+// timestamp the record. Duration returns the time elapsed since midnight UTC.
+type TimedRecord interface {
+	Duration() time.Duration
+}
+
+// AltitudeProvider is implemented by data items carrying an altitude. The
+// method is named AltitudeFeet because items expose the value in their own
+// unit as an Altitude field.
+type AltitudeProvider interface {
+	AltitudeFeet() float64
+}
+
+// FieldGetter is implemented by data items that expose their scalar fields
+// by name, so callers can read them through Record.GetField without a type
+// assertion to the item's concrete type. Names are the Go field names of the
+// item, e.g. "SAC" or "Address", and values are returned in the field's own
+// type and unit. ok is false for an unknown name or an absent subfield.
+type FieldGetter interface {
+	GetField(name string) (value any, ok bool)
+}
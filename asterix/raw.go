@@ -0,0 +1,143 @@
+// asterix/raw.go
+package asterix
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// RawDataItem holds the encoded bytes of an item that has no typed
+// implementation. It finds the end of the item from the layout of its UAP
+// field and Encode writes the bytes back unchanged, so records carrying
+// unimplemented items still decode and re-encode losslessly.
+//
+// Compound items cannot be held raw, as the length of their subfields is
+// not given by the field definition.
+type RawDataItem struct {
+	Type   ItemType
+	Length uint8  // As in DataField
+	Data   []byte // Encoded item, including any repetition factor or length octet
+
+	maxFXDepth int // Set by SetMaxFXDepth, 0 for DefaultMaxFXDepth
+}
+
+// NewRawDataItem returns an empty RawDataItem laid out like field
+func NewRawDataItem(field DataField) *RawDataItem {
+	return &RawDataItem{Type: field.Type, Length: field.Length}
+}
+
+// RawDataItem returns a RawDataItem for the UAP field id. A UAP opts into
+// raw decoding of its unimplemented items by returning this from
+// CreateDataItem instead of an ErrUnknownDataItem error. Compound fields
+// and IDs not in the UAP still fail with ErrUnknownDataItem.
+func (u *BaseUAP) RawDataItem(id string) (DataItem, error) {
+	for _, field := range u.fields {
+		if field.DataItem != id {
+			continue
+		}
+		if field.Type == Compound {
+			return nil, fmt.Errorf("%w: %s is compound and cannot be held raw", ErrUnknownDataItem, id)
+		}
+		return NewRawDataItem(field), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownDataItem, id)
+}
+
+// Reset implements Resetter, dropping the bytes but keeping the layout
+func (r *RawDataItem) Reset() {
+	r.Data = nil
+}
+
+// SetMaxFXDepth implements FXDepthLimiter, bounding the extensions of an
+// extended item. A value of 0 or less restores DefaultMaxFXDepth.
+func (r *RawDataItem) SetMaxFXDepth(octets int) {
+	r.maxFXDepth = max(octets, 0)
+}
+
+func (r *RawDataItem) Decode(buf *bytes.Buffer) (int, error) {
+	depth := r.maxFXDepth
+	if depth == 0 {
+		depth = DefaultMaxFXDepth
+	}
+	n, err := r.itemLength(buf.Bytes(), depth)
+	if err != nil {
+		return 0, err
+	}
+	r.Data = append([]byte(nil), buf.Next(n)...)
+	return n, nil
+}
+
+func (r *RawDataItem) Encode(buf *bytes.Buffer) (int, error) {
+	if err := r.Validate(); err != nil {
+		return 0, err
+	}
+
+	n, err := buf.Write(r.Data)
+	if err != nil {
+		return n, fmt.Errorf("writing raw item: %w", err)
+	}
+	return n, nil
+}
+
+// Validate checks that Data holds exactly one item of the layout
+func (r *RawDataItem) Validate() error {
+	n, err := r.itemLength(r.Data, 0)
+	if err != nil {
+		return err
+	}
+	if n != len(r.Data) {
+		return fmt.Errorf("%w: raw item has %d octets, layout gives %d", ErrInvalidLength, len(r.Data), n)
+	}
+	return nil
+}
+
+func (r *RawDataItem) String() string {
+	return fmt.Sprintf("raw [% X]", r.Data)
+}
+
+// itemLength returns the length of the item of the layout starting at
+// data[0]. The extensions of an extended item are bounded by maxFX octets,
+// or only by data when maxFX is 0.
+func (r *RawDataItem) itemLength(data []byte, maxFX int) (int, error) {
+	var n int
+	switch r.Type {
+	case Fixed:
+		n = int(r.Length)
+
+	case Extended:
+		// The FX bit closes the first part and every one-octet extension
+		n = max(int(r.Length), 1)
+		for n <= len(data) && data[n-1]&0x01 != 0 {
+			if maxFX > 0 && n >= int(r.Length)+maxFX {
+				return 0, fmt.Errorf("%w: chain longer than %d octets", ErrFXDepthExceeded, maxFX)
+			}
+			n++
+		}
+
+	case Repetitive:
+		if len(data) < 1 {
+			return 0, fmt.Errorf("%w: reading repetition factor", ErrBufferTooShort)
+		}
+		n = 1 + int(data[0])*int(r.Length)
+
+	case Explicit:
+		if len(data) < 1 {
+			return 0, fmt.Errorf("%w: reading length indicator", ErrBufferTooShort)
+		}
+		if data[0] == 0 {
+			return 0, fmt.Errorf("%w: length indicator of 0", ErrInvalidLength)
+		}
+		n = int(data[0])
+
+	default:
+		return 0, fmt.Errorf("%w: no raw layout for item type %d", ErrInvalidField, r.Type)
+	}
+
+	if n == 0 {
+		return 0, fmt.Errorf("%w: raw item of 0 octets", ErrInvalidLength)
+	}
+	if len(data) < n {
+		return 0, fmt.Errorf("%w: need %d octets, have %d", ErrBufferTooShort, n, len(data))
+	}
+	return n, nil
+}
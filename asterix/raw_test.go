@@ -0,0 +1,158 @@
+// asterix/raw_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// rawUAP implements only I034/010 and holds every other item raw
+type rawUAP struct {
+	*asterix.BaseUAP
+}
+
+func (u *rawUAP) CreateDataItem(id string) (asterix.DataItem, error) {
+	if id == "I034/010" {
+		return &common.DataSourceIdentifier{}, nil
+	}
+	return u.RawDataItem(id)
+}
+
+func newRawUAP(t *testing.T) *rawUAP {
+	t.Helper()
+	base, err := asterix.NewBaseUAP(asterix.Cat034, "test", []asterix.DataField{
+		{FRN: 1, DataItem: "I034/010", Type: asterix.Fixed, Length: 2},
+		{FRN: 2, DataItem: "I034/F3", Type: asterix.Fixed, Length: 3},
+		{FRN: 3, DataItem: "I034/E1", Type: asterix.Extended, Length: 1},
+		{FRN: 4, DataItem: "I034/E2", Type: asterix.Extended, Length: 2},
+		{FRN: 5, DataItem: "I034/R2", Type: asterix.Repetitive, Length: 2},
+		{FRN: 6, DataItem: "SP034", Type: asterix.Explicit, Length: 1},
+		{FRN: 7, DataItem: "I034/C", Type: asterix.Compound, Length: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewBaseUAP() error = %v", err)
+	}
+	return &rawUAP{BaseUAP: base}
+}
+
+func TestRawDataItem_RecordRoundTrip(t *testing.T) {
+	uap := newRawUAP(t)
+	data := []byte{
+		34, 0x00, 0x17, // CAT, LEN
+		0xFC,       // FSPEC: FRNs 1 to 6
+		0x19, 0x0A, // I034/010
+		0x01, 0x02, 0x03, // Fixed, 3 octets
+		0x81, 0x03, 0x00, // Extended, two extensions
+		0x12, 0x35, 0x40, // Extended with a two-octet first part
+		0x02, 0xAA, 0xBB, 0xCC, 0xDD, // Repetitive, two elements of 2 octets
+		0x03, 0xEE, 0xFF, // Explicit, length octet included
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat034, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.Decode(data); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	record := block.Records()[0]
+	want := map[string][]byte{
+		"I034/F3": {0x01, 0x02, 0x03},
+		"I034/E1": {0x81, 0x03, 0x00},
+		"I034/E2": {0x12, 0x35, 0x40},
+		"I034/R2": {0x02, 0xAA, 0xBB, 0xCC, 0xDD},
+		"SP034":   {0x03, 0xEE, 0xFF},
+	}
+	for id, raw := range want {
+		item, _, ok := record.GetDataItem(id)
+		if !ok {
+			t.Errorf("%s missing after decode", id)
+			continue
+		}
+		if got := item.(*asterix.RawDataItem).Data; !bytes.Equal(got, raw) {
+			t.Errorf("%s = % X, want % X", id, got, raw)
+		}
+	}
+
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(encoded, data) {
+		t.Errorf("Encode() = % X, want % X", encoded, data)
+	}
+}
+
+func TestRawDataItem_RecordReuse(t *testing.T) {
+	uap := newRawUAP(t)
+	data := []byte{
+		34, 0x00, 0x0C, // CAT, LEN
+		0xE0,       // FSPEC: FRNs 1 to 3
+		0x19, 0x0A, // I034/010
+		0x01, 0x02, 0x03, // Fixed, 3 octets
+		0x81, 0x03, 0x00, // Extended, two extensions
+	}
+	decoder, err := asterix.NewDecoderWithOptions(asterix.WithUAPs(uap), asterix.WithRecordReuse())
+	if err != nil {
+		t.Fatalf("NewDecoderWithOptions() error = %v", err)
+	}
+
+	// The second decode reuses the raw items, which must keep their layout
+	for i := range 2 {
+		msg, err := decoder.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Decode() #%d error = %v", i, err)
+		}
+		item, _, ok := msg.GetDataItemFromRecord("I034/E1", 0)
+		raw, isRaw := item.(*asterix.RawDataItem)
+		if !ok || !isRaw || raw.Type != asterix.Extended || !bytes.Equal(raw.Data, data[9:]) {
+			t.Errorf("Decode() #%d I034/E1 = %+v, want the extended item % X", i, item, data[9:])
+		}
+	}
+}
+
+func TestRawDataItem_Errors(t *testing.T) {
+	uap := newRawUAP(t)
+	if _, err := uap.RawDataItem("I034/C"); !errors.Is(err, asterix.ErrUnknownDataItem) {
+		t.Errorf("RawDataItem(compound) error = %v, want %v", err, asterix.ErrUnknownDataItem)
+	}
+	if _, err := uap.RawDataItem("I034/999"); !errors.Is(err, asterix.ErrUnknownDataItem) {
+		t.Errorf("RawDataItem(unknown) error = %v, want %v", err, asterix.ErrUnknownDataItem)
+	}
+
+	tests := []struct {
+		name  string
+		field asterix.DataField
+		data  []byte
+		want  error
+	}{
+		{"fixed truncated", asterix.DataField{Type: asterix.Fixed, Length: 3}, []byte{0x01, 0x02}, asterix.ErrBufferTooShort},
+		{"extension truncated", asterix.DataField{Type: asterix.Extended, Length: 1}, []byte{0x81}, asterix.ErrBufferTooShort},
+		{"repetitions truncated", asterix.DataField{Type: asterix.Repetitive, Length: 2}, []byte{0x02, 0xAA, 0xBB}, asterix.ErrBufferTooShort},
+		{"zero length indicator", asterix.DataField{Type: asterix.Explicit, Length: 1}, []byte{0x00}, asterix.ErrInvalidLength},
+		{"endless FX chain", asterix.DataField{Type: asterix.Extended, Length: 1}, bytes.Repeat([]byte{0x01}, 32), asterix.ErrFXDepthExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(tt.data)
+			n, err := asterix.NewRawDataItem(tt.field).Decode(buf)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Decode() error = %v, want %v", err, tt.want)
+			}
+			if n != 0 || buf.Len() != len(tt.data) {
+				t.Errorf("Decode() consumed %d octets on error", len(tt.data)-buf.Len())
+			}
+		})
+	}
+
+	// Data must hold exactly one item
+	item := &asterix.RawDataItem{Type: asterix.Explicit, Length: 1, Data: []byte{0x02, 0xEE, 0xFF}}
+	if err := item.Validate(); !errors.Is(err, asterix.ErrInvalidLength) {
+		t.Errorf("Validate() error = %v, want %v", err, asterix.ErrInvalidLength)
+	}
+}
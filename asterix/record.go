@@ -4,6 +4,7 @@ package asterix
 import (
 	"bytes"
 	"fmt"
+	"hash/fnv"
 	"io"
 )
 
@@ -13,6 +14,10 @@ type Record struct {
 	fspec    *FSPEC
 	items    map[string]DataItem
 	uap      UAP
+
+	selector   UAP                 // The UAPSelector uap was chosen by, if any
+	spare      map[string]DataItem // Items removed by Reset, for DecodeInto
+	maxFXDepth int                 // Set by SetMaxFXDepth, 0 for DefaultMaxFXDepth
 }
 
 // NewRecord creates a new record for a specific category
@@ -36,6 +41,14 @@ func NewRecord(cat Category, uap UAP) (*Record, error) {
 	}, nil
 }
 
+// SetMaxFXDepth sets the maximum number of octets Decode accepts in the FX
+// chain of an extended item, the primary subfield of a compound item or any
+// FX chain an FXDepthLimiter item reads, like WithDecoderMaxDepth does for a
+// Decoder. A value of 0 or less restores DefaultMaxFXDepth.
+func (r *Record) SetMaxFXDepth(octets int) {
+	r.maxFXDepth = max(octets, 0)
+}
+
 // SetDataItem adds or updates a data item
 func (r *Record) SetDataItem(id string, item DataItem) error {
 	if item == nil {
@@ -63,12 +76,186 @@ func (r *Record) SetDataItem(id string, item DataItem) error {
 	return r.fspec.SetFRN(frn)
 }
 
+// Apply runs fn on the item id of the record, typed as T, so the item can be
+// changed in place. Afterwards any raw bytes the item retained from decoding
+// are discarded (see RawRetainer), so Encode reflects the change, and the
+// item is validated again. If fn or the validation fails, the record gets
+// back the item as it was before, decoded from its encoding taken before fn
+// ran, and the error is returned; the T given to fn is then detached from
+// the record. An item that could not be encoded beforehand is left as fn
+// left it. Apply fails if the item is absent or not a T.
+func Apply[T DataItem](r *Record, id string, fn func(T) error) error {
+	item, exists := r.items[id]
+	if !exists {
+		return fmt.Errorf("%w: %s not present in record", ErrUnknownDataItem, id)
+	}
+	typed, ok := item.(T)
+	if !ok {
+		var want T
+		return fmt.Errorf("%w: %s is %T, not %T", ErrInvalidField, id, item, want)
+	}
+
+	// Snapshot the item so that a failed change can be undone
+	snapshot := new(bytes.Buffer)
+	_, snapErr := item.Encode(snapshot)
+
+	err := fn(typed)
+	if err == nil {
+		if rr, ok := item.(RawRetainer); ok {
+			rr.DiscardRaw()
+		}
+		if err = item.Validate(); err == nil {
+			return nil
+		}
+		err = fmt.Errorf("validating %s: %w", id, err)
+	}
+
+	if snapErr == nil {
+		if restored, rerr := r.uap.CreateDataItem(id); rerr == nil {
+			if _, rerr := restored.Decode(snapshot); rerr == nil {
+				r.items[id] = restored
+			}
+		}
+	}
+	return err
+}
+
+// UAP returns the User Application Profile the record was created with
+func (r *Record) UAP() UAP {
+	return r.uap
+}
+
+// PresentFRNs returns, in ascending order, the FRNs marked in the record's
+// FSPEC. After Decode this includes fixed length items that were skipped
+// because the UAP does not implement them.
+func (r *Record) PresentFRNs() []uint8 {
+	var frns []uint8
+	for i, b := range r.fspec.bits {
+		for bit := 0; bit < 7; bit++ {
+			if b&(0x80>>bit) != 0 {
+				frns = append(frns, uint8(i*7+bit+1))
+			}
+		}
+	}
+	return frns
+}
+
 // GetDataItem retrieves a data item by its ID
 func (r *Record) GetDataItem(id string) (DataItem, string, bool) {
 	item, exists := r.items[id]
 	return item, fmt.Sprintf("%T", item), exists
 }
 
+// ItemBytes returns the wire bytes of item id: the bytes it was decoded from
+// when it implements RawBytesProvider and still holds them, otherwise its
+// encoding. ok is false when the item is absent or fails to encode. The
+// result must not be modified.
+func (r *Record) ItemBytes(id string) ([]byte, bool) {
+	item, exists := r.items[id]
+	if !exists {
+		return nil, false
+	}
+	if rp, ok := item.(RawBytesProvider); ok {
+		if raw, ok := rp.RawBytes(); ok {
+			return raw, true
+		}
+	}
+	buf := new(bytes.Buffer)
+	if _, err := item.Encode(buf); err != nil {
+		return nil, false
+	}
+	return buf.Bytes(), true
+}
+
+// GetField returns the named field of item id. ok is false when the item is
+// absent, does not implement FieldGetter, or has no such field.
+func (r *Record) GetField(id, name string) (any, bool) {
+	getter, ok := r.items[id].(FieldGetter)
+	if !ok {
+		return nil, false
+	}
+	return getter.GetField(name)
+}
+
+// Normalize brings the record into canonical form: every item is encoded from
+// its typed fields, decoded into a fresh instance and the FSPEC is rebuilt from
+// the items actually present. Afterwards two records carrying the same content
+// encode to the same bytes regardless of how they were originally produced.
+//
+// Normalize discards any raw bytes retained from decoding (see RawRetainer),
+// including vendor-specific content the typed fields do not represent. The
+// record gets new items; if Normalize fails, the items it keeps are decoded
+// again from the raw bytes they held.
+func (r *Record) Normalize() (err error) {
+	fspec := NewFSPEC()
+	items := make(map[string]DataItem, len(r.items))
+
+	// Items whose raw bytes were discarded get them back if Normalize fails
+	var restore []rawItem
+	defer func() {
+		if err != nil {
+			for _, ri := range restore {
+				ri.item.Decode(bytes.NewBuffer(ri.raw))
+			}
+		}
+	}()
+
+	for _, field := range r.uap.Fields() {
+		item, exists := r.items[field.DataItem]
+		if !exists {
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		if rr, ok := item.(RawRetainer); ok {
+			if raw, ok := rawBytes(item); ok {
+				restore = append(restore, rawItem{item, raw})
+			}
+			rr.DiscardRaw()
+		}
+
+		if _, err := item.Encode(buf); err != nil {
+			return fmt.Errorf("normalizing %s: encoding: %w", field.DataItem, err)
+		}
+
+		fresh, err := r.uap.CreateDataItem(field.DataItem)
+		if err != nil {
+			return fmt.Errorf("normalizing %s: %w", field.DataItem, err)
+		}
+		if _, err := fresh.Decode(buf); err != nil {
+			return fmt.Errorf("normalizing %s: decoding: %w", field.DataItem, err)
+		}
+		if rr, ok := fresh.(RawRetainer); ok {
+			rr.DiscardRaw()
+		}
+
+		items[field.DataItem] = fresh
+		if err := fspec.SetFRN(field.FRN); err != nil {
+			return fmt.Errorf("normalizing %s: %w", field.DataItem, err)
+		}
+	}
+
+	r.items = items
+	r.fspec = fspec
+	return nil
+}
+
+// rawItem is an item and the raw bytes it held before Normalize
+type rawItem struct {
+	item DataItem
+	raw  []byte
+}
+
+// rawBytes returns a copy of the raw bytes item holds, if it provides them
+func rawBytes(item DataItem) ([]byte, bool) {
+	p, ok := item.(RawBytesProvider)
+	if !ok {
+		return nil, false
+	}
+	raw, ok := p.RawBytes()
+	return bytes.Clone(raw), ok
+}
+
 // Encode writes the record to a buffer
 func (r *Record) Encode(buf *bytes.Buffer) (int, error) {
 	if err := r.uap.Validate(r.items); err != nil {
@@ -84,21 +271,17 @@ func (r *Record) Encode(buf *bytes.Buffer) (int, error) {
 	}
 	bytesWritten += n
 
-	// Write items in FRN order
-	for _, field := range r.uap.Fields() {
-		if !r.fspec.GetFRN(field.FRN) {
-			continue
-		}
-
-		item, exists := r.items[field.DataItem]
+	// Write items in FRN order, as laid out for this set of items
+	for _, id := range layoutOf(r).items {
+		item, exists := r.items[id]
 		if !exists {
 			return bytesWritten, fmt.Errorf("%w: %s marked in FSPEC but not present",
-				ErrInvalidMessage, field.DataItem)
+				ErrInvalidMessage, id)
 		}
 
 		n, err := item.Encode(buf)
 		if err != nil {
-			return bytesWritten, fmt.Errorf("encoding %s: %w", field.DataItem, err)
+			return bytesWritten, fmt.Errorf("encoding %s: %w", id, err)
 		}
 		bytesWritten += n
 	}
@@ -106,59 +289,252 @@ func (r *Record) Encode(buf *bytes.Buffer) (int, error) {
 	return bytesWritten, nil
 }
 
-// Decode reads a record from a buffer
+// clone returns an independent copy of the record, made by encoding it and
+// decoding the bytes into a fresh record
+func (r *Record) clone() (*Record, error) {
+	buf := new(bytes.Buffer)
+	if _, err := r.Encode(buf); err != nil {
+		return nil, err
+	}
+	c, err := NewRecord(r.category, r.uap)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Decode(buf); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Checksum returns a 64-bit FNV-1a hash of the record's encoded bytes.
+// Records whose encodings are identical have the same checksum; use
+// Normalize first to compare content independently of byte provenance.
+func (r *Record) Checksum() (uint64, error) {
+	buf := scratchBuffers.Get()
+	defer scratchBuffers.Put(buf)
+	if _, err := r.Encode(buf); err != nil {
+		return 0, fmt.Errorf("computing checksum: %w", err)
+	}
+
+	h := fnv.New64a()
+	h.Write(buf.Bytes())
+	return h.Sum64(), nil
+}
+
+// Equal reports whether both records belong to the same category and carry
+// the same items with identical encodings. Items that fail to encode are
+// never equal; Normalize both records first to ignore retained raw bytes.
+func (r *Record) Equal(other *Record) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if r.category != other.category || len(r.items) != len(other.items) {
+		return false
+	}
+
+	var a, b bytes.Buffer
+	for id, item := range r.items {
+		otherItem, exists := other.items[id]
+		if !exists {
+			return false
+		}
+
+		a.Reset()
+		b.Reset()
+		if _, err := item.Encode(&a); err != nil {
+			return false
+		}
+		if _, err := otherItem.Encode(&b); err != nil {
+			return false
+		}
+		if !bytes.Equal(a.Bytes(), b.Bytes()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks every item and then the record as a whole against the UAP
+func (r *Record) Validate() error {
+	for _, field := range r.uap.Fields() {
+		item, exists := r.items[field.DataItem]
+		if !exists {
+			continue
+		}
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("validating %s: %w", field.DataItem, err)
+		}
+	}
+	return r.uap.Validate(r.items)
+}
+
+// Reset removes every item from the record and clears its FSPEC. A record
+// created with a UAPSelector goes back to it. The removed items are kept for
+// DecodeInto to reuse.
+func (r *Record) Reset() {
+	if r.spare == nil {
+		r.spare = make(map[string]DataItem, len(r.items))
+	}
+	for id, item := range r.items {
+		r.spare[id] = item
+	}
+	clear(r.items)
+	r.fspec.bits = r.fspec.bits[:0]
+	if r.selector != nil {
+		r.uap = r.selector
+	}
+}
+
+// DecodeInto is Decode for hot loops: it resets the record and decodes into
+// the items the record held before, or removed by an earlier Reset, where
+// the same item is present again, instead of allocating new ones. Only items
+// implementing Resetter are reused, reset before they are decoded, so the
+// result is the same as that of Decode. Items obtained from the record
+// before the call must not be used afterwards, since they may now hold the
+// new record's values.
+func (r *Record) DecodeInto(buf *bytes.Buffer) (int, error) {
+	r.Reset()
+	return r.decode(buf)
+}
+
+// Decode reads a record from a buffer into newly created items. When the
+// record's UAP is a UAPSelector, the record switches to the profile it
+// selects once the shared fields are decoded.
 func (r *Record) Decode(buf *bytes.Buffer) (int, error) {
+	if r.selector != nil {
+		r.uap = r.selector
+	}
+	r.items = make(map[string]DataItem)
+	r.spare = nil
+	return r.decode(buf)
+}
+
+// decode reads a record into r.items, which the caller has emptied
+func (r *Record) decode(buf *bytes.Buffer) (int, error) {
 	if buf.Len() == 0 {
 		return 0, io.EOF
 	}
 
+	size := buf.Len()
 	bytesRead := 0
 
 	// Read FSPEC
 	n, err := r.fspec.Decode(buf)
 	if err != nil {
-		return bytesRead, fmt.Errorf("decoding FSPEC: %w", err)
+		return bytesRead, NewDecodeError(r.category, "decoding FSPEC", err).WithPosition(0, size)
 	}
 	bytesRead += n
 
-	// Clear existing items
-	r.items = make(map[string]DataItem)
-
 	// Read items based on FSPEC
-	for _, field := range r.uap.Fields() {
-		if !r.fspec.GetFRN(field.FRN) {
+	shared := r.uap.Fields()
+	n, err = r.decodeFields(buf, shared, 0, bytesRead)
+	bytesRead += n
+	if err != nil {
+		return bytesRead, withBufferSize(err, size)
+	}
+
+	if sel, ok := r.uap.(UAPSelector); ok {
+		profile, err := sel.SelectUAP(r.items)
+		if err != nil {
+			return bytesRead, fmt.Errorf("selecting UAP: %w", err)
+		}
+		r.selector, r.uap = sel, profile
+
+		n, err := r.decodeFields(buf, profile.Fields(), lastFRN(shared), bytesRead)
+		bytesRead += n
+		if err != nil {
+			return bytesRead, withBufferSize(err, size)
+		}
+	}
+
+	return bytesRead, r.uap.Validate(r.items)
+}
+
+// decodeFields decodes the items of the given fields above FRN after that
+// are marked in the FSPEC, creating them with the record's UAP. offset is the
+// number of record bytes read before, so that a failing item is reported as
+// a *DecodeError at its offset in the record.
+func (r *Record) decodeFields(buf *bytes.Buffer, fields []DataField, after uint8, offset int) (int, error) {
+	bytesRead := 0
+	fail := func(id string, err error) (int, error) {
+		return bytesRead, NewDecodeError(r.category, "", err).
+			WithDataItem(id).WithPosition(offset+bytesRead, 0)
+	}
+	for _, field := range fields {
+		if field.FRN <= after || !r.fspec.GetFRN(field.FRN) {
 			continue
 		}
 
 		// Check if we have enough bytes for fixed-length items
 		if field.Type == Fixed && buf.Len() < int(field.Length) {
-			return bytesRead, fmt.Errorf("buffer too short for %s: need %d bytes, have %d",
-				field.DataItem, field.Length, buf.Len())
+			return fail(field.DataItem, fmt.Errorf("%w: need %d bytes, have %d",
+				ErrBufferTooShort, field.Length, buf.Len()))
 		}
 
-		item, err := r.uap.CreateDataItem(field.DataItem)
+		// Bound the FX chain of extended items and compound primary subfields
+		if field.Type == Extended || field.Type == Compound {
+			if err := CheckFXChain(buf.Bytes(), r.maxFXDepth); err != nil {
+				return fail(field.DataItem, err)
+			}
+		}
+
+		item, ok := reuseItem(r.spare, field.DataItem)
+		var err error
+		if !ok {
+			item, err = r.uap.CreateDataItem(field.DataItem)
+		}
 		if err != nil {
 			if field.Type == Fixed {
 				// For fixed length items, we can skip unknown ones
-				if buf.Len() < int(field.Length) {
-					return bytesRead, fmt.Errorf("buffer too short to skip %s: need %d bytes, have %d",
-						field.DataItem, field.Length, buf.Len())
-				}
 				buf.Next(int(field.Length))
 				bytesRead += int(field.Length)
 				continue
 			}
-			return bytesRead, fmt.Errorf("creating %s: %w", field.DataItem, err)
+			return fail(field.DataItem, fmt.Errorf("creating item: %w", err))
 		}
 
+		limitFXDepth(item, r.maxFXDepth)
 		n, err := item.Decode(buf)
 		if err != nil {
-			return bytesRead, fmt.Errorf("decoding %s: %w", field.DataItem, err)
+			return fail(field.DataItem, err)
 		}
 		bytesRead += n
 
 		r.items[field.DataItem] = item
 	}
+	return bytesRead, nil
+}
+
+// reuseItem takes item id out of spare and resets it, ready to be decoded
+// into. ok is false when spare holds no such item or the item is not a
+// Resetter.
+func reuseItem(spare map[string]DataItem, id string) (DataItem, bool) {
+	item, ok := spare[id]
+	if !ok {
+		return nil, false
+	}
+	delete(spare, id)
+	r, ok := item.(Resetter)
+	if !ok {
+		return nil, false
+	}
+	r.Reset()
+	return item, true
+}
 
-	return bytesRead, r.uap.Validate(r.items)
+// withBufferSize sets the buffer size of a *DecodeError at the head of err
+func withBufferSize(err error, size int) error {
+	if de, ok := err.(*DecodeError); ok {
+		de.BufferSize = size
+	}
+	return err
+}
+
+// lastFRN returns the highest FRN of the given fields
+func lastFRN(fields []DataField) uint8 {
+	var last uint8
+	for _, field := range fields {
+		last = max(last, field.FRN)
+	}
+	return last
 }
@@ -0,0 +1,41 @@
+// asterix/record_builder.go
+package asterix
+
+// RecordBuilder assembles a record through chained Set calls. The first
+// error stops the chain: later calls are ignored and Build reports it.
+//
+//	record, err := asterix.NewRecordBuilder(asterix.Cat021, uap).
+//		Set("I021/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100}).
+//		Set("I021/040", &v26.TargetReportDescriptor{ATP: 1}).
+//		Build()
+type RecordBuilder struct {
+	record *Record
+	err    error
+}
+
+// NewRecordBuilder starts a record of the given category. An invalid
+// category or UAP is reported by Build.
+func NewRecordBuilder(cat Category, uap UAP) *RecordBuilder {
+	record, err := NewRecord(cat, uap)
+	return &RecordBuilder{record: record, err: err}
+}
+
+// Set adds or replaces a data item, as Record.SetDataItem does
+func (b *RecordBuilder) Set(id string, item DataItem) *RecordBuilder {
+	if b.err == nil {
+		b.err = b.record.SetDataItem(id, item)
+	}
+	return b
+}
+
+// Build returns the record once every item was accepted and the record as a
+// whole, including its mandatory items, validates against the UAP
+func (b *RecordBuilder) Build() (*Record, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.record.Validate(); err != nil {
+		return nil, err
+	}
+	return b.record, nil
+}
@@ -0,0 +1,58 @@
+// asterix/record_builder_test.go
+package asterix_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestRecordBuilder(t *testing.T) {
+	uap := newCat021UAP(t)
+
+	record, err := asterix.NewRecordBuilder(asterix.Cat021, uap).
+		Set("I021/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100}).
+		Set("I021/040", &v26.TargetReportDescriptor{ATP: 1}).
+		Set("I021/080", &v26.TargetAddress{Address: 0xABCDEF}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := newCat021Record(t, uap, 25, 100, 0xABCDEF)
+	if !record.Equal(want) {
+		t.Errorf("Build() = %v, want the record built with SetDataItem", record.Describe())
+	}
+}
+
+func TestRecordBuilder_Errors(t *testing.T) {
+	uap := newCat021UAP(t)
+
+	// The unknown item fails the chain; the valid item after it is ignored
+	_, err := asterix.NewRecordBuilder(asterix.Cat021, uap).
+		Set("I021/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100}).
+		Set("I021/999", &common.ServiceIdentification{}).
+		Set("I021/040", &v26.TargetReportDescriptor{ATP: 1}).
+		Build()
+	if !errors.Is(err, asterix.ErrUnknownDataItem) {
+		t.Errorf("Build() error = %v, want %v", err, asterix.ErrUnknownDataItem)
+	}
+
+	// Every item is accepted, but the mandatory data source is missing
+	_, err = asterix.NewRecordBuilder(asterix.Cat021, uap).
+		Set("I021/040", &v26.TargetReportDescriptor{ATP: 1}).
+		Build()
+	if !errors.Is(err, asterix.ErrMandatoryField) {
+		t.Errorf("Build() error = %v, want %v", err, asterix.ErrMandatoryField)
+	}
+
+	// A UAP of another category is reported by Build
+	_, err = asterix.NewRecordBuilder(asterix.Cat048, uap).
+		Set("I021/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100}).
+		Build()
+	if !errors.Is(err, asterix.ErrInvalidMessage) {
+		t.Errorf("Build() error = %v, want %v", err, asterix.ErrInvalidMessage)
+	}
+}
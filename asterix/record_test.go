@@ -0,0 +1,509 @@
+// asterix/record_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat021"
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	"github.com/davidkohl/gobelix/cat/cat062"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func newCat021UAP(t testing.TB) asterix.UAP {
+	t.Helper()
+	uap, err := cat021.NewUAP(cat021.Version26)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	return uap
+}
+
+// newCat021Record builds a minimal Cat021 record carrying the mandatory items
+func newCat021Record(t testing.TB, uap asterix.UAP, sac, sic uint8, address uint32) *asterix.Record {
+	t.Helper()
+	record, err := asterix.NewRecord(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I021/010", &common.DataSourceIdentifier{SAC: sac, SIC: sic}},
+		{"I021/040", &v26.TargetReportDescriptor{ATP: 1}},
+		{"I021/080", &v26.TargetAddress{Address: address}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+	return record
+}
+
+func encodeRecord(t *testing.T, r *asterix.Record) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if _, err := r.Encode(buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRecord_Normalize(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	// Record produced by another encoder: the I062/380 FSPEC carries a
+	// superfluous empty extension octet (81 00 instead of 80)
+	raw := []byte{
+		0x91, 0x1C, // FSPEC: 010, 070, 380, 040, 080
+		0x01, 0x02, // I062/010
+		0x00, 0x00, 0x80, // I062/070
+		0x81, 0x00, 0xAB, 0xCD, 0xEF, // I062/380 target address
+		0x00, 0x2A, // I062/040
+		0x00, // I062/080
+	}
+	fromRaw, err := asterix.NewRecord(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	if _, err := fromRaw.Decode(bytes.NewBuffer(raw)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	// Same content built from typed fields
+	fromTyped, err := asterix.NewRecord(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	addr := uint32(0xABCDEF)
+	items := map[string]asterix.DataItem{
+		"I062/010": &common.DataSourceIdentifier{SAC: 1, SIC: 2},
+		"I062/070": &v117.TimeOfTrackInformation{Time: 1.0},
+		"I062/380": &v117.AircraftDerivedData{TargetAddress: &addr},
+		"I062/040": &v117.TrackNumber{Value: 42},
+		"I062/080": &v117.TrackStatus{},
+	}
+	for id, item := range items {
+		if err := fromTyped.SetDataItem(id, item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", id, err)
+		}
+	}
+
+	if bytes.Equal(encodeRecord(t, fromRaw), encodeRecord(t, fromTyped)) {
+		t.Fatalf("records encode identically before Normalize, test input is not exercising raw bytes")
+	}
+
+	if err := fromRaw.Normalize(); err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if err := fromTyped.Normalize(); err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+
+	a, b := encodeRecord(t, fromRaw), encodeRecord(t, fromTyped)
+	if !bytes.Equal(a, b) {
+		t.Errorf("normalized records differ:\n raw:   % X\n typed: % X", a, b)
+	}
+}
+
+// A record Normalize cannot bring into typed form keeps the bytes it was
+// decoded from
+func TestRecord_NormalizeFailureKeepsRecord(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	raw := []byte{
+		0x91, 0x1C, // FSPEC: 010, 070, 380, 040, 080
+		0x01, 0x02, // I062/010
+		0x00, 0x00, 0x80, // I062/070
+		0x81, 0x00, 0xAB, 0xCD, 0xEF, // I062/380 target address
+		0x00, 0x2A, // I062/040
+		0x00, // I062/080
+	}
+	record, err := asterix.NewRecord(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	if _, err := record.Decode(bytes.NewBuffer(raw)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	// Typed fields changed behind the item's back no longer encode
+	item, _, _ := record.GetDataItem("I062/380")
+	callsign := "DLH#4AB"
+	item.(*v117.AircraftDerivedData).TargetIdentification = &callsign
+
+	if err := record.Normalize(); err == nil {
+		t.Fatalf("Normalize() accepted the identification %q", callsign)
+	}
+	if got := encodeRecord(t, record); !bytes.Equal(got, raw) {
+		t.Errorf("Encode() after failed Normalize = % X, want % X", got, raw)
+	}
+}
+
+func TestRecord_Describe(t *testing.T) {
+	uap := newCat021UAP(t)
+	record := newCat021Record(t, uap, 25, 100, 0xABCDEF)
+
+	views := record.Describe()
+	want := []struct {
+		frn         int
+		id          string
+		description string
+		raw         []byte
+	}{
+		{1, "I021/010", "Data Source Identification", []byte{25, 100}},
+		{2, "I021/040", "Target Report Descriptor", []byte{0x20}},
+		{11, "I021/080", "Target Address", []byte{0xAB, 0xCD, 0xEF}},
+	}
+	if len(views) != len(want) {
+		t.Fatalf("Describe() returned %d views, want %d", len(views), len(want))
+	}
+	for i, w := range want {
+		v := views[i]
+		if v.FRN != w.frn || v.ID != w.id || v.Description != w.description {
+			t.Errorf("view %d = {%d %s %q}, want {%d %s %q}", i, v.FRN, v.ID, v.Description, w.frn, w.id, w.description)
+		}
+		if !bytes.Equal(v.Raw, w.raw) {
+			t.Errorf("view %d raw = % X, want % X", i, v.Raw, w.raw)
+		}
+		if v.Value == "" {
+			t.Errorf("view %d has an empty value", i)
+		}
+	}
+}
+
+func TestRecord_PresentFRNs(t *testing.T) {
+	uap := newCat021UAP(t)
+	record := newCat021Record(t, uap, 25, 100, 0xABCDEF)
+
+	// I021/010, I021/040 and I021/080 are FRNs 1, 2 and 11 of the v2.6 UAP
+	if got, want := record.PresentFRNs(), []uint8{1, 2, 11}; !slices.Equal(got, want) {
+		t.Errorf("PresentFRNs() = %v, want %v", got, want)
+	}
+}
+
+func TestRecord_GetField(t *testing.T) {
+	record := newCat021Record(t, newCat021UAP(t), 25, 10, 0x3C6544)
+
+	if got, ok := record.GetField("I021/080", "Address"); !ok || got != uint32(0x3C6544) {
+		t.Errorf("GetField(I021/080, Address) = %v, %v, want 3C6544", got, ok)
+	}
+	if got, ok := record.GetField("I021/010", "SIC"); !ok || got != uint8(10) {
+		t.Errorf("GetField(I021/010, SIC) = %v, %v, want 10", got, ok)
+	}
+	for _, tt := range []struct{ id, name string }{
+		{"I021/080", "Callsign"}, // no such field
+		{"I021/130", "Latitude"}, // item absent
+		{"I021/040", "ATP"},      // item has no FieldGetter
+	} {
+		if got, ok := record.GetField(tt.id, tt.name); ok {
+			t.Errorf("GetField(%s, %s) = %v, want not found", tt.id, tt.name, got)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	raw := []byte{
+		0x91, 0x1C, // FSPEC: 010, 070, 380, 040, 080
+		0x01, 0x02, // I062/010
+		0x00, 0x00, 0x80, // I062/070
+		0x80, 0xAB, 0xCD, 0xEF, // I062/380 target address
+		0x00, 0x2A, // I062/040
+		0x00, // I062/080
+	}
+	record, err := asterix.NewRecord(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	if _, err := record.Decode(bytes.NewBuffer(raw)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	// The decoded I380 replays its raw bytes unless Apply discards them
+	err = asterix.Apply(record, "I062/380", func(a *v117.AircraftDerivedData) error {
+		addr := uint32(0x123456)
+		a.TargetAddress = &addr
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	encoded := encodeRecord(t, record)
+	if !bytes.Contains(encoded, []byte{0x80, 0x12, 0x34, 0x56}) {
+		t.Errorf("Encode() = % X, want the changed target address", encoded)
+	}
+
+	err = asterix.Apply(record, "I062/380", func(a *v117.AircraftDerivedData) error {
+		addr := uint32(0x1000000)
+		a.TargetAddress = &addr
+		return nil
+	})
+	if err == nil {
+		t.Errorf("Apply() accepted an invalid target address")
+	}
+	errHalfway := errors.New("halfway")
+	err = asterix.Apply(record, "I062/380", func(a *v117.AircraftDerivedData) error {
+		a.TargetAddress = nil
+		return errHalfway
+	})
+	if !errors.Is(err, errHalfway) {
+		t.Errorf("Apply() error = %v, want %v", err, errHalfway)
+	}
+
+	// Neither failed change is left in the record
+	item, _, _ := record.GetDataItem("I062/380")
+	if a := item.(*v117.AircraftDerivedData); a.TargetAddress == nil || *a.TargetAddress != 0x123456 {
+		t.Errorf("I062/380 after failed Apply() = %+v, want target address 123456", a)
+	}
+	if encoded := encodeRecord(t, record); !bytes.Contains(encoded, []byte{0x80, 0x12, 0x34, 0x56}) {
+		t.Errorf("Encode() after failed Apply() = % X, want target address 123456", encoded)
+	}
+
+	err = asterix.Apply(record, "I062/040", func(*v117.AircraftDerivedData) error { return nil })
+	if !errors.Is(err, asterix.ErrInvalidField) {
+		t.Errorf("Apply() with the wrong type error = %v, want %v", err, asterix.ErrInvalidField)
+	}
+	err = asterix.Apply(record, "I062/290", func(*v117.SystemTrackUpdateAges) error { return nil })
+	if !errors.Is(err, asterix.ErrUnknownDataItem) {
+		t.Errorf("Apply() on an absent item error = %v, want %v", err, asterix.ErrUnknownDataItem)
+	}
+}
+
+// encodeUncached encodes the record the long way, walking every field of
+// its UAP, as a reference for the cached layouts of Record.Encode
+func encodeUncached(t *testing.T, r *asterix.Record) []byte {
+	t.Helper()
+	fspec := asterix.NewFSPEC()
+	for _, frn := range r.PresentFRNs() {
+		if err := fspec.SetFRN(frn); err != nil {
+			t.Fatalf("SetFRN() error = %v", err)
+		}
+	}
+	buf := new(bytes.Buffer)
+	if _, err := fspec.Encode(buf); err != nil {
+		t.Fatalf("FSPEC.Encode() error = %v", err)
+	}
+	for _, field := range r.UAP().Fields() {
+		if item, _, ok := r.GetDataItem(field.DataItem); ok {
+			if _, err := item.Encode(buf); err != nil {
+				t.Fatalf("Encode(%s) error = %v", field.DataItem, err)
+			}
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestRecord_EncodeLayoutCache(t *testing.T) {
+	uap := newCat021UAP(t)
+
+	// Records sharing an item set share a layout
+	for i := uint32(0); i < 3; i++ {
+		record := newCat021Record(t, uap, 25, 100, 0x3C6586+i)
+		if got, want := encodeRecord(t, record), encodeUncached(t, record); !bytes.Equal(got, want) {
+			t.Errorf("record %d: Encode() = % X, want % X", i, got, want)
+		}
+	}
+
+	// Adding an item after encoding changes the layout
+	record := newCat021Record(t, uap, 25, 100, 0x3C6586)
+	encodeRecord(t, record)
+	if err := record.SetDataItem("I021/071", &v26.TimeOfApplicabilityPosition{Time: 3600}); err != nil {
+		t.Fatalf("SetDataItem() error = %v", err)
+	}
+	if got, want := encodeRecord(t, record), encodeUncached(t, record); !bytes.Equal(got, want) {
+		t.Errorf("after SetDataItem: Encode() = % X, want % X", got, want)
+	}
+}
+
+func BenchmarkRecord_EncodeSameSchema(b *testing.B) {
+	uap, err := cat021.NewUAP(cat021.Version26)
+	if err != nil {
+		b.Fatalf("NewUAP() error = %v", err)
+	}
+	records := make([]*asterix.Record, 10000)
+	for i := range records {
+		record, err := asterix.NewRecord(asterix.Cat021, uap)
+		if err != nil {
+			b.Fatalf("NewRecord() error = %v", err)
+		}
+		record.SetDataItem("I021/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100})
+		record.SetDataItem("I021/040", &v26.TargetReportDescriptor{ATP: 1})
+		record.SetDataItem("I021/071", &v26.TimeOfApplicabilityPosition{Time: float64(i) / 128})
+		record.SetDataItem("I021/080", &v26.TargetAddress{Address: uint32(i)})
+		records[i] = record
+	}
+
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, record := range records {
+			buf.Reset()
+			if _, err := record.Encode(&buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestRecord_HexDump(t *testing.T) {
+	record := newCat021Record(t, newCat021UAP(t), 25, 100, 0xABCDEF)
+
+	want := "" +
+		"0000  [FSPEC]     C1 10\n" +
+		"0002  [I021/010]  19 64\n" +
+		"0004  [I021/040]  20\n" +
+		"0005  [I021/080]  AB CD EF\n"
+	if got := record.HexDump(); got != want {
+		t.Errorf("HexDump() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// cat062Records encodes Cat062 records whose item sets and I062/380
+// subfields differ from one record to the next
+func cat062Records(tb testing.TB, uap asterix.UAP) [][]byte {
+	tb.Helper()
+	addr, ident, heading := uint32(0x3C6586), "DLH4AB", 90.0
+	adds := []*v117.AircraftDerivedData{
+		{TargetAddress: &addr, TargetIdentification: &ident},
+		{MagneticHeading: &heading},
+		nil,
+		{TargetAddress: &addr, ModeSMBData: []v117.ModeSMB{
+			{BDS1: 4, BDS2: 0, Data: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}},
+		}},
+		{TargetIdentification: &ident},
+	}
+
+	var records [][]byte
+	for i, add := range adds {
+		record, err := asterix.NewRecord(asterix.Cat062, uap)
+		if err != nil {
+			tb.Fatalf("NewRecord() error = %v", err)
+		}
+		record.SetDataItem("I062/010", &common.DataSourceIdentifier{SAC: 25, SIC: uint8(100 + i)})
+		record.SetDataItem("I062/040", &v117.TrackNumber{Value: uint16(i)})
+		record.SetDataItem("I062/070", &v117.TimeOfTrackInformation{Time: 3600 + float64(i)})
+		record.SetDataItem("I062/080", &v117.TrackStatus{MON: i%2 == 0, CNF: i%3 == 0})
+		if i%2 == 1 {
+			record.SetDataItem("I062/105", &v117.CalculatedPositionWGS84{Latitude: 50.1, Longitude: 8.6})
+		}
+		if add != nil {
+			record.SetDataItem("I062/380", add)
+		}
+		buf := new(bytes.Buffer)
+		if _, err := record.Encode(buf); err != nil {
+			tb.Fatalf("Encode() error = %v", err)
+		}
+		records = append(records, buf.Bytes())
+	}
+	return records
+}
+
+func TestRecord_DecodeInto(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	reused, err := asterix.NewRecord(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+
+	// Twice over, so that every item is reused after a record without it
+	data := cat062Records(t, uap)
+	for i, raw := range append(data, data...) {
+		fresh, _ := asterix.NewRecord(asterix.Cat062, uap)
+		if _, err := fresh.Decode(bytes.NewBuffer(raw)); err != nil {
+			t.Fatalf("record %d: Decode() error = %v", i, err)
+		}
+		if _, err := reused.DecodeInto(bytes.NewBuffer(raw)); err != nil {
+			t.Fatalf("record %d: DecodeInto() error = %v", i, err)
+		}
+
+		if got, want := reused.PresentFRNs(), fresh.PresentFRNs(); !slices.Equal(got, want) {
+			t.Errorf("record %d: PresentFRNs() = %v, want %v", i, got, want)
+		}
+		for _, field := range uap.Fields() {
+			got, _, _ := reused.GetDataItem(field.DataItem)
+			want, _, _ := fresh.GetDataItem(field.DataItem)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("record %d: %s = %+v, want %+v", i, field.DataItem, got, want)
+			}
+		}
+	}
+
+	reused.Reset()
+	if frns := reused.PresentFRNs(); len(frns) != 0 {
+		t.Errorf("PresentFRNs() after Reset() = %v, want none", frns)
+	}
+}
+
+func BenchmarkRecord_DecodeInto(b *testing.B) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		b.Fatalf("NewUAP() error = %v", err)
+	}
+	data := cat062Records(b, uap)
+
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			record, _ := asterix.NewRecord(asterix.Cat062, uap)
+			if _, err := record.Decode(bytes.NewBuffer(data[i%len(data)])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("DecodeInto", func(b *testing.B) {
+		record, _ := asterix.NewRecord(asterix.Cat062, uap)
+		var buf bytes.Buffer
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			buf.Write(data[i%len(data)])
+			if _, err := record.DecodeInto(&buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestRecord_ItemBytes(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	data := cat062Records(t, uap)[0]
+	record, _ := asterix.NewRecord(asterix.Cat062, uap)
+	if _, err := record.Decode(bytes.NewBuffer(data)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	// I062/380 keeps the bytes it was decoded from, I062/010 is re-encoded
+	add, ok := record.ItemBytes("I062/380")
+	if want := []byte{0xC0, 0x3C, 0x65, 0x86}; !ok || !bytes.HasPrefix(add, want) || !bytes.Contains(data, add) {
+		t.Errorf("ItemBytes(I062/380) = % X, %v, want octets of % X", add, ok, data)
+	}
+	if src, ok := record.ItemBytes("I062/010"); !ok || !bytes.Equal(src, []byte{25, 100}) {
+		t.Errorf("ItemBytes(I062/010) = % X, %v, want 19 64", src, ok)
+	}
+	if _, ok := record.ItemBytes("I062/500"); ok {
+		t.Error("ItemBytes() of an absent item reported bytes")
+	}
+}
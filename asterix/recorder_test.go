@@ -0,0 +1,91 @@
+// asterix/recorder_test.go
+package asterix_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+func TestFileRecorder_RoundTrip(t *testing.T) {
+	uap := newCat021UAP(t)
+	var blocks []*asterix.DataBlock
+	for i := uint32(1); i <= 3; i++ {
+		block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		for j := uint32(0); j < i; j++ {
+			if err := block.AddRecord(newCat021Record(t, uap, 25, 100, i*10+j)); err != nil {
+				t.Fatalf("AddRecord() error = %v", err)
+			}
+		}
+		blocks = append(blocks, block)
+	}
+
+	var out bytes.Buffer
+	w := bufio.NewWriter(&out)
+	recorder := asterix.NewFileRecorder(w)
+	for i, block := range blocks {
+		if err := recorder.Write(block); err != nil {
+			t.Fatalf("Write(block %d) error = %v", i, err)
+		}
+	}
+	if w.Buffered() != 0 {
+		t.Errorf("%d bytes left in the bufio.Writer, want every block flushed", w.Buffered())
+	}
+
+	decoder, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	decoded, err := asterix.DecodeAllFrom(decoder, bytes.NewReader(out.Bytes()))
+	if err != nil {
+		t.Fatalf("DecodeAllFrom() error = %v", err)
+	}
+	if len(decoded) != len(blocks) {
+		t.Fatalf("DecodeAllFrom() = %d blocks, want %d", len(decoded), len(blocks))
+	}
+	for i := range blocks {
+		if !decoded[i].Equal(blocks[i]) {
+			t.Errorf("block %d differs after the round trip", i)
+		}
+	}
+
+	// With timestamps each block follows the time it was written
+	var stamped bytes.Buffer
+	before := time.Now()
+	recorder = asterix.NewFileRecorder(&stamped, asterix.WithTimestamps())
+	for i, block := range blocks {
+		if err := recorder.Write(block); err != nil {
+			t.Fatalf("Write(block %d) error = %v", i, err)
+		}
+	}
+	data := stamped.Bytes()
+	for i := range blocks {
+		if len(data) < asterix.TimestampHeaderLength+3 {
+			t.Fatalf("recording ends before block %d", i)
+		}
+		at := time.Unix(0, int64(binary.BigEndian.Uint64(data)))
+		if at.Before(before) || at.After(time.Now()) {
+			t.Errorf("block %d timestamp %v outside the recording", i, at)
+		}
+		data = data[asterix.TimestampHeaderLength:]
+		length := int(binary.BigEndian.Uint16(data[1:3]))
+		block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		if err := block.Decode(data[:length]); err != nil {
+			t.Fatalf("Decode(block %d) error = %v", i, err)
+		}
+		if !block.Equal(blocks[i]) {
+			t.Errorf("timestamped block %d differs after the round trip", i)
+		}
+		data = data[length:]
+	}
+}
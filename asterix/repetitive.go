@@ -0,0 +1,63 @@
+// asterix/repetitive.go
+package asterix
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ReadRepetitive reads a repetitive field: a one-octet repetition factor
+// followed by that many fixed-size elements of elemLen octets each.
+// It returns the elements (each a copy) and the total number of bytes read.
+func ReadRepetitive(buf *bytes.Buffer, elemLen int) ([][]byte, int, error) {
+	if elemLen <= 0 {
+		return nil, 0, fmt.Errorf("%w: element length must be positive", ErrInvalidField)
+	}
+
+	rep, err := buf.ReadByte()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: reading repetition factor", ErrBufferTooShort)
+	}
+	bytesRead := 1
+
+	if buf.Len() < int(rep)*elemLen {
+		return nil, bytesRead, fmt.Errorf("%w: need %d bytes for %d repetitions, have %d",
+			ErrBufferTooShort, int(rep)*elemLen, rep, buf.Len())
+	}
+
+	elems := make([][]byte, int(rep))
+	for i := range elems {
+		elems[i] = make([]byte, elemLen)
+		copy(elems[i], buf.Next(elemLen))
+		bytesRead += elemLen
+	}
+
+	return elems, bytesRead, nil
+}
+
+// WriteRepetitive writes a repetition factor followed by the given elements.
+// All elements must have the same length elemLen.
+func WriteRepetitive(buf *bytes.Buffer, elemLen int, elems [][]byte) (int, error) {
+	if len(elems) > 255 {
+		return 0, fmt.Errorf("%w: too many repetitions: %d (max 255)", ErrInvalidField, len(elems))
+	}
+
+	if err := buf.WriteByte(byte(len(elems))); err != nil {
+		return 0, fmt.Errorf("writing repetition factor: %w", err)
+	}
+	bytesWritten := 1
+
+	for i, elem := range elems {
+		if len(elem) != elemLen {
+			return bytesWritten, fmt.Errorf("%w: repetition %d has %d bytes, want %d",
+				ErrInvalidField, i, len(elem), elemLen)
+		}
+		n, err := buf.Write(elem)
+		bytesWritten += n
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing repetition %d: %w", i, err)
+		}
+	}
+
+	return bytesWritten, nil
+}
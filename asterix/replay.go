@@ -0,0 +1,105 @@
+// asterix/replay.go
+package asterix
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Replayer plays back a recording made by a FileRecorder WithTimestamps,
+// delivering its blocks with the time between them they were recorded with
+type Replayer struct {
+	r     io.Reader
+	dec   *Decoder
+	speed float64
+}
+
+// ReplayerOption configures a Replayer created by NewReplayer
+type ReplayerOption func(*Replayer)
+
+// WithSpeed makes the Replayer play factor times faster than recorded, so
+// 2 halves the gaps between blocks and 0.5 doubles them. A factor of 0 or
+// less keeps the recorded speed.
+func WithSpeed(factor float64) ReplayerOption {
+	return func(p *Replayer) {
+		if factor > 0 {
+			p.speed = factor
+		}
+	}
+}
+
+// NewReplayer creates a Replayer reading the timestamped recording r and
+// decoding its blocks with dec
+func NewReplayer(r io.Reader, dec *Decoder, opts ...ReplayerOption) *Replayer {
+	p := &Replayer{r: r, dec: dec, speed: 1}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Play passes each block of the recording to cb in order. The first block is
+// delivered at once and every later one when, scaled by WithSpeed, as much
+// time has passed since the first as had when it was recorded; a block
+// recorded earlier than its predecessor is delivered right away. Play returns
+// nil at the end of the recording, or the first error from reading, decoding
+// or cb. It stops with an error wrapping ctx.Err() once ctx is done, also
+// while waiting for the next block.
+func (p *Replayer) Play(ctx context.Context, cb func(*DataBlock) error) error {
+	stamp := make([]byte, TimestampHeaderLength)
+	header := make([]byte, 3)
+	frame := p.dec.buffers.Get()
+	defer p.dec.buffers.Put(frame)
+	var first, start time.Time
+	for n := 0; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("replay stopped after %d blocks: %w", n, err)
+		}
+		if _, err := io.ReadFull(p.r, stamp); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("block %d: reading timestamp: %w", n, err)
+		}
+		at := time.Unix(0, int64(binary.BigEndian.Uint64(stamp)))
+		data, err := readFrame(p.r, header, frame)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return fmt.Errorf("block %d: %w", n, err)
+		}
+		block, err := p.dec.decodeFrame(data)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", n, err)
+		}
+
+		if n == 0 {
+			first, start = at, time.Now()
+		} else if err := p.wait(ctx, start.Add(time.Duration(float64(at.Sub(first))/p.speed))); err != nil {
+			return fmt.Errorf("replay stopped after %d blocks: %w", n, err)
+		}
+		if err := cb(block); err != nil {
+			return fmt.Errorf("block %d: %w", n, err)
+		}
+	}
+}
+
+// wait sleeps until deadline or until ctx is done
+func (p *Replayer) wait(ctx context.Context, deadline time.Time) error {
+	d := time.Until(deadline)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,74 @@
+// asterix/replay_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+// timestampedRecording builds a recording of one-record Cat021 blocks, the
+// i-th stamped i seconds after the first
+func timestampedRecording(t *testing.T, uap asterix.UAP, count int) []byte {
+	t.Helper()
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var recording []byte
+	for i := 0; i < count; i++ {
+		at := start.Add(time.Duration(i) * time.Second)
+		recording = binary.BigEndian.AppendUint64(recording, uint64(at.UnixNano()))
+		recording = append(recording, encodeCat021Block(t, uap, uint32(i+1))...)
+	}
+	return recording
+}
+
+func TestReplayer_Play(t *testing.T) {
+	uap := newCat021UAP(t)
+	decoder, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	recording := timestampedRecording(t, uap, 4)
+
+	// Three one-second gaps take 3 ms at 1000 times the recorded speed
+	player := asterix.NewReplayer(bytes.NewReader(recording), decoder, asterix.WithSpeed(1000))
+	var addrs []any
+	start := time.Now()
+	err = player.Play(context.Background(), func(block *asterix.DataBlock) error {
+		addr, _ := block.Records()[0].GetField("I021/080", "Address")
+		addrs = append(addrs, addr)
+		return nil
+	})
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+	for i, addr := range addrs {
+		if addr != uint32(i+1) {
+			t.Errorf("block %d address = %v, want %d", i, addr, i+1)
+		}
+	}
+	if len(addrs) != 4 {
+		t.Errorf("Play() delivered %d blocks, want 4", len(addrs))
+	}
+	if elapsed < 3*time.Millisecond || elapsed > time.Second {
+		t.Errorf("Play() took %v, want about 3ms", elapsed)
+	}
+
+	// Cancelling while waiting for the next block stops the replay
+	ctx, cancel := context.WithCancel(context.Background())
+	player = asterix.NewReplayer(bytes.NewReader(recording), decoder)
+	calls := 0
+	err = player.Play(ctx, func(*asterix.DataBlock) error {
+		calls++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("Play() after cancel = %d blocks, %v, want 1 block and %v", calls, err, context.Canceled)
+	}
+}
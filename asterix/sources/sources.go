@@ -0,0 +1,50 @@
+// asterix/sources/sources.go
+
+// Package sources provides a process-wide registry mapping ASTERIX data
+// source identifiers (SAC/SIC) to human-readable sensor names.
+package sources
+
+import "sync"
+
+// ID identifies a data source by System Area Code and System Identification Code
+type ID struct {
+	SAC uint8
+	SIC uint8
+}
+
+var (
+	mu    sync.RWMutex
+	names = make(map[ID]string)
+)
+
+// Register associates a name with a SAC/SIC pair, replacing any previous name
+func Register(sac, sic uint8, name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	names[ID{SAC: sac, SIC: sic}] = name
+}
+
+// Name returns the registered name for a SAC/SIC pair
+func Name(sac, sic uint8) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	name, ok := names[ID{SAC: sac, SIC: sic}]
+	return name, ok
+}
+
+// Load registers all entries of the given map, replacing existing names for
+// the same identifiers
+func Load(entries map[ID]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for id, name := range entries {
+		names[id] = name
+	}
+}
+
+// Reset removes all registered names
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	names = make(map[ID]string)
+}
@@ -0,0 +1,64 @@
+// asterix/subfields/subfields.go
+
+// Package subfields describes the subfields of compound data items as a
+// table, so that behaviour common to all of them can be driven by data
+// instead of being written out per item.
+package subfields
+
+import "strings"
+
+// Field describes one subfield of a compound item of type T
+type Field[T any] struct {
+	Name    string
+	Present func(item T) bool
+	// Format returns the value of a present subfield. A nil Format prints
+	// the name alone, which suits flag-like subfields.
+	Format func(item T) string
+}
+
+// Stringer formats compound items of type T from their subfield table. Every
+// present subfield is listed in table order as "Name: value", so a String
+// built on it never omits data the item carries.
+type Stringer[T any] struct {
+	Type   string // Printed before the subfield list, e.g. "AircraftDerivedData"
+	Fields []Field[T]
+}
+
+// String formats item as Type[Name: value, ...], or Type[empty] when no
+// subfield is present
+func (s Stringer[T]) String(item T) string {
+	var sb strings.Builder
+	sb.WriteString(s.Type)
+	sb.WriteByte('[')
+	n := 0
+	for _, f := range s.Fields {
+		if !f.Present(item) {
+			continue
+		}
+		if n > 0 {
+			sb.WriteString(", ")
+		}
+		n++
+		sb.WriteString(f.Name)
+		if f.Format != nil {
+			sb.WriteString(": ")
+			sb.WriteString(f.Format(item))
+		}
+	}
+	if n == 0 {
+		sb.WriteString("empty")
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// Present returns the names of the present subfields in table order
+func (s Stringer[T]) Present(item T) []string {
+	var names []string
+	for _, f := range s.Fields {
+		if f.Present(item) {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
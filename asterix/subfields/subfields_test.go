@@ -0,0 +1,51 @@
+// asterix/subfields/subfields_test.go
+package subfields_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix/subfields"
+)
+
+type item struct {
+	Range *float64
+	Flag  bool
+}
+
+var stringer = subfields.Stringer[*item]{
+	Type: "Item",
+	Fields: []subfields.Field[*item]{
+		{
+			Name:    "Range",
+			Present: func(i *item) bool { return i.Range != nil },
+			Format:  func(i *item) string { return fmt.Sprintf("%.1f NM", *i.Range) },
+		},
+		{
+			Name:    "Flag",
+			Present: func(i *item) bool { return i.Flag },
+		},
+	},
+}
+
+func TestStringer(t *testing.T) {
+	r := 12.5
+	tests := []struct {
+		item    *item
+		want    string
+		present []string
+	}{
+		{&item{}, "Item[empty]", nil},
+		{&item{Range: &r}, "Item[Range: 12.5 NM]", []string{"Range"}},
+		{&item{Range: &r, Flag: true}, "Item[Range: 12.5 NM, Flag]", []string{"Range", "Flag"}},
+	}
+	for _, tt := range tests {
+		if got := stringer.String(tt.item); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		if got := stringer.Present(tt.item); !reflect.DeepEqual(got, tt.present) {
+			t.Errorf("Present() = %v, want %v", got, tt.present)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// asterix/timestamp.go
+package asterix
+
+import "time"
+
+// TimestampSelector may be implemented by a UAP that carries several time
+// items, to state which one best timestamps a record. The returned item IDs
+// are tried in order; the first one present in the record wins.
+type TimestampSelector interface {
+	TimestampItems() []string
+}
+
+// Timestamp returns the record's time of day as elapsed time since midnight
+// UTC. If the UAP implements TimestampSelector its preference order is used;
+// otherwise the first item implementing TimedRecord in UAP order is taken.
+// ok is false when the record carries no time item.
+func (r *Record) Timestamp() (tod time.Duration, ok bool) {
+	if selector, isSelector := r.uap.(TimestampSelector); isSelector {
+		for _, id := range selector.TimestampItems() {
+			if tr, isTimed := r.items[id].(TimedRecord); isTimed {
+				return tr.Duration(), true
+			}
+		}
+		return 0, false
+	}
+
+	for _, field := range r.uap.Fields() {
+		if tr, isTimed := r.items[field.DataItem].(TimedRecord); isTimed {
+			return tr.Duration(), true
+		}
+	}
+	return 0, false
+}
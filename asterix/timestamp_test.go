@@ -0,0 +1,69 @@
+// asterix/timestamp_test.go
+package asterix_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestRecord_Timestamp(t *testing.T) {
+	uap := newCat021UAP(t)
+	record := newCat021Record(t, uap, 25, 100, 0x3C6586)
+
+	if _, ok := record.Timestamp(); ok {
+		t.Fatal("Timestamp() ok without time items")
+	}
+
+	transmission := &v26.TimeOfReportTransmission{TimeOfDay: common.TimeOfDay{Time: 43200.5}}
+	if err := record.SetDataItem("I021/077", transmission); err != nil {
+		t.Fatalf("SetDataItem(I021/077) error = %v", err)
+	}
+	if tod, ok := record.Timestamp(); !ok || tod != 12*time.Hour+500*time.Millisecond {
+		t.Errorf("Timestamp() = %v, %v; want transmission time", tod, ok)
+	}
+
+	// The position time takes precedence over the transmission time
+	if err := record.SetDataItem("I021/073", &v26.TimeOfMessageReceptionPosition{Time: 43200.25}); err != nil {
+		t.Fatalf("SetDataItem(I021/073) error = %v", err)
+	}
+	if tod, ok := record.Timestamp(); !ok || tod != 12*time.Hour+250*time.Millisecond {
+		t.Errorf("Timestamp() = %v, %v; want position reception time", tod, ok)
+	}
+	if err := record.SetDataItem("I021/071", &v26.TimeOfApplicabilityPosition{Time: 43200}); err != nil {
+		t.Fatalf("SetDataItem(I021/071) error = %v", err)
+	}
+	if tod, ok := record.Timestamp(); !ok || tod != 12*time.Hour {
+		t.Errorf("Timestamp() = %v, %v; want time of applicability", tod, ok)
+	}
+}
+
+func TestTimeOfReportTransmission_RoundTrip(t *testing.T) {
+	for _, want := range []float64{0, 1.0 / 128, 43200.5, 86399.9921875} {
+		item := &v26.TimeOfReportTransmission{TimeOfDay: common.TimeOfDay{Time: want}}
+		var buf bytes.Buffer
+		if n, err := item.Encode(&buf); err != nil || n != 3 {
+			t.Fatalf("Encode(%v) = %d, %v", want, n, err)
+		}
+
+		var got v26.TimeOfReportTransmission
+		if _, err := got.Decode(&buf); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if got.Time != want {
+			t.Errorf("round trip %v = %v", want, got.Time)
+		}
+		if d := got.Duration(); d != time.Duration(want*float64(time.Second)) {
+			t.Errorf("Duration() = %v for %v", d, want)
+		}
+	}
+
+	if _, err := (&v26.TimeOfReportTransmission{TimeOfDay: common.TimeOfDay{Time: 86400}}).Encode(new(bytes.Buffer)); err == nil {
+		t.Error("Encode() accepted a time of 86400 s")
+	}
+	var _ asterix.TimedRecord = &v26.TimeOfReportTransmission{}
+}
@@ -0,0 +1,55 @@
+// asterix/track/interpolate.go
+package track
+
+import (
+	"math"
+	"time"
+)
+
+// Interpolate estimates the position of a track at time t from two states
+// of it, a before b. The position moves along the great circle between the
+// two positions at constant speed and the altitude changes linearly. Times
+// outside [a.Time, b.Time] are clamped to the nearest state, as are all
+// times when b is not later than a.
+//
+// The states' HasPosition and HasAltitude flags are not consulted; callers
+// should check them first.
+func Interpolate(a, b TrackState, t time.Time) (lat, lon, alt float64) {
+	f := 0.0
+	if span := b.Time.Sub(a.Time); span > 0 {
+		f = float64(t.Sub(a.Time)) / float64(span)
+		f = math.Max(0, math.Min(1, f))
+	}
+
+	lat, lon = slerp(a.Latitude, a.Longitude, b.Latitude, b.Longitude, f)
+	alt = a.Altitude + (b.Altitude-a.Altitude)*f
+	return lat, lon, alt
+}
+
+// slerp returns the point at fraction f along the great circle from
+// (lat1, lon1) to (lat2, lon2), in degrees
+func slerp(lat1, lon1, lat2, lon2, f float64) (lat, lon float64) {
+	x1, y1, z1 := toUnitVector(lat1, lon1)
+	x2, y2, z2 := toUnitVector(lat2, lon2)
+
+	d := math.Acos(math.Max(-1, math.Min(1, x1*x2+y1*y2+z1*z2)))
+	var wa, wb float64
+	if d < 1e-12 {
+		// Coincident points: the weights below degenerate to 0/0
+		wa, wb = 1-f, f
+	} else {
+		wa = math.Sin((1-f)*d) / math.Sin(d)
+		wb = math.Sin(f*d) / math.Sin(d)
+	}
+
+	x := wa*x1 + wb*x2
+	y := wa*y1 + wb*y2
+	z := wa*z1 + wb*z2
+	return math.Atan2(z, math.Hypot(x, y)) * 180 / math.Pi, math.Atan2(y, x) * 180 / math.Pi
+}
+
+func toUnitVector(lat, lon float64) (x, y, z float64) {
+	sinLat, cosLat := math.Sincos(lat * math.Pi / 180)
+	sinLon, cosLon := math.Sincos(lon * math.Pi / 180)
+	return cosLat * cosLon, cosLat * sinLon, sinLat
+}
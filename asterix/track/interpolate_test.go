@@ -0,0 +1,62 @@
+// asterix/track/interpolate_test.go
+package track_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix/track"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+)
+
+func TestInterpolate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := track.TrackState{Time: start, Latitude: 50, Longitude: 0, Altitude: 10000}
+	b := track.TrackState{Time: start.Add(10 * time.Second), Latitude: 50, Longitude: 10, Altitude: 20000}
+
+	// Great-circle midpoint of two points on the same parallel lies
+	// poleward of the parallel
+	phi := 50 * math.Pi / 180
+	dLambda := 10 * math.Pi / 180
+	bx, by := math.Cos(phi)*math.Cos(dLambda), math.Cos(phi)*math.Sin(dLambda)
+	midLat := math.Atan2(2*math.Sin(phi), math.Hypot(math.Cos(phi)+bx, by)) * 180 / math.Pi
+
+	tests := []struct {
+		name          string
+		t             time.Time
+		lat, lon, alt float64
+	}{
+		{"start", start, 50, 0, 10000},
+		{"end", b.Time, 50, 10, 20000},
+		{"midpoint", start.Add(5 * time.Second), midLat, 5, 15000},
+		{"before start", start.Add(-time.Minute), 50, 0, 10000},
+		{"after end", start.Add(time.Minute), 50, 10, 20000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lon, alt := track.Interpolate(a, b, tt.t)
+			if math.Abs(lat-tt.lat) > 1e-9 || math.Abs(lon-tt.lon) > 1e-9 || math.Abs(alt-tt.alt) > 1e-9 {
+				t.Errorf("Interpolate() = (%f, %f, %f), want (%f, %f, %f)", lat, lon, alt, tt.lat, tt.lon, tt.alt)
+			}
+		})
+	}
+	if midLat <= 50.1 {
+		t.Errorf("midpoint latitude %f not poleward of the parallel", midLat)
+	}
+}
+
+func TestStore_Altitude(t *testing.T) {
+	record := newCat062Record(t, 42, 0o1234, false)
+	if err := record.SetDataItem("I062/135", &v117.CalculatedTrackBarometricAltitude{Altitude: 350}); err != nil {
+		t.Fatalf("SetDataItem(I062/135) error = %v", err)
+	}
+	state, err := track.NewStore().Update(record, time.Now())
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !state.HasAltitude || state.Altitude != 35000 {
+		t.Errorf("altitude = (%v, %v), want (35000, true)", state.Altitude, state.HasAltitude)
+	}
+}
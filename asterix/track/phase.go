@@ -0,0 +1,42 @@
+// asterix/track/phase.go
+package track
+
+import "github.com/davidkohl/gobelix/asterix"
+
+// TrackPhase is the lifecycle phase of a track as reported by its track
+// status item (see asterix.TrackStatusProvider)
+type TrackPhase uint8
+
+const (
+	PhaseUnknown   TrackPhase = iota // No track status received yet
+	PhaseTentative                   // Track initiated but not yet confirmed
+	PhaseConfirmed                   // Confirmed and updated by measurements
+	PhaseCoasted                     // Extrapolated without recent measurements
+)
+
+// String returns the name of the phase
+func (p TrackPhase) String() string {
+	switch p {
+	case PhaseTentative:
+		return "tentative"
+	case PhaseConfirmed:
+		return "confirmed"
+	case PhaseCoasted:
+		return "coasted"
+	default:
+		return "unknown"
+	}
+}
+
+// phaseOf derives the phase from a track status item. Coasting takes
+// precedence, as a coasted track is normally still confirmed.
+func phaseOf(sp asterix.TrackStatusProvider) TrackPhase {
+	switch {
+	case sp.Coasted():
+		return PhaseCoasted
+	case sp.Confirmed():
+		return PhaseConfirmed
+	default:
+		return PhaseTentative
+	}
+}
@@ -0,0 +1,200 @@
+// asterix/track/track.go
+
+// Package track maintains the latest known state of each track reported in
+// decoded ASTERIX records.
+package track
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+// TrackState is the state of a track after its most recent update
+type TrackState struct {
+	TrackNumber uint16
+	Time        time.Time // Time of the most recent update
+	Updates     uint64    // Number of updates received
+
+	Latitude    float64
+	Longitude   float64
+	HasPosition bool
+
+	Altitude    float64 // Feet, from the first altitude item in UAP order
+	HasAltitude bool
+
+	// Phase is the lifecycle phase from the most recent track status; it is
+	// kept when an update carries no status item
+	Phase TrackPhase
+
+	// Changed lists the IDs of the items whose change flag was set in the
+	// most recent update (see asterix.ChangeProvider)
+	Changed []string
+
+	ssrPosition bool // Position taken from an SSR-derived update
+}
+
+// ItemChanged reports whether the item with the given ID flagged a change in
+// the most recent update
+func (s TrackState) ItemChanged(id string) bool {
+	for _, c := range s.Changed {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Store holds the state of every track it has been updated with, keyed by
+// track number. It is safe for concurrent use.
+type Store struct {
+	mu           sync.RWMutex
+	tracks       map[uint16]*TrackState
+	onTransition func(addr uint32, from, to TrackPhase)
+	preferSSR    bool
+}
+
+// StoreOption configures a Store created by NewStore
+type StoreOption func(*Store)
+
+// WithSSRPreference makes the store keep the position of a track that was
+// last updated from an SSR-derived report when a PSR-only report arrives,
+// as told by an item implementing asterix.DetectionTypeProvider. Primary
+// plots are less precise and carry no identity, so they are only used to
+// position tracks with no SSR-derived position yet. Updates without a
+// detection type are taken as they come.
+func WithSSRPreference() StoreOption {
+	return func(s *Store) {
+		s.preferSSR = true
+	}
+}
+
+// NewStore creates an empty track store
+func NewStore(opts ...StoreOption) *Store {
+	s := &Store{
+		tracks: make(map[uint16]*TrackState),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// OnTransition registers a callback invoked whenever an update changes the
+// phase of a track, including the first status received for a new track
+// (from PhaseUnknown). addr is the address the store keys the track by, its
+// track number. The callback runs after the store has been updated and may
+// call back into the store. Passing nil removes the callback.
+func (s *Store) OnTransition(fn func(addr uint32, from, to TrackPhase)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onTransition = fn
+}
+
+// Update folds a record received at time t into the store and returns the
+// resulting state. The record must carry an item implementing
+// asterix.TrackNumberProvider.
+func (s *Store) Update(record *asterix.Record, t time.Time) (TrackState, error) {
+	var (
+		trackNumber uint16
+		found       bool
+		changed     []string
+		phase       TrackPhase
+		altitude    float64
+		hasAltitude bool
+		detection   asterix.DetectionTypeProvider
+	)
+
+	uap := record.UAP()
+	for _, field := range uap.Fields() {
+		item, _, exists := record.GetDataItem(field.DataItem)
+		if !exists {
+			continue
+		}
+		if tp, ok := item.(asterix.TrackNumberProvider); ok && !found {
+			trackNumber = tp.TrackNumber()
+			found = true
+		}
+		if cp, ok := item.(asterix.ChangeProvider); ok && cp.HasChanged() {
+			changed = append(changed, field.DataItem)
+		}
+		if sp, ok := item.(asterix.TrackStatusProvider); ok && phase == PhaseUnknown {
+			phase = phaseOf(sp)
+		}
+		if ap, ok := item.(asterix.AltitudeProvider); ok && !hasAltitude {
+			altitude, hasAltitude = ap.AltitudeFeet(), true
+		}
+		if dp, ok := item.(asterix.DetectionTypeProvider); ok && detection == nil {
+			detection = dp
+		}
+	}
+	if !found {
+		return TrackState{}, fmt.Errorf("%w: record carries no track number", asterix.ErrMandatoryField)
+	}
+
+	s.mu.Lock()
+	state, exists := s.tracks[trackNumber]
+	if !exists {
+		state = &TrackState{TrackNumber: trackNumber}
+		s.tracks[trackNumber] = state
+	}
+	state.Time = t
+	state.Updates++
+	state.Changed = changed
+	psrOnly := detection != nil && detection.IsPSROnly()
+	if lat, lon, ok := record.BestPosition(); ok && !(s.preferSSR && psrOnly && state.ssrPosition) {
+		state.Latitude, state.Longitude = lat, lon
+		state.HasPosition = true
+		state.ssrPosition = detection != nil && detection.IsSSRDerived()
+	}
+	if hasAltitude {
+		state.Altitude, state.HasAltitude = altitude, true
+	}
+	from := state.Phase
+	if phase != PhaseUnknown {
+		state.Phase = phase
+	}
+	snapshot := state.copy()
+	onTransition := s.onTransition
+	s.mu.Unlock()
+
+	if onTransition != nil && snapshot.Phase != from {
+		onTransition(uint32(trackNumber), from, snapshot.Phase)
+	}
+	return snapshot, nil
+}
+
+// Get returns the state of a track
+func (s *Store) Get(trackNumber uint16) (TrackState, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, exists := s.tracks[trackNumber]
+	if !exists {
+		return TrackState{}, false
+	}
+	return state.copy(), true
+}
+
+// Len returns the number of tracks in the store
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.tracks)
+}
+
+// Remove drops a track from the store
+func (s *Store) Remove(trackNumber uint16) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tracks, trackNumber)
+}
+
+// copy returns a snapshot that shares no mutable state with the store
+func (s *TrackState) copy() TrackState {
+	c := *s
+	c.Changed = append([]string(nil), s.Changed...)
+	return c
+}
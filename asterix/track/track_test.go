@@ -0,0 +1,200 @@
+// asterix/track/track_test.go
+package track_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/track"
+	"github.com/davidkohl/gobelix/cat/cat062"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// newCat062Record builds a Cat062 track record with a Mode 3/A code
+func newCat062Record(t *testing.T, trackNumber, squawk uint16, changed bool) *asterix.Record {
+	t.Helper()
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	record, err := asterix.NewRecord(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I062/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100}},
+		{"I062/040", &v117.TrackNumber{Value: trackNumber}},
+		{"I062/060", &v117.TrackMode3ACode{Code: squawk, Changed: changed}},
+		{"I062/105", &v117.CalculatedPositionWGS84{Latitude: 50.0, Longitude: 8.5}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+	return record
+}
+
+func TestStore_Mode3AChange(t *testing.T) {
+	store := track.NewStore()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	state, err := store.Update(newCat062Record(t, 42, 0o1234, false), start)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if state.ItemChanged("I062/060") {
+		t.Error("first update flagged a Mode 3/A change without the CH bit")
+	}
+
+	state, err = store.Update(newCat062Record(t, 42, 0o7700, true), start.Add(4*time.Second))
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !state.ItemChanged("I062/060") {
+		t.Errorf("Changed = %v, want I062/060 flagged", state.Changed)
+	}
+	if state.Updates != 2 || !state.Time.Equal(start.Add(4*time.Second)) {
+		t.Errorf("state = %+v, want 2 updates at the second timestamp", state)
+	}
+	if !state.HasPosition || state.Latitude != 50.0 || state.Longitude != 8.5 {
+		t.Errorf("position = (%v, %v, %v), want (50, 8.5, true)", state.Latitude, state.Longitude, state.HasPosition)
+	}
+
+	// The flag only describes the most recent update
+	if _, err := store.Update(newCat062Record(t, 42, 0o7700, false), start.Add(8*time.Second)); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got, _ := store.Get(42); got.ItemChanged("I062/060") {
+		t.Error("change flag persisted into an update without the CH bit")
+	}
+	if store.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", store.Len())
+	}
+}
+
+func TestStore_UpdateWithoutTrackNumber(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	record, err := asterix.NewRecord(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	if _, err := track.NewStore().Update(record, time.Now()); err == nil {
+		t.Error("Update() accepted a record without a track number")
+	}
+}
+
+func TestStore_OnTransition(t *testing.T) {
+	type transition struct {
+		addr     uint32
+		from, to track.TrackPhase
+	}
+	var got []transition
+
+	store := track.NewStore()
+	store.OnTransition(func(addr uint32, from, to track.TrackPhase) {
+		got = append(got, transition{addr, from, to})
+	})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	statuses := []*v117.TrackStatus{
+		{CNF: true},  // tentative
+		{CNF: true},  // still tentative
+		{CNF: false}, // confirmed
+		{CST: true},  // coasted
+		{CST: true},  // still coasted
+	}
+	for i, status := range statuses {
+		status.SetHasExtension()
+		record := newCat062Record(t, 7, 0o1234, false)
+		if err := record.SetDataItem("I062/080", status); err != nil {
+			t.Fatalf("SetDataItem(I062/080) error = %v", err)
+		}
+		if _, err := store.Update(record, start.Add(time.Duration(i)*4*time.Second)); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	// An update without track status keeps the phase
+	if _, err := store.Update(newCat062Record(t, 7, 0o1234, false), start.Add(time.Minute)); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	want := []transition{
+		{7, track.PhaseUnknown, track.PhaseTentative},
+		{7, track.PhaseTentative, track.PhaseConfirmed},
+		{7, track.PhaseConfirmed, track.PhaseCoasted},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("transitions = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("transition %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if state, _ := store.Get(7); state.Phase != track.PhaseCoasted {
+		t.Errorf("Phase = %v, want %v", state.Phase, track.PhaseCoasted)
+	}
+}
+
+func TestStore_SSRPreference(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	report := func(reportType uint8, lat float64) *asterix.Record {
+		t.Helper()
+		record := newCat062Record(t, 9, 0o1234, false)
+		items := []struct {
+			id   string
+			item asterix.DataItem
+		}{
+			{"I062/105", &v117.CalculatedPositionWGS84{Latitude: lat, Longitude: 8.5}},
+			{"I062/340", &v117.MeasuredInformation{ReportType: &reportType}},
+		}
+		for _, it := range items {
+			if err := record.SetDataItem(it.id, it.item); err != nil {
+				t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+			}
+		}
+		return record
+	}
+
+	tests := []struct {
+		name    string
+		opts    []track.StoreOption
+		reports []*asterix.Record
+		wantLat float64
+	}{
+		{"PSR after SSR kept out", []track.StoreOption{track.WithSSRPreference()},
+			[]*asterix.Record{report(2, 50.0), report(1, 50.1)}, 50.0},
+		{"PSR after SSR without preference", nil,
+			[]*asterix.Record{report(2, 50.0), report(1, 50.1)}, 50.1},
+		{"PSR positions a new track", []track.StoreOption{track.WithSSRPreference()},
+			[]*asterix.Record{report(1, 50.0), report(1, 50.1)}, 50.1},
+		{"SSR replaces PSR", []track.StoreOption{track.WithSSRPreference()},
+			[]*asterix.Record{report(1, 50.0), report(5, 50.1)}, 50.1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := track.NewStore(tt.opts...)
+			var state track.TrackState
+			for i, record := range tt.reports {
+				var err error
+				if state, err = store.Update(record, start.Add(time.Duration(i)*4*time.Second)); err != nil {
+					t.Fatalf("Update() error = %v", err)
+				}
+			}
+			if state.Latitude != tt.wantLat || state.Updates != uint64(len(tt.reports)) {
+				t.Errorf("Latitude = %v after %d updates, want %v after %d",
+					state.Latitude, state.Updates, tt.wantLat, len(tt.reports))
+			}
+		})
+	}
+}
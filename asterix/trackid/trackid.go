@@ -0,0 +1,86 @@
+// asterix/trackid/trackid.go
+
+// Package trackid assigns stable synthetic identifiers to track numbers that
+// wrap around, such as the 12-bit ADS-B track numbers of I021/161.
+package trackid
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultModulus is the number of distinct values of a 12-bit track number
+const DefaultModulus = 4096
+
+// Assigner maps raw track numbers to monotonically allocated 32-bit IDs.
+//
+// Sensors allocate track numbers sequentially and reuse them after wrapping.
+// A raw number seen again within the timeout keeps its synthetic ID; a number
+// that has been idle for longer is treated as a new track. A wrap is detected
+// when a new track is allocated a raw number no higher than the previous new
+// one, which includes the same number expiring and coming back, at which
+// point the epoch advances so that IDs keep increasing.
+type Assigner struct {
+	mu      sync.Mutex
+	modulus uint32
+	timeout time.Duration
+	epoch   uint32
+	lastNew uint32
+	started bool
+	active  map[uint32]entry // By raw track number
+}
+
+type entry struct {
+	id       uint32
+	lastSeen time.Time
+}
+
+// NewAssigner creates an Assigner for track numbers in [0, modulus).
+// A modulus of 0 selects DefaultModulus.
+func NewAssigner(modulus uint32, timeout time.Duration) *Assigner {
+	if modulus == 0 {
+		modulus = DefaultModulus
+	}
+	return &Assigner{
+		modulus: modulus,
+		timeout: timeout,
+		active:  make(map[uint32]entry),
+	}
+}
+
+// Assign returns the synthetic ID for a track number observed at time t
+func (a *Assigner) Assign(trackNumber uint16, t time.Time) uint32 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	raw := uint32(trackNumber) % a.modulus
+
+	if e, ok := a.active[raw]; ok && t.Sub(e.lastSeen) <= a.timeout {
+		e.lastSeen = t
+		a.active[raw] = e
+		return e.id
+	}
+
+	// New track: advance the epoch if the allocation sequence wrapped
+	if a.started && raw <= a.lastNew {
+		a.epoch++
+	}
+	a.started = true
+	a.lastNew = raw
+
+	id := a.epoch*a.modulus + raw
+	a.active[raw] = entry{id: id, lastSeen: t}
+	return id
+}
+
+// Prune forgets track numbers idle for longer than the timeout at time t
+func (a *Assigner) Prune(t time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for raw, e := range a.active {
+		if t.Sub(e.lastSeen) > a.timeout {
+			delete(a.active, raw)
+		}
+	}
+}
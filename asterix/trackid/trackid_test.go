@@ -0,0 +1,59 @@
+// asterix/trackid/trackid_test.go
+package trackid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix/trackid"
+)
+
+func TestAssigner_Wraparound(t *testing.T) {
+	a := trackid.NewAssigner(0, 30*time.Second)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var last uint32
+	sequence := []uint16{4090, 4091, 4092, 4093, 4094, 4095, 0, 1, 2, 3}
+	for i, tn := range sequence {
+		id := a.Assign(tn, start.Add(time.Duration(i)*time.Second))
+		if i > 0 && id <= last {
+			t.Fatalf("Assign(%d) = %d, not greater than previous %d", tn, id, last)
+		}
+		last = id
+	}
+	if last != 4096+3 {
+		t.Errorf("Assign(3) after wrap = %d, want %d", last, 4096+3)
+	}
+
+	// A track that is still active keeps its ID across the wrap
+	if id := a.Assign(4093, start.Add(11*time.Second)); id != 4093 {
+		t.Errorf("Assign(4093) for active track = %d, want 4093", id)
+	}
+
+	// Once idle beyond the timeout the raw number is treated as a new track
+	id := a.Assign(4093, start.Add(2*time.Minute))
+	if id <= last {
+		t.Errorf("Assign(4093) for reused number = %d, want > %d", id, last)
+	}
+}
+
+func TestAssigner_ExpireAndReappear(t *testing.T) {
+	a := trackid.NewAssigner(0, 30*time.Second)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// The only track expires and its number is the next one allocated
+	first := a.Assign(7, start)
+	a.Prune(start.Add(time.Minute))
+	second := a.Assign(7, start.Add(time.Minute))
+	if second <= first {
+		t.Fatalf("Assign(7) after expiry = %d, want > %d", second, first)
+	}
+	if second != 4096+7 {
+		t.Errorf("Assign(7) after expiry = %d, want %d", second, 4096+7)
+	}
+
+	// Still active, the number keeps its new ID
+	if id := a.Assign(7, start.Add(time.Minute+time.Second)); id != second {
+		t.Errorf("Assign(7) while active = %d, want %d", id, second)
+	}
+}
@@ -21,12 +21,56 @@ type UAP interface {
 	Validate(items map[string]DataItem) error
 }
 
+// UAPSelector is implemented by a UAP standing for several profiles of one
+// category, such as the plot and track UAPs of Category 001. Its Fields are
+// the leading fields the profiles share. Once those are decoded, SelectUAP
+// returns the profile that lays out the whole record; each profile repeats
+// the shared fields with the same FRNs.
+type UAPSelector interface {
+	UAP
+
+	// SelectUAP returns the profile for a record carrying the given items
+	SelectUAP(items map[string]DataItem) (UAP, error)
+}
+
+// profileOf returns the UAP laying out a record with the given items: the
+// profile chosen by a UAPSelector, or uap itself. A selector that cannot
+// choose leaves uap in place.
+func profileOf(uap UAP, items map[string]DataItem) UAP {
+	if sel, ok := uap.(UAPSelector); ok {
+		if profile, err := sel.SelectUAP(items); err == nil {
+			return profile
+		}
+	}
+	return uap
+}
+
+// BlockableUAP is implemented by a UAP that states whether a data block of
+// its category may carry more than one record. A UAP without the method is
+// blockable, as ASTERIX allows by default.
+type BlockableUAP interface {
+	UAP
+
+	// Blockable reports whether records may be blocked together
+	Blockable() bool
+}
+
+// isBlockable reports whether blocks laid out by uap may hold several records
+func isBlockable(uap UAP) bool {
+	if b, ok := uap.(BlockableUAP); ok {
+		return b.Blockable()
+	}
+	return true
+}
+
 // BaseUAP provides common UAP functionality
 type BaseUAP struct {
 	category     Category
 	version      string
 	fields       []DataField
 	mandatoryIDs []string // Pre-computed list of mandatory item IDs
+
+	layouts layoutCache // Encoding layouts of records, by FSPEC
 }
 
 func NewBaseUAP(cat Category, version string, fields []DataField) (*BaseUAP, error) {
@@ -95,3 +139,21 @@ func (u *BaseUAP) CreateDataItem(id string) (DataItem, error) {
 	return nil, fmt.Errorf("%w: CreateDataItem must be implemented by specific UAP",
 		ErrUAPNotDefined)
 }
+
+// CheckImplemented returns the IDs of the items defined in the UAP's fields
+// for which CreateDataItem fails, in FRN order. Such items are accepted in
+// the FSPEC but make decoding fail as soon as a record carries them, so the
+// list gives implementers an upfront coverage report. Spare fields are
+// skipped.
+func CheckImplemented(uap UAP) []string {
+	var missing []string
+	for _, field := range uap.Fields() {
+		if field.DataItem == "" {
+			continue
+		}
+		if item, err := uap.CreateDataItem(field.DataItem); err != nil || item == nil {
+			missing = append(missing, field.DataItem)
+		}
+	}
+	return missing
+}
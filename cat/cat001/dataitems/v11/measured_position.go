@@ -0,0 +1,71 @@
+// cat/cat001/dataitems/v11/measured_position.go
+package v11
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// MeasuredPosition implements I001/040
+// Measured position of an aircraft in local polar co-ordinates.
+type MeasuredPosition struct {
+	RHO   float64 // Measured range (NM)
+	THETA float64 // Measured azimuth (degrees)
+}
+
+func (m *MeasuredPosition) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 4)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading measured position: %w", err)
+	}
+	if n != 4 {
+		return n, fmt.Errorf("insufficient data for measured position: got %d bytes, want 4", n)
+	}
+
+	// RHO (16 bits): Range, LSB = 1/128 NM
+	rhoRaw := uint16(data[0])<<8 | uint16(data[1])
+	m.RHO = float64(rhoRaw) / 128.0
+
+	// THETA (16 bits): Azimuth, LSB = 360/2^16 degrees
+	thetaRaw := uint16(data[2])<<8 | uint16(data[3])
+	m.THETA = float64(thetaRaw) * (360.0 / 65536.0)
+
+	return n, nil
+}
+
+func (m *MeasuredPosition) Encode(buf *bytes.Buffer) (int, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	rhoRaw := uint16(min(math.Round(m.RHO*128.0), 0xFFFF))
+
+	// Normalize THETA to [0, 360) degrees; 360 itself wraps to 0
+	theta := math.Mod(m.THETA, 360.0)
+	if theta < 0 {
+		theta += 360.0
+	}
+	thetaRaw := uint16(int(math.Round(theta*(65536.0/360.0))) % 65536)
+
+	n, err := buf.Write([]byte{byte(rhoRaw >> 8), byte(rhoRaw), byte(thetaRaw >> 8), byte(thetaRaw)})
+	if err != nil {
+		return n, fmt.Errorf("writing measured position: %w", err)
+	}
+	return n, nil
+}
+
+func (m *MeasuredPosition) Validate() error {
+	if m.RHO < 0 {
+		return fmt.Errorf("negative range value not allowed: %f", m.RHO)
+	}
+	if m.RHO >= 512.0 {
+		return fmt.Errorf("range value too large (>= 512 NM): %f", m.RHO)
+	}
+	return nil
+}
+
+func (m *MeasuredPosition) String() string {
+	return fmt.Sprintf("RHO: %.3f NM, THETA: %.3f°", m.RHO, m.THETA)
+}
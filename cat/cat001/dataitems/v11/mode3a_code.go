@@ -0,0 +1,85 @@
+// cat/cat001/dataitems/v11/mode3a_code.go
+package v11
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Mode3ACode implements I001/070
+// Reply to Mode-3/A interrogation in octal representation.
+type Mode3ACode struct {
+	V    bool   // Code not validated
+	G    bool   // Garbled code
+	L    bool   // Mode-3/A code not extracted during the last update period
+	Code uint16 // Mode-3/A reply, the octal digits written as a decimal number
+}
+
+func (m *Mode3ACode) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading Mode-3/A code: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for Mode-3/A code: got %d bytes, want 2", n)
+	}
+
+	m.V = (data[0] & 0x80) != 0 // bit 16
+	m.G = (data[0] & 0x40) != 0 // bit 15
+	m.L = (data[0] & 0x20) != 0 // bit 14
+	// bit 13 is spare
+
+	// The 12 code bits hold the octal digits A, B, C and D
+	raw := uint16(data[0]&0x0F)<<8 | uint16(data[1])
+	m.Code = (raw>>9)*1000 + (raw>>6&0x07)*100 + (raw>>3&0x07)*10 + raw&0x07
+
+	return n, m.Validate()
+}
+
+func (m *Mode3ACode) Encode(buf *bytes.Buffer) (int, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	raw := (m.Code/1000)<<9 | (m.Code/100%10)<<6 | (m.Code/10%10)<<3 | m.Code%10
+	if m.V {
+		raw |= 0x8000
+	}
+	if m.G {
+		raw |= 0x4000
+	}
+	if m.L {
+		raw |= 0x2000
+	}
+
+	n, err := buf.Write([]byte{byte(raw >> 8), byte(raw)})
+	if err != nil {
+		return n, fmt.Errorf("writing Mode-3/A code: %w", err)
+	}
+	return n, nil
+}
+
+func (m *Mode3ACode) Validate() error {
+	if m.Code > 7777 || m.Code/1000 > 7 || m.Code/100%10 > 7 || m.Code/10%10 > 7 || m.Code%10 > 7 {
+		return fmt.Errorf("invalid octal digit in Mode-3/A code: %04d", m.Code)
+	}
+	return nil
+}
+
+func (m *Mode3ACode) String() string {
+	var flags []string
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{{"V", m.V}, {"G", m.G}, {"L", m.L}} {
+		if f.set {
+			flags = append(flags, f.name)
+		}
+	}
+	if len(flags) == 0 {
+		return fmt.Sprintf("%04d", m.Code)
+	}
+	return fmt.Sprintf("%s %04d", strings.Join(flags, ","), m.Code)
+}
@@ -0,0 +1,49 @@
+// cat/cat001/dataitems/v11/symmetry_test.go
+package v11_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v11 "github.com/davidkohl/gobelix/cat/cat001/dataitems/v11"
+)
+
+func TestTargetReportDescriptor_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v11.TargetReportDescriptor{} }, [][]byte{
+		{0x00},
+		{0xFE},
+		{0x31, 0xF8},
+		{0xA1, 0x40},
+	})
+
+	var trd v11.TargetReportDescriptor
+	if _, err := trd.Decode(bytes.NewBuffer([]byte{0xA5, 0x60})); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !trd.IsTrack() || trd.SSRPSR != v11.DetectionSSR || !trd.SPI || trd.Ext == nil || trd.Ext.DS1DS2 != 3 {
+		t.Errorf("Decode() = %+v, ext %+v", trd, trd.Ext)
+	}
+	if got := trd.String(); got != "Track, SSR, SPI, 7700" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestMode3ACode_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v11.Mode3ACode{} }, [][]byte{
+		{0x00, 0x00},
+		{0x0F, 0xFF},
+		{0xE2, 0x9C},
+	})
+	if got := (&v11.Mode3ACode{G: true, Code: 7600}).String(); got != "G 7600" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestMeasuredPosition_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v11.MeasuredPosition{} }, [][]byte{
+		{0x19, 0x00, 0x40, 0x00},
+		{0xFF, 0xFF, 0xFF, 0xFF},
+	})
+}
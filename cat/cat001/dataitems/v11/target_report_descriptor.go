@@ -0,0 +1,172 @@
+// cat/cat001/dataitems/v11/target_report_descriptor.go
+package v11
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+// Detection types of the SSR/PSR field of I001/020
+const (
+	DetectionNone     uint8 = 0 // No detection
+	DetectionPSR      uint8 = 1 // Sole primary detection
+	DetectionSSR      uint8 = 2 // Sole secondary detection
+	DetectionCombined uint8 = 3 // Combined primary and secondary detection
+)
+
+// TargetReportDescriptor implements I001/020
+// Type and characteristics of the radar data as transmitted by a radar
+// station. The TYP bit tells whether the record is a plot or a track and so
+// which UAP lays out the rest of it.
+type TargetReportDescriptor struct {
+	// Primary field
+	TYP    bool  // Track, plot when false
+	SIM    bool  // Simulated plot or track
+	SSRPSR uint8 // Detection type, see the Detection constants
+	ANT    bool  // Target report from antenna 2
+	SPI    bool  // Special position identification
+	RAB    bool  // Plot or track from a fixed transponder
+
+	// First extension
+	Ext *TargetReportExtension // nil when absent
+}
+
+// TargetReportExtension is the first extension of I001/020
+type TargetReportExtension struct {
+	TST    bool  // Test target indicator
+	DS1DS2 uint8 // 0 default, 1 unlawful interference (7500), 2 radio communication failure (7600), 3 emergency (7700)
+	ME     bool  // Military emergency
+	MI     bool  // Military identification
+}
+
+func (t *TargetReportDescriptor) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading target report descriptor: %w", err)
+	}
+
+	t.TYP = (b & 0x80) != 0
+	t.SIM = (b & 0x40) != 0
+	t.SSRPSR = (b >> 4) & 0x03
+	t.ANT = (b & 0x08) != 0
+	t.SPI = (b & 0x04) != 0
+	t.RAB = (b & 0x02) != 0
+	t.Ext = nil
+
+	if b&0x01 == 0 {
+		return 1, nil
+	}
+
+	ext, err := buf.ReadByte()
+	if err != nil {
+		return 1, fmt.Errorf("%w: reading target report descriptor extension: %v", asterix.ErrBufferTooShort, err)
+	}
+	if ext&0x01 != 0 {
+		return 2, fmt.Errorf("%w: target report descriptor: FX bit set in first extension, but no further extension is defined",
+			asterix.ErrCorruptData)
+	}
+	// bits 3-2 are spare
+	t.Ext = &TargetReportExtension{
+		TST:    (ext & 0x80) != 0,
+		DS1DS2: (ext >> 5) & 0x03,
+		ME:     (ext & 0x10) != 0,
+		MI:     (ext & 0x08) != 0,
+	}
+
+	return 2, nil
+}
+
+func (t *TargetReportDescriptor) Encode(buf *bytes.Buffer) (int, error) {
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+
+	b := t.SSRPSR << 4
+	if t.TYP {
+		b |= 0x80
+	}
+	if t.SIM {
+		b |= 0x40
+	}
+	if t.ANT {
+		b |= 0x08
+	}
+	if t.SPI {
+		b |= 0x04
+	}
+	if t.RAB {
+		b |= 0x02
+	}
+	if t.Ext == nil {
+		if err := buf.WriteByte(b); err != nil {
+			return 0, fmt.Errorf("writing target report descriptor: %w", err)
+		}
+		return 1, nil
+	}
+
+	ext := t.Ext.DS1DS2 << 5
+	if t.Ext.TST {
+		ext |= 0x80
+	}
+	if t.Ext.ME {
+		ext |= 0x10
+	}
+	if t.Ext.MI {
+		ext |= 0x08
+	}
+
+	n, err := buf.Write([]byte{b | 0x01, ext})
+	if err != nil {
+		return n, fmt.Errorf("writing target report descriptor: %w", err)
+	}
+	return n, nil
+}
+
+func (t *TargetReportDescriptor) Validate() error {
+	if t.SSRPSR > 3 {
+		return fmt.Errorf("invalid SSR/PSR detection type: %d", t.SSRPSR)
+	}
+	if t.Ext != nil && t.Ext.DS1DS2 > 3 {
+		return fmt.Errorf("invalid DS1/DS2 value: %d", t.Ext.DS1DS2)
+	}
+	return nil
+}
+
+// IsTrack reports whether the record is a track rather than a plot
+func (t *TargetReportDescriptor) IsTrack() bool {
+	return t.TYP
+}
+
+func (t *TargetReportDescriptor) String() string {
+	parts := []string{"Plot"}
+	if t.TYP {
+		parts[0] = "Track"
+	}
+	parts = append(parts, [...]string{"No detection", "PSR", "SSR", "PSR+SSR"}[t.SSRPSR&0x03])
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{{"SIM", t.SIM}, {"ANT2", t.ANT}, {"SPI", t.SPI}, {"RAB", t.RAB}} {
+		if f.set {
+			parts = append(parts, f.name)
+		}
+	}
+	if e := t.Ext; e != nil {
+		if e.TST {
+			parts = append(parts, "TST")
+		}
+		if e.DS1DS2 != 0 {
+			parts = append(parts, [...]string{"", "7500", "7600", "7700"}[e.DS1DS2&0x03])
+		}
+		if e.ME {
+			parts = append(parts, "ME")
+		}
+		if e.MI {
+			parts = append(parts, "MI")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
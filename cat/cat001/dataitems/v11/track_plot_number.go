@@ -0,0 +1,49 @@
+// cat/cat001/dataitems/v11/track_plot_number.go
+package v11
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// TrackPlotNumber implements I001/161
+// Unique reference to a track record within the track file of the radar
+// station
+type TrackPlotNumber struct {
+	Value uint16
+}
+
+func (t *TrackPlotNumber) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading track/plot number: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for track/plot number: got %d bytes, want 2", n)
+	}
+
+	t.Value = uint16(data[0])<<8 | uint16(data[1])
+	return n, nil
+}
+
+func (t *TrackPlotNumber) Encode(buf *bytes.Buffer) (int, error) {
+	n, err := buf.Write([]byte{byte(t.Value >> 8), byte(t.Value)})
+	if err != nil {
+		return n, fmt.Errorf("writing track/plot number: %w", err)
+	}
+	return n, nil
+}
+
+func (t *TrackPlotNumber) Validate() error {
+	return nil
+}
+
+func (t *TrackPlotNumber) String() string {
+	return fmt.Sprintf("%d", t.Value)
+}
+
+// TrackNumber implements asterix.TrackNumberProvider
+func (t *TrackPlotNumber) TrackNumber() uint16 {
+	return t.Value
+}
@@ -0,0 +1,494 @@
+// cat/cat001/uap/uap_v11.go
+package uap
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v11 "github.com/davidkohl/gobelix/cat/cat001/dataitems/v11"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// UAP001 implements the User Application Profile for ASTERIX Category 001.
+// Category 001 defines one UAP for plots and one for tracks, chosen by the
+// TYP bit of I001/020. UAP001 covers the two leading fields they share and
+// selects the profile for the rest of each record.
+type UAP001 struct {
+	*asterix.BaseUAP
+	plot  *UAP001Plot
+	track *UAP001Track
+}
+
+// UAP001Plot is the Category 001 UAP for plot records
+type UAP001Plot struct {
+	*asterix.BaseUAP
+}
+
+// UAP001Track is the Category 001 UAP for track records
+type UAP001Track struct {
+	*asterix.BaseUAP
+}
+
+// NewUAP001 creates a new instance of the Category 001 UAP, selecting the
+// plot or track profile per record
+func NewUAP001() (*UAP001, error) {
+	base, err := asterix.NewBaseUAP(asterix.Cat001, "1.1", cat001SharedFields)
+	if err != nil {
+		return nil, err
+	}
+	plot, err := NewUAP001Plot()
+	if err != nil {
+		return nil, err
+	}
+	track, err := NewUAP001Track()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UAP001{
+		BaseUAP: base,
+		plot:    plot,
+		track:   track,
+	}, nil
+}
+
+// NewUAP001Plot creates a new instance of the Category 001 plot UAP
+func NewUAP001Plot() (*UAP001Plot, error) {
+	base, err := asterix.NewBaseUAP(asterix.Cat001, "1.1", cat001PlotFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UAP001Plot{
+		BaseUAP: base,
+	}, nil
+}
+
+// NewUAP001Track creates a new instance of the Category 001 track UAP
+func NewUAP001Track() (*UAP001Track, error) {
+	base, err := asterix.NewBaseUAP(asterix.Cat001, "1.1", cat001TrackFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UAP001Track{
+		BaseUAP: base,
+	}, nil
+}
+
+// SelectUAP implements asterix.UAPSelector, returning the plot or track
+// profile as given by the TYP bit of I001/020
+func (u *UAP001) SelectUAP(items map[string]asterix.DataItem) (asterix.UAP, error) {
+	trd, ok := items["I001/020"].(*v11.TargetReportDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%w: I001/020 selects the plot or track UAP", asterix.ErrMandatoryField)
+	}
+	if trd.IsTrack() {
+		return u.track, nil
+	}
+	return u.plot, nil
+}
+
+// CreateDataItem creates a new instance of a Cat001 data item
+func (u *UAP001) CreateDataItem(id string) (asterix.DataItem, error) {
+	return createDataItem(id)
+}
+
+// CreateDataItem creates a new instance of a Cat001 data item
+func (u *UAP001Plot) CreateDataItem(id string) (asterix.DataItem, error) {
+	return createDataItem(id)
+}
+
+// CreateDataItem creates a new instance of a Cat001 data item
+func (u *UAP001Track) CreateDataItem(id string) (asterix.DataItem, error) {
+	if id == "I001/161" {
+		return &v11.TrackPlotNumber{}, nil
+	}
+	return createDataItem(id)
+}
+
+// Validate implements critical validations for Cat001 plots
+func (u *UAP001Plot) Validate(items map[string]asterix.DataItem) error {
+	if err := u.BaseUAP.Validate(items); err != nil {
+		return err
+	}
+	return checkTYP(items, false)
+}
+
+// Validate implements critical validations for Cat001 tracks
+func (u *UAP001Track) Validate(items map[string]asterix.DataItem) error {
+	if err := u.BaseUAP.Validate(items); err != nil {
+		return err
+	}
+	return checkTYP(items, true)
+}
+
+// createDataItem creates the items the plot and track UAPs share
+func createDataItem(id string) (asterix.DataItem, error) {
+	switch id {
+	case "I001/010":
+		return &common.DataSourceIdentifier{}, nil
+	case "I001/020":
+		return &v11.TargetReportDescriptor{}, nil
+	case "I001/040":
+		return &v11.MeasuredPosition{}, nil
+	case "I001/070":
+		return &v11.Mode3ACode{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", asterix.ErrUnknownDataItem, id)
+	}
+}
+
+// checkTYP fails when the TYP bit of I001/020 does not match the profile
+func checkTYP(items map[string]asterix.DataItem, track bool) error {
+	trd, ok := items["I001/020"].(*v11.TargetReportDescriptor)
+	if !ok || trd.IsTrack() == track {
+		return nil
+	}
+	if track {
+		return fmt.Errorf("%w: I001/020 marks a plot, not a track", asterix.ErrInvalidField)
+	}
+	return fmt.Errorf("%w: I001/020 marks a track, not a plot", asterix.ErrInvalidField)
+}
+
+// cat001SharedFields defines the fields the plot and track UAPs share
+var cat001SharedFields = []asterix.DataField{
+	{
+		FRN:         1,
+		DataItem:    "I001/010",
+		Description: "Data Source Identifier",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         2,
+		DataItem:    "I001/020",
+		Description: "Target Report Descriptor",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   true,
+	},
+}
+
+// cat001PlotFields defines the complete plot UAP for Category 001. FRNs 16
+// to 19 are spare.
+var cat001PlotFields = []asterix.DataField{
+	{
+		FRN:         1,
+		DataItem:    "I001/010",
+		Description: "Data Source Identifier",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         2,
+		DataItem:    "I001/020",
+		Description: "Target Report Descriptor",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   true,
+	},
+	{
+		FRN:         3,
+		DataItem:    "I001/040",
+		Description: "Measured Position in Polar Coordinates",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         4,
+		DataItem:    "I001/070",
+		Description: "Mode-3/A Code in Octal Representation",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         5,
+		DataItem:    "I001/090",
+		Description: "Mode-C Code in Binary Representation",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         6,
+		DataItem:    "I001/130",
+		Description: "Radar Plot Characteristics",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         7,
+		DataItem:    "I001/141",
+		Description: "Truncated Time of Day",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         8,
+		DataItem:    "I001/050",
+		Description: "Mode-2 Code in Octal Representation",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         9,
+		DataItem:    "I001/120",
+		Description: "Measured Radial Doppler Speed",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         10,
+		DataItem:    "I001/131",
+		Description: "Received Power",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         11,
+		DataItem:    "I001/080",
+		Description: "Mode-3/A Code Confidence Indicator",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         12,
+		DataItem:    "I001/100",
+		Description: "Mode-C Code and Code Confidence Indicator",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         13,
+		DataItem:    "I001/060",
+		Description: "Mode-2 Code Confidence Indicator",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         14,
+		DataItem:    "I001/030",
+		Description: "Warning/Error Conditions",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         15,
+		DataItem:    "I001/150",
+		Description: "Presence of X-Pulse",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         20,
+		DataItem:    "SP001",
+		Description: "Special Purpose Field",
+		Type:        asterix.Explicit,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         21,
+		DataItem:    "RFS001",
+		Description: "Random Field Sequencing",
+		Type:        asterix.Repetitive,
+		Length:      1,
+		Mandatory:   false,
+	},
+}
+
+// cat001TrackFields defines the complete track UAP for Category 001. FRNs
+// 23 to 28 are spare.
+var cat001TrackFields = []asterix.DataField{
+	{
+		FRN:         1,
+		DataItem:    "I001/010",
+		Description: "Data Source Identifier",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         2,
+		DataItem:    "I001/020",
+		Description: "Target Report Descriptor",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   true,
+	},
+	{
+		FRN:         3,
+		DataItem:    "I001/161",
+		Description: "Track/Plot Number",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         4,
+		DataItem:    "I001/040",
+		Description: "Measured Position in Polar Coordinates",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         5,
+		DataItem:    "I001/042",
+		Description: "Calculated Position in Cartesian Coordinates",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         6,
+		DataItem:    "I001/200",
+		Description: "Calculated Track Velocity in Polar Coordinates",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         7,
+		DataItem:    "I001/070",
+		Description: "Mode-3/A Code in Octal Representation",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         8,
+		DataItem:    "I001/090",
+		Description: "Mode-C Code in Binary Representation",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         9,
+		DataItem:    "I001/141",
+		Description: "Truncated Time of Day",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         10,
+		DataItem:    "I001/130",
+		Description: "Radar Plot Characteristics",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         11,
+		DataItem:    "I001/131",
+		Description: "Received Power",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         12,
+		DataItem:    "I001/120",
+		Description: "Measured Radial Doppler Speed",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         13,
+		DataItem:    "I001/170",
+		Description: "Track Status",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         14,
+		DataItem:    "I001/210",
+		Description: "Track Quality",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         15,
+		DataItem:    "I001/050",
+		Description: "Mode-2 Code in Octal Representation",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         16,
+		DataItem:    "I001/080",
+		Description: "Mode-3/A Code Confidence Indicator",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         17,
+		DataItem:    "I001/100",
+		Description: "Mode-C Code and Code Confidence Indicator",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         18,
+		DataItem:    "I001/060",
+		Description: "Mode-2 Code Confidence Indicator",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         19,
+		DataItem:    "I001/030",
+		Description: "Warning/Error Conditions",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         20,
+		DataItem:    "SP001",
+		Description: "Special Purpose Field",
+		Type:        asterix.Explicit,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         21,
+		DataItem:    "RFS001",
+		Description: "Random Field Sequencing",
+		Type:        asterix.Repetitive,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         22,
+		DataItem:    "I001/150",
+		Description: "Presence of X-Pulse",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+}
@@ -0,0 +1,166 @@
+// cat/cat001/uap/uap_v11_test.go
+package uap_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat001"
+	v11 "github.com/davidkohl/gobelix/cat/cat001/dataitems/v11"
+	"github.com/davidkohl/gobelix/cat/cat001/uap"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+type testItem struct {
+	id   string
+	item asterix.DataItem
+}
+
+var (
+	plotItems = []testItem{
+		{"I001/010", &common.DataSourceIdentifier{SAC: 8, SIC: 1}},
+		{"I001/020", &v11.TargetReportDescriptor{SSRPSR: v11.DetectionCombined}},
+		{"I001/040", &v11.MeasuredPosition{RHO: 50, THETA: 90}},
+		{"I001/070", &v11.Mode3ACode{Code: 7000}},
+	}
+	trackItems = []testItem{
+		{"I001/010", &common.DataSourceIdentifier{SAC: 8, SIC: 1}},
+		{"I001/020", &v11.TargetReportDescriptor{TYP: true, SSRPSR: v11.DetectionSSR}},
+		{"I001/161", &v11.TrackPlotNumber{Value: 257}},
+		{"I001/040", &v11.MeasuredPosition{RHO: 50, THETA: 90}},
+		{"I001/070", &v11.Mode3ACode{Code: 1234}},
+	}
+
+	// A plot record followed by a track record
+	plotAndTrack = []byte{
+		1, 0x00, 0x19, // CAT, LEN
+		0xF0,       // FSPEC: 010, 020, 040, 070
+		0x08, 0x01, // I001/010
+		0x30,                   // I001/020: plot, PSR+SSR
+		0x19, 0x00, 0x40, 0x00, // I001/040: 50 NM, 90°
+		0x0E, 0x00, // I001/070: 7000
+		0xF2,       // FSPEC: 010, 020, 161, 040, 070
+		0x08, 0x01, // I001/010
+		0xA0,       // I001/020: track, SSR
+		0x01, 0x01, // I001/161
+		0x19, 0x00, 0x40, 0x00, // I001/040: 50 NM, 90°
+		0x02, 0x9C, // I001/070: 1234
+	}
+)
+
+func newRecord(t *testing.T, profile asterix.UAP, items []testItem) *asterix.Record {
+	t.Helper()
+	record, err := asterix.NewRecord(asterix.Cat001, profile)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+	return record
+}
+
+func TestUAP001_PlotAndTrackRoundTrip(t *testing.T) {
+	plotUAP, err := cat001.NewPlotUAP(cat001.Version11)
+	if err != nil {
+		t.Fatalf("NewPlotUAP() error = %v", err)
+	}
+	trackUAP, err := cat001.NewTrackUAP(cat001.Version11)
+	if err != nil {
+		t.Fatalf("NewTrackUAP() error = %v", err)
+	}
+	selector, err := cat001.NewUAP(cat001.Version11)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat001, selector)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	for _, r := range []*asterix.Record{newRecord(t, plotUAP, plotItems), newRecord(t, trackUAP, trackItems)} {
+		if err := block.AddRecord(r); err != nil {
+			t.Fatalf("AddRecord() error = %v", err)
+		}
+	}
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(encoded, plotAndTrack) {
+		t.Fatalf("Encode() = % X, want % X", encoded, plotAndTrack)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat001, selector)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatalf("DataBlock.Decode() error = %v", err)
+	}
+	if decoded.Length() != 2 {
+		t.Fatalf("Length() = %d, want 2", decoded.Length())
+	}
+	if _, ok := decoded.Records()[0].UAP().(*uap.UAP001Plot); !ok {
+		t.Errorf("record 0 UAP = %T, want plot UAP", decoded.Records()[0].UAP())
+	}
+	if _, ok := decoded.Records()[1].UAP().(*uap.UAP001Track); !ok {
+		t.Errorf("record 1 UAP = %T, want track UAP", decoded.Records()[1].UAP())
+	}
+	for i, items := range [][]testItem{plotItems, trackItems} {
+		for _, it := range items {
+			item, _, ok := decoded.Records()[i].GetDataItem(it.id)
+			if !ok {
+				t.Errorf("record %d: %s missing after decode", i, it.id)
+				continue
+			}
+			if !reflect.DeepEqual(item, it.item) {
+				t.Errorf("record %d: %s = %+v, want %+v", i, it.id, item, it.item)
+			}
+		}
+	}
+}
+
+func TestUAP001_Decoder(t *testing.T) {
+	selector, err := cat001.NewUAP(cat001.Version11)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	decoder, err := asterix.NewDecoder(selector)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	msg, err := decoder.Decode(bytes.NewReader(plotAndTrack))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if msg.GetRecordCount() != 2 {
+		t.Fatalf("GetRecordCount() = %d, want 2", msg.GetRecordCount())
+	}
+	if item, _, ok := msg.GetDataItemFromRecord("I001/161", 1); !ok || !reflect.DeepEqual(item, trackItems[2].item) {
+		t.Errorf("track I001/161 = %v, %v", item, ok)
+	}
+	if item, _, ok := msg.GetDataItemFromRecord("I001/070", 0); !ok || !reflect.DeepEqual(item, plotItems[3].item) {
+		t.Errorf("plot I001/070 = %v, %v", item, ok)
+	}
+}
+
+func TestUAP001_TYPMustMatchProfile(t *testing.T) {
+	plotUAP, err := cat001.NewPlotUAP(cat001.Version11)
+	if err != nil {
+		t.Fatalf("NewPlotUAP() error = %v", err)
+	}
+	items := map[string]asterix.DataItem{}
+	for _, it := range trackItems[:2] {
+		items[it.id] = it.item
+	}
+	if err := plotUAP.Validate(items); !errors.Is(err, asterix.ErrInvalidField) {
+		t.Errorf("plot Validate() of a track error = %v, want %v", err, asterix.ErrInvalidField)
+	}
+}
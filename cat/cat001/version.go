@@ -0,0 +1,57 @@
+// cat/cat001/version.go
+package cat001
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat001/uap"
+)
+
+// Version constants
+const (
+	Version11 = "1.1"
+)
+
+// NewUAP returns the UAP for the specified version of CAT001. It decodes
+// both plots and tracks, selecting the profile from I001/020 of each record.
+func NewUAP(version string) (asterix.UAP, error) {
+	switch version {
+	case Version11:
+		return uap.NewUAP001()
+	default:
+		return nil, fmt.Errorf("%w: CAT001 %s", asterix.ErrUnsupportedVersion, version)
+	}
+}
+
+// NewPlotUAP returns the plot UAP for the specified version of CAT001, for
+// building plot records
+func NewPlotUAP(version string) (asterix.UAP, error) {
+	switch version {
+	case Version11:
+		return uap.NewUAP001Plot()
+	default:
+		return nil, fmt.Errorf("%w: CAT001 %s", asterix.ErrUnsupportedVersion, version)
+	}
+}
+
+// NewTrackUAP returns the track UAP for the specified version of CAT001, for
+// building track records
+func NewTrackUAP(version string) (asterix.UAP, error) {
+	switch version {
+	case Version11:
+		return uap.NewUAP001Track()
+	default:
+		return nil, fmt.Errorf("%w: CAT001 %s", asterix.ErrUnsupportedVersion, version)
+	}
+}
+
+// LatestVersion returns the latest available version
+func LatestVersion() string {
+	return Version11
+}
+
+// AvailableVersions returns all supported versions
+func AvailableVersions() []string {
+	return []string{Version11}
+}
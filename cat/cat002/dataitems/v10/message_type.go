@@ -0,0 +1,71 @@
+// cat/cat002/dataitems/v10/message_type.go
+package v10
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Message types defined for I002/000
+const (
+	MessageTypeNorthMarker          uint8 = 1
+	MessageTypeSectorCrossing       uint8 = 2
+	MessageTypeSouthMarker          uint8 = 3
+	MessageTypeBlindZoneFilterStart uint8 = 8
+	MessageTypeBlindZoneFilterStop  uint8 = 9
+)
+
+// MessageType implements I002/000
+// This Data Item allows for a more convenient handling of the messages at
+// the receiver side by further defining the type of transaction.
+type MessageType struct {
+	Value uint8
+}
+
+func (m *MessageType) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading message type: %w", err)
+	}
+	m.Value = b
+
+	return 1, m.Validate()
+}
+
+func (m *MessageType) Encode(buf *bytes.Buffer) (int, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	if err := buf.WriteByte(m.Value); err != nil {
+		return 0, fmt.Errorf("writing message type: %w", err)
+	}
+	return 1, nil
+}
+
+func (m *MessageType) Validate() error {
+	switch m.Value {
+	case MessageTypeNorthMarker, MessageTypeSectorCrossing, MessageTypeSouthMarker,
+		MessageTypeBlindZoneFilterStart, MessageTypeBlindZoneFilterStop:
+		return nil
+	default:
+		return fmt.Errorf("invalid message type: %d", m.Value)
+	}
+}
+
+func (m *MessageType) String() string {
+	switch m.Value {
+	case MessageTypeNorthMarker:
+		return "North Marker"
+	case MessageTypeSectorCrossing:
+		return "Sector Crossing"
+	case MessageTypeSouthMarker:
+		return "South Marker"
+	case MessageTypeBlindZoneFilterStart:
+		return "Activation of Blind Zone Filtering"
+	case MessageTypeBlindZoneFilterStop:
+		return "Stop of Blind Zone Filtering"
+	default:
+		return fmt.Sprintf("Unknown (%d)", m.Value)
+	}
+}
@@ -0,0 +1,51 @@
+// cat/cat002/dataitems/v10/sector_number.go
+package v10
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// sectorLSB is the resolution of I002/020 in degrees
+const sectorLSB = 360.0 / 256
+
+// SectorNumber implements I002/020
+// Eight most significant bits of the antenna azimuth defining a particular
+// azimuth sector.
+type SectorNumber struct {
+	Azimuth float64 // Start of the sector in degrees, [0,360)
+}
+
+func (s *SectorNumber) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading sector number: %w", err)
+	}
+	s.Azimuth = float64(b) * sectorLSB
+	return 1, nil
+}
+
+func (s *SectorNumber) Encode(buf *bytes.Buffer) (int, error) {
+	if err := s.Validate(); err != nil {
+		return 0, err
+	}
+
+	// Azimuths that round up to 360° wrap to sector 0
+	raw := byte(int(math.Round(s.Azimuth/sectorLSB)) % 256)
+	if err := buf.WriteByte(raw); err != nil {
+		return 0, fmt.Errorf("writing sector number: %w", err)
+	}
+	return 1, nil
+}
+
+func (s *SectorNumber) Validate() error {
+	if s.Azimuth < 0 || s.Azimuth >= 360 {
+		return fmt.Errorf("sector azimuth out of range [0,360): %f", s.Azimuth)
+	}
+	return nil
+}
+
+func (s *SectorNumber) String() string {
+	return fmt.Sprintf("%.2f°", s.Azimuth)
+}
@@ -0,0 +1,168 @@
+// cat/cat002/uap/uap_v10.go
+package uap
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v10 "github.com/davidkohl/gobelix/cat/cat002/dataitems/v10"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// UAP002 implements the User Application Profile for ASTERIX Category 002
+type UAP002 struct {
+	*asterix.BaseUAP
+}
+
+// NewUAP002 creates a new instance of the Category 002 UAP
+func NewUAP002() (*UAP002, error) {
+	base, err := asterix.NewBaseUAP(asterix.Cat002, "1.0", cat002Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UAP002{
+		BaseUAP: base,
+	}, nil
+}
+
+// CreateDataItem creates a new instance of a Cat002 data item
+func (u *UAP002) CreateDataItem(id string) (asterix.DataItem, error) {
+	switch id {
+	case "I002/000":
+		return &v10.MessageType{}, nil
+	case "I002/010":
+		return &common.DataSourceIdentifier{}, nil
+	case "I002/020":
+		return &v10.SectorNumber{}, nil
+	case "I002/030":
+		return &common.TimeOfDay{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", asterix.ErrUnknownDataItem, id)
+	}
+}
+
+// Validate implements critical validations for Cat002
+func (u *UAP002) Validate(items map[string]asterix.DataItem) error {
+	// First do base validation (mandatory fields)
+	if err := u.BaseUAP.Validate(items); err != nil {
+		return err
+	}
+
+	// A sector crossing message must say which sector was crossed
+	if mt, ok := items["I002/000"].(*v10.MessageType); ok && mt.Value == v10.MessageTypeSectorCrossing {
+		if _, exists := items["I002/020"]; !exists {
+			return fmt.Errorf("%w: sector crossing message without I002/020", asterix.ErrMandatoryField)
+		}
+	}
+
+	return nil
+}
+
+// cat002Fields defines the complete UAP for Category 002. FRN 12 is spare.
+var cat002Fields = []asterix.DataField{
+	{
+		FRN:         1,
+		DataItem:    "I002/010",
+		Description: "Data Source Identifier",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         2,
+		DataItem:    "I002/000",
+		Description: "Message Type",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   true,
+	},
+	{
+		FRN:         3,
+		DataItem:    "I002/020",
+		Description: "Sector Number",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         4,
+		DataItem:    "I002/030",
+		Description: "Time of Day",
+		Type:        asterix.Fixed,
+		Length:      3,
+		Mandatory:   false,
+	},
+	{
+		FRN:         5,
+		DataItem:    "I002/041",
+		Description: "Antenna Rotation Speed",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         6,
+		DataItem:    "I002/050",
+		Description: "Station Configuration Status",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         7,
+		DataItem:    "I002/060",
+		Description: "Station Processing Mode",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         8,
+		DataItem:    "I002/070",
+		Description: "Plot Count Values",
+		Type:        asterix.Repetitive,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         9,
+		DataItem:    "I002/100",
+		Description: "Dynamic Window - Type 1",
+		Type:        asterix.Fixed,
+		Length:      8,
+		Mandatory:   false,
+	},
+	{
+		FRN:         10,
+		DataItem:    "I002/090",
+		Description: "Collimation Error",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         11,
+		DataItem:    "I002/080",
+		Description: "Warning/Error Conditions",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         13,
+		DataItem:    "SP002",
+		Description: "Special Purpose Field",
+		Type:        asterix.Explicit,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         14,
+		DataItem:    "RFS002",
+		Description: "Random Field Sequencing",
+		Type:        asterix.Repetitive,
+		Length:      1,
+		Mandatory:   false,
+	},
+}
@@ -0,0 +1,100 @@
+// cat/cat002/uap/uap_v10_test.go
+package uap_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat002"
+	v10 "github.com/davidkohl/gobelix/cat/cat002/dataitems/v10"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestUAP002_SectorCrossingRoundTrip(t *testing.T) {
+	uap, err := cat002.NewUAP(cat002.Version10)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	record, err := asterix.NewRecord(asterix.Cat002, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I002/010", &common.DataSourceIdentifier{SAC: 8, SIC: 1}},
+		{"I002/000", &v10.MessageType{Value: v10.MessageTypeSectorCrossing}},
+		{"I002/020", &v10.SectorNumber{Azimuth: 90}},
+		{"I002/030", &common.TimeOfDay{Time: 36000.5}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat002, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(record); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	want := []byte{
+		2, 0x00, 0x0B, // CAT, LEN
+		0xF0,       // FSPEC: 010, 000, 020, 030
+		0x08, 0x01, // I002/010
+		0x02,             // I002/000
+		0x40,             // I002/020
+		0x46, 0x50, 0x40, // I002/030
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Fatalf("Encode() = % X, want % X", encoded, want)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat002, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatalf("DataBlock.Decode() error = %v", err)
+	}
+	if decoded.Length() != 1 {
+		t.Fatalf("Length() = %d, want 1", decoded.Length())
+	}
+	got := decoded.Records()[0]
+	for _, it := range items {
+		item, _, ok := got.GetDataItem(it.id)
+		if !ok {
+			t.Errorf("%s missing after decode", it.id)
+			continue
+		}
+		if !reflect.DeepEqual(item, it.item) {
+			t.Errorf("%s = %+v, want %+v", it.id, item, it.item)
+		}
+	}
+}
+
+func TestUAP002_SectorCrossingNeedsSector(t *testing.T) {
+	uap, err := cat002.NewUAP(cat002.Version10)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	items := map[string]asterix.DataItem{
+		"I002/010": &common.DataSourceIdentifier{SAC: 8, SIC: 1},
+		"I002/000": &v10.MessageType{Value: v10.MessageTypeSectorCrossing},
+	}
+	if err := uap.Validate(items); !errors.Is(err, asterix.ErrMandatoryField) {
+		t.Errorf("Validate() without I002/020 error = %v, want %v", err, asterix.ErrMandatoryField)
+	}
+}
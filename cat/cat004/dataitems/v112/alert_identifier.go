@@ -0,0 +1,44 @@
+// cat/cat004/dataitems/v112/alert_identifier.go
+package v112
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// AlertIdentifier implements I004/040
+// Identification of an alert (alert number), unique for the lifetime of
+// the alert
+type AlertIdentifier struct {
+	Value uint16
+}
+
+func (a *AlertIdentifier) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading alert identifier: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for alert identifier: got %d bytes, want 2", n)
+	}
+
+	a.Value = uint16(data[0])<<8 | uint16(data[1])
+	return n, nil
+}
+
+func (a *AlertIdentifier) Encode(buf *bytes.Buffer) (int, error) {
+	n, err := buf.Write([]byte{byte(a.Value >> 8), byte(a.Value)})
+	if err != nil {
+		return n, fmt.Errorf("writing alert identifier: %w", err)
+	}
+	return n, nil
+}
+
+func (a *AlertIdentifier) Validate() error {
+	return nil
+}
+
+func (a *AlertIdentifier) String() string {
+	return fmt.Sprintf("%d", a.Value)
+}
@@ -0,0 +1,47 @@
+// cat/cat004/dataitems/v112/alert_status.go
+package v112
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// AlertStatus implements I004/045
+// Information concerning the status of the alert. The meaning of each
+// status value is defined by the safety nets system.
+type AlertStatus struct {
+	STAT uint8 // Status of the alert, 0 to 7
+}
+
+func (a *AlertStatus) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading alert status: %w", err)
+	}
+
+	// Bits 8-4 are spare
+	a.STAT = b & 0x07
+	return 1, nil
+}
+
+func (a *AlertStatus) Encode(buf *bytes.Buffer) (int, error) {
+	if err := a.Validate(); err != nil {
+		return 0, err
+	}
+
+	if err := buf.WriteByte(a.STAT); err != nil {
+		return 0, fmt.Errorf("writing alert status: %w", err)
+	}
+	return 1, nil
+}
+
+func (a *AlertStatus) Validate() error {
+	if a.STAT > 7 {
+		return fmt.Errorf("invalid alert status: %d", a.STAT)
+	}
+	return nil
+}
+
+func (a *AlertStatus) String() string {
+	return fmt.Sprintf("STAT: %d", a.STAT)
+}
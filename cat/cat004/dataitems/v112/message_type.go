@@ -0,0 +1,93 @@
+// cat/cat004/dataitems/v112/message_type.go
+package v112
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Message types defined for I004/000. Values without a constant are
+// accepted and printed by number.
+const (
+	MessageTypeAlive  uint8 = 1  // Alive message
+	MessageTypeRAMLD  uint8 = 2  // Route adherence monitor longitudinal deviation
+	MessageTypeRAMHD  uint8 = 3  // Route adherence monitor heading deviation
+	MessageTypeMSAW   uint8 = 4  // Minimum safe altitude warning
+	MessageTypeAPW    uint8 = 5  // Area proximity warning
+	MessageTypeCLAM   uint8 = 6  // Clearance level adherence monitor
+	MessageTypeSTCA   uint8 = 7  // Short term conflict alert
+	MessageTypeAPM    uint8 = 8  // Approach path monitor
+	MessageTypeALM    uint8 = 9  // RIMCAS arrival/landing monitor
+	MessageTypeWRA    uint8 = 10 // RIMCAS arrival/departure wrong runway alert
+	MessageTypeOTA    uint8 = 11 // RIMCAS arrival/departure opposite traffic alert
+	MessageTypeRDM    uint8 = 12 // RIMCAS departure monitor
+	MessageTypeRCM    uint8 = 13 // RIMCAS runway/taxiway crossing monitor
+	MessageTypeTSM    uint8 = 14 // RIMCAS taxiway separation monitor
+	MessageTypeUTMM   uint8 = 15 // RIMCAS unauthorized taxiway movement monitor
+	MessageTypeSBOA   uint8 = 16 // RIMCAS stop bar overrun alert
+	MessageTypeEOC    uint8 = 17 // End of conflict
+	MessageTypeACASRA uint8 = 18 // ACAS resolution advisory
+)
+
+var messageTypeNames = map[uint8]string{
+	MessageTypeAlive:  "Alive",
+	MessageTypeRAMLD:  "RAMLD",
+	MessageTypeRAMHD:  "RAMHD",
+	MessageTypeMSAW:   "MSAW",
+	MessageTypeAPW:    "APW",
+	MessageTypeCLAM:   "CLAM",
+	MessageTypeSTCA:   "STCA",
+	MessageTypeAPM:    "APM",
+	MessageTypeALM:    "ALM",
+	MessageTypeWRA:    "WRA",
+	MessageTypeOTA:    "OTA",
+	MessageTypeRDM:    "RDM",
+	MessageTypeRCM:    "RCM",
+	MessageTypeTSM:    "TSM",
+	MessageTypeUTMM:   "UTMM",
+	MessageTypeSBOA:   "SBOA",
+	MessageTypeEOC:    "End of Conflict",
+	MessageTypeACASRA: "ACAS RA",
+}
+
+// MessageType implements I004/000
+// This Data Item allows for a more convenient handling of the messages at
+// the receiver side by further defining the type of alert.
+type MessageType struct {
+	Value uint8
+}
+
+func (m *MessageType) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading message type: %w", err)
+	}
+	m.Value = b
+
+	return 1, m.Validate()
+}
+
+func (m *MessageType) Encode(buf *bytes.Buffer) (int, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	if err := buf.WriteByte(m.Value); err != nil {
+		return 0, fmt.Errorf("writing message type: %w", err)
+	}
+	return 1, nil
+}
+
+func (m *MessageType) Validate() error {
+	if m.Value == 0 {
+		return fmt.Errorf("invalid message type: %d", m.Value)
+	}
+	return nil
+}
+
+func (m *MessageType) String() string {
+	if name, ok := messageTypeNames[m.Value]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", m.Value)
+}
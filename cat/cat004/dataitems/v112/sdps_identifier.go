@@ -0,0 +1,59 @@
+// cat/cat004/dataitems/v112/sdps_identifier.go
+package v112
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// SDPSIdentifier implements I004/015
+// Identification of the SDPS providing data to the safety nets server,
+// as a repetition of SAC/SIC pairs
+type SDPSIdentifier struct {
+	Sources []common.DataSourceIdentifier
+}
+
+func (s *SDPSIdentifier) Decode(buf *bytes.Buffer) (int, error) {
+	elems, n, err := asterix.ReadRepetitive(buf, 2)
+	if err != nil {
+		return n, fmt.Errorf("reading SDPS identifier: %w", err)
+	}
+
+	s.Sources = make([]common.DataSourceIdentifier, len(elems))
+	for i, e := range elems {
+		s.Sources[i] = common.DataSourceIdentifier{SAC: e[0], SIC: e[1]}
+	}
+	return n, nil
+}
+
+func (s *SDPSIdentifier) Encode(buf *bytes.Buffer) (int, error) {
+	elems := make([][]byte, len(s.Sources))
+	for i, src := range s.Sources {
+		elems[i] = []byte{src.SAC, src.SIC}
+	}
+
+	n, err := asterix.WriteRepetitive(buf, 2, elems)
+	if err != nil {
+		return n, fmt.Errorf("writing SDPS identifier: %w", err)
+	}
+	return n, nil
+}
+
+func (s *SDPSIdentifier) Validate() error {
+	if len(s.Sources) > 255 {
+		return fmt.Errorf("%w: too many SDPS identifiers: %d (max 255)", asterix.ErrInvalidField, len(s.Sources))
+	}
+	return nil
+}
+
+func (s *SDPSIdentifier) String() string {
+	parts := make([]string, len(s.Sources))
+	for i, src := range s.Sources {
+		parts[i] = fmt.Sprintf("SAC: %d, SIC: %d", src.SAC, src.SIC)
+	}
+	return "[" + strings.Join(parts, "; ") + "]"
+}
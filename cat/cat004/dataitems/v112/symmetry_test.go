@@ -0,0 +1,31 @@
+// cat/cat004/dataitems/v112/symmetry_test.go
+package v112_test
+
+import (
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v112 "github.com/davidkohl/gobelix/cat/cat004/dataitems/v112"
+)
+
+func TestAlertItems_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v112.MessageType{} }, [][]byte{{0x01}, {0x07}, {0x12}})
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v112.SDPSIdentifier{} }, [][]byte{
+		{0x00},
+		{0x01, 0x19, 0x01},
+		{0x02, 0x19, 0x01, 0x19, 0x02},
+	})
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v112.AlertIdentifier{} }, [][]byte{{0x00, 0x00}, {0xFF, 0xFF}})
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v112.AlertStatus{} }, [][]byte{{0x00}, {0x07}})
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v112.TrackNumber{} }, [][]byte{{0x04, 0x57}})
+}
+
+func TestMessageType_RejectsZero(t *testing.T) {
+	if err := (&v112.MessageType{}).Validate(); err == nil {
+		t.Error("Validate() accepted message type 0")
+	}
+	if got := (&v112.MessageType{Value: v112.MessageTypeSTCA}).String(); got != "STCA" {
+		t.Errorf("String() = %q, want STCA", got)
+	}
+}
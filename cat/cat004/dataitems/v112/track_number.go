@@ -0,0 +1,49 @@
+// cat/cat004/dataitems/v112/track_number.go
+package v112
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// TrackNumber implements I004/030 and I004/035
+// Identification of a track involved in the conflict, as allocated by the
+// SDPS
+type TrackNumber struct {
+	Value uint16
+}
+
+func (t *TrackNumber) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading track number: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for track number: got %d bytes, want 2", n)
+	}
+
+	t.Value = uint16(data[0])<<8 | uint16(data[1])
+	return n, nil
+}
+
+func (t *TrackNumber) Encode(buf *bytes.Buffer) (int, error) {
+	n, err := buf.Write([]byte{byte(t.Value >> 8), byte(t.Value)})
+	if err != nil {
+		return n, fmt.Errorf("writing track number: %w", err)
+	}
+	return n, nil
+}
+
+func (t *TrackNumber) Validate() error {
+	return nil
+}
+
+func (t *TrackNumber) String() string {
+	return fmt.Sprintf("%d", t.Value)
+}
+
+// TrackNumber implements asterix.TrackNumberProvider
+func (t *TrackNumber) TrackNumber() uint16 {
+	return t.Value
+}
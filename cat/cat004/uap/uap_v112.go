@@ -0,0 +1,213 @@
+// cat/cat004/uap/uap_v112.go
+package uap
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v112 "github.com/davidkohl/gobelix/cat/cat004/dataitems/v112"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// UAP004 implements the User Application Profile for ASTERIX Category 004
+type UAP004 struct {
+	*asterix.BaseUAP
+}
+
+// NewUAP004 creates a new instance of the Category 004 UAP
+func NewUAP004() (*UAP004, error) {
+	base, err := asterix.NewBaseUAP(asterix.Cat004, "1.12", cat004Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UAP004{
+		BaseUAP: base,
+	}, nil
+}
+
+// CreateDataItem creates a new instance of a Cat004 data item
+func (u *UAP004) CreateDataItem(id string) (asterix.DataItem, error) {
+	switch id {
+	case "I004/000":
+		return &v112.MessageType{}, nil
+	case "I004/010":
+		return &common.DataSourceIdentifier{}, nil
+	case "I004/015":
+		return &v112.SDPSIdentifier{}, nil
+	case "I004/020":
+		return &common.TimeOfDay{}, nil
+	case "I004/030", "I004/035":
+		return &v112.TrackNumber{}, nil
+	case "I004/040":
+		return &v112.AlertIdentifier{}, nil
+	case "I004/045":
+		return &v112.AlertStatus{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", asterix.ErrUnknownDataItem, id)
+	}
+}
+
+// cat004Fields defines the complete UAP for Category 004. FRN 19 is spare.
+var cat004Fields = []asterix.DataField{
+	{
+		FRN:         1,
+		DataItem:    "I004/010",
+		Description: "Data Source Identifier",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         2,
+		DataItem:    "I004/000",
+		Description: "Message Type",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   true,
+	},
+	{
+		FRN:         3,
+		DataItem:    "I004/015",
+		Description: "SDPS Identifier",
+		Type:        asterix.Repetitive,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         4,
+		DataItem:    "I004/020",
+		Description: "Time of Message",
+		Type:        asterix.Fixed,
+		Length:      3,
+		Mandatory:   false,
+	},
+	{
+		FRN:         5,
+		DataItem:    "I004/040",
+		Description: "Alert Identifier",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         6,
+		DataItem:    "I004/045",
+		Description: "Alert Status",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         7,
+		DataItem:    "I004/060",
+		Description: "Safety Net Function & System Status",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         8,
+		DataItem:    "I004/030",
+		Description: "Track Number 1",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         9,
+		DataItem:    "I004/170",
+		Description: "Aircraft Identification & Characteristics 1",
+		Type:        asterix.Compound,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         10,
+		DataItem:    "I004/120",
+		Description: "Conflict Characteristics",
+		Type:        asterix.Compound,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         11,
+		DataItem:    "I004/070",
+		Description: "Conflict Timing and Separation",
+		Type:        asterix.Compound,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         12,
+		DataItem:    "I004/076",
+		Description: "Vertical Deviation",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         13,
+		DataItem:    "I004/074",
+		Description: "Longitudinal Deviation",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         14,
+		DataItem:    "I004/075",
+		Description: "Transversal Distance Deviation",
+		Type:        asterix.Fixed,
+		Length:      3,
+		Mandatory:   false,
+	},
+	{
+		FRN:         15,
+		DataItem:    "I004/100",
+		Description: "Area Definitions",
+		Type:        asterix.Compound,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         16,
+		DataItem:    "I004/035",
+		Description: "Track Number 2",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         17,
+		DataItem:    "I004/171",
+		Description: "Aircraft Identification & Characteristics 2",
+		Type:        asterix.Compound,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         18,
+		DataItem:    "I004/110",
+		Description: "FDPS Sector Control Identification",
+		Type:        asterix.Repetitive,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         20,
+		DataItem:    "RE004",
+		Description: "Reserved Expansion Field",
+		Type:        asterix.Explicit,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         21,
+		DataItem:    "SP004",
+		Description: "Special Purpose Field",
+		Type:        asterix.Explicit,
+		Length:      1,
+		Mandatory:   false,
+	},
+}
@@ -0,0 +1,93 @@
+// cat/cat004/uap/uap_v112_test.go
+package uap_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat004"
+	v112 "github.com/davidkohl/gobelix/cat/cat004/dataitems/v112"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestUAP004_STCAAlertRoundTrip(t *testing.T) {
+	uap, err := cat004.NewUAP(cat004.Version112)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	record, err := asterix.NewRecord(asterix.Cat004, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I004/010", &common.DataSourceIdentifier{SAC: 25, SIC: 4}},
+		{"I004/000", &v112.MessageType{Value: v112.MessageTypeSTCA}},
+		{"I004/015", &v112.SDPSIdentifier{Sources: []common.DataSourceIdentifier{{SAC: 25, SIC: 1}}}},
+		{"I004/020", &common.TimeOfDay{Time: 36000.5}},
+		{"I004/040", &v112.AlertIdentifier{Value: 300}},
+		{"I004/045", &v112.AlertStatus{STAT: 1}},
+		{"I004/030", &v112.TrackNumber{Value: 1111}},
+		{"I004/035", &v112.TrackNumber{Value: 2222}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat004, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(record); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	want := []byte{
+		4, 0x00, 0x16, // CAT, LEN
+		0xFD, 0x81, 0x40, // FSPEC: 010, 000, 015, 020, 040, 045, 030, 035
+		0x19, 0x04, // I004/010
+		0x07,             // I004/000
+		0x01, 0x19, 0x01, // I004/015
+		0x46, 0x50, 0x40, // I004/020
+		0x01, 0x2C, // I004/040
+		0x01,       // I004/045
+		0x04, 0x57, // I004/030
+		0x08, 0xAE, // I004/035
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Fatalf("Encode() = % X, want % X", encoded, want)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat004, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatalf("DataBlock.Decode() error = %v", err)
+	}
+	if decoded.Length() != 1 {
+		t.Fatalf("Length() = %d, want 1", decoded.Length())
+	}
+	got := decoded.Records()[0]
+	for _, it := range items {
+		item, _, ok := got.GetDataItem(it.id)
+		if !ok {
+			t.Errorf("%s missing after decode", it.id)
+			continue
+		}
+		if !reflect.DeepEqual(item, it.item) {
+			t.Errorf("%s = %+v, want %+v", it.id, item, it.item)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// cat/cat004/version.go
+package cat004
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat004/uap"
+)
+
+// Version constants
+const (
+	Version112 = "1.12"
+)
+
+// NewUAP returns the UAP for the specified version of CAT004
+func NewUAP(version string) (asterix.UAP, error) {
+	switch version {
+	case Version112:
+		return uap.NewUAP004()
+	default:
+		return nil, fmt.Errorf("%w: CAT004 %s", asterix.ErrUnsupportedVersion, version)
+	}
+}
+
+// LatestVersion returns the latest available version
+func LatestVersion() string {
+	return Version112
+}
+
+// AvailableVersions returns all supported versions
+func AvailableVersions() []string {
+	return []string{Version112}
+}
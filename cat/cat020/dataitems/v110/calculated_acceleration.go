@@ -0,0 +1,64 @@
+// cat/cat020/dataitems/v110/calculated_acceleration.go
+package v110
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// accelerationLSB is the resolution of both I020/210 components in m/s²
+const accelerationLSB = 0.25
+
+// CalculatedAcceleration implements I020/210
+// Calculated acceleration of the target, in two's complement form
+type CalculatedAcceleration struct {
+	Ax float64 // X component in m/s²
+	Ay float64 // Y component in m/s²
+}
+
+func (c *CalculatedAcceleration) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading calculated acceleration: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for calculated acceleration: got %d bytes, want 2", n)
+	}
+
+	c.Ax = float64(int8(data[0])) * accelerationLSB
+	c.Ay = float64(int8(data[1])) * accelerationLSB
+	return n, nil
+}
+
+func (c *CalculatedAcceleration) Encode(buf *bytes.Buffer) (int, error) {
+	if err := c.Validate(); err != nil {
+		return 0, err
+	}
+
+	ax := int8(math.Round(c.Ax / accelerationLSB))
+	ay := int8(math.Round(c.Ay / accelerationLSB))
+	n, err := buf.Write([]byte{byte(ax), byte(ay)})
+	if err != nil {
+		return n, fmt.Errorf("writing calculated acceleration: %w", err)
+	}
+	return n, nil
+}
+
+func (c *CalculatedAcceleration) Validate() error {
+	for _, a := range []struct {
+		name  string
+		value float64
+	}{{"Ax", c.Ax}, {"Ay", c.Ay}} {
+		if raw := math.Round(a.value / accelerationLSB); raw < math.MinInt8 || raw > math.MaxInt8 {
+			return fmt.Errorf("%s out of range [%.2f,%.2f]: %f", a.name,
+				math.MinInt8*accelerationLSB, math.MaxInt8*accelerationLSB, a.value)
+		}
+	}
+	return nil
+}
+
+func (c *CalculatedAcceleration) String() string {
+	return fmt.Sprintf("Ax: %.2f m/s², Ay: %.2f m/s²", c.Ax, c.Ay)
+}
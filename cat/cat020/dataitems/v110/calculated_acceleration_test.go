@@ -0,0 +1,53 @@
+// cat/cat020/dataitems/v110/calculated_acceleration_test.go
+package v110_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v110 "github.com/davidkohl/gobelix/cat/cat020/dataitems/v110"
+)
+
+func TestCalculatedAcceleration(t *testing.T) {
+	tests := []struct {
+		data   []byte
+		ax, ay float64
+	}{
+		{[]byte{0x00, 0x00}, 0, 0},
+		{[]byte{0x04, 0x0A}, 1, 2.5},
+		{[]byte{0xFC, 0xFF}, -1, -0.25},
+		{[]byte{0x7F, 0x80}, 31.75, -32},
+	}
+	for _, tt := range tests {
+		var acc v110.CalculatedAcceleration
+		if _, err := acc.Decode(bytes.NewBuffer(tt.data)); err != nil {
+			t.Fatalf("Decode(% X) error = %v", tt.data, err)
+		}
+		if acc.Ax != tt.ax || acc.Ay != tt.ay {
+			t.Errorf("Decode(% X) = %v, %v, want %v, %v", tt.data, acc.Ax, acc.Ay, tt.ax, tt.ay)
+		}
+
+		var buf bytes.Buffer
+		if _, err := acc.Encode(&buf); err != nil {
+			t.Fatalf("Encode(%v, %v) error = %v", tt.ax, tt.ay, err)
+		}
+		if !bytes.Equal(buf.Bytes(), tt.data) {
+			t.Errorf("Encode(%v, %v) = % X, want % X", tt.ax, tt.ay, buf.Bytes(), tt.data)
+		}
+	}
+
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v110.CalculatedAcceleration{} }, [][]byte{
+		{0x04, 0x0A}, {0xFC, 0xFF}, {0x7F, 0x80},
+	})
+
+	if got := (&v110.CalculatedAcceleration{Ax: -1, Ay: 2.5}).String(); got != "Ax: -1.00 m/s², Ay: 2.50 m/s²" {
+		t.Errorf("String() = %q", got)
+	}
+	for _, acc := range []v110.CalculatedAcceleration{{Ax: 32}, {Ay: -32.25}} {
+		if _, err := acc.Encode(new(bytes.Buffer)); err == nil {
+			t.Errorf("Encode(%+v) succeeded", acc)
+		}
+	}
+}
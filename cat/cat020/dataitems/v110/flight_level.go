@@ -0,0 +1,90 @@
+// cat/cat020/dataitems/v110/flight_level.go
+package v110
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FlightLevel implements I020/090
+// Flight level converted into binary representation, with LSB 1/4 FL
+type FlightLevel struct {
+	Valid   bool    // Code validated (V = 0)
+	Garbled bool    // Garbled code (G = 1)
+	Level   float64 // Flight level, as a multiple of 100 ft
+}
+
+func (f *FlightLevel) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading flight level: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for flight level: got %d bytes, want 2", n)
+	}
+
+	f.Valid = data[0]&0x80 == 0 // bit 16
+	f.Garbled = data[0]&0x40 != 0
+
+	// Bits 14-1 in two's complement: shift the sign bit into bit 16 and
+	// back to sign extend
+	raw := int16(uint16(data[0])<<8|uint16(data[1])) << 2 >> 2
+	f.Level = float64(raw) * 0.25
+	return n, nil
+}
+
+func (f *FlightLevel) Encode(buf *bytes.Buffer) (int, error) {
+	if err := f.Validate(); err != nil {
+		return 0, err
+	}
+
+	raw := uint16(int16(math.Round(f.Level/0.25))) & 0x3FFF
+	if !f.Valid {
+		raw |= 0x8000
+	}
+	if f.Garbled {
+		raw |= 0x4000
+	}
+
+	n, err := buf.Write([]byte{byte(raw >> 8), byte(raw)})
+	if err != nil {
+		return n, fmt.Errorf("writing flight level: %w", err)
+	}
+	return n, nil
+}
+
+func (f *FlightLevel) Validate() error {
+	// 14 bits in two's complement cover -2048 FL to 2047.75 FL
+	if raw := math.Round(f.Level / 0.25); raw < -1<<13 || raw > 1<<13-1 {
+		return fmt.Errorf("flight level out of range [-2048,2047.75]: %f", f.Level)
+	}
+	return nil
+}
+
+func (f *FlightLevel) String() string {
+	level := strconv.FormatFloat(f.Level, 'f', -1, 64)
+	if !strings.Contains(level, ".") {
+		level += ".0"
+	}
+
+	var flags []string
+	if !f.Valid {
+		flags = append(flags, "not validated")
+	}
+	if f.Garbled {
+		flags = append(flags, "garbled")
+	}
+	if len(flags) == 0 {
+		return "FL" + level
+	}
+	return fmt.Sprintf("FL%s (%s)", level, strings.Join(flags, ", "))
+}
+
+// AltitudeFeet implements asterix.AltitudeProvider
+func (f *FlightLevel) AltitudeFeet() float64 {
+	return f.Level * 100
+}
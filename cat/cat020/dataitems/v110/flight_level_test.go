@@ -0,0 +1,55 @@
+// cat/cat020/dataitems/v110/flight_level_test.go
+package v110_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v110 "github.com/davidkohl/gobelix/cat/cat020/dataitems/v110"
+)
+
+func TestFlightLevel_Decode(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want v110.FlightLevel
+		str  string
+	}{
+		{"FL350", []byte{0x05, 0x78}, v110.FlightLevel{Valid: true, Level: 350}, "FL350.0"},
+		{"negative", []byte{0x3F, 0xF2}, v110.FlightLevel{Valid: true, Level: -3.5}, "FL-3.5"},
+		{"lowest", []byte{0x20, 0x00}, v110.FlightLevel{Valid: true, Level: -2048}, "FL-2048.0"},
+		{"garbled", []byte{0xC0, 0x51}, v110.FlightLevel{Garbled: true, Level: 20.25}, "FL20.25 (not validated, garbled)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fl v110.FlightLevel
+			if _, err := fl.Decode(bytes.NewBuffer(tt.data)); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if fl != tt.want {
+				t.Errorf("Decode() = %+v, want %+v", fl, tt.want)
+			}
+			if got := fl.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+
+			var buf bytes.Buffer
+			if _, err := fl.Encode(&buf); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.data) {
+				t.Errorf("Encode() = % X, want % X", buf.Bytes(), tt.data)
+			}
+		})
+	}
+
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v110.FlightLevel{} }, [][]byte{
+		{0x05, 0x78}, {0x3F, 0xF2}, {0xC0, 0x51}, {0x9F, 0xFF},
+	})
+
+	if err := (&v110.FlightLevel{Level: 2048}).Validate(); err == nil {
+		t.Error("Validate() accepted FL2048")
+	}
+}
@@ -0,0 +1,56 @@
+// cat/cat020/dataitems/v110/geometric_height.go
+package v110
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// GeometricHeight implements I020/105
+// Vertical distance between the target and the projection of its position on the earth's ellipsoid, as defined by WGS-84
+type GeometricHeight struct {
+	Height float64 // Height in feet
+}
+
+func (h *GeometricHeight) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading geometric height: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for geometric height: got %d bytes, want 2", n)
+	}
+
+	// Two's complement, LSB = 6.25 ft
+	raw := int16(data[0])<<8 | int16(data[1])
+	h.Height = float64(raw) * heightLSB
+	return n, nil
+}
+
+func (h *GeometricHeight) Encode(buf *bytes.Buffer) (int, error) {
+	if err := h.Validate(); err != nil {
+		return 0, err
+	}
+
+	raw := int16(math.Round(h.Height / heightLSB))
+	n, err := buf.Write([]byte{byte(raw >> 8), byte(raw)})
+	if err != nil {
+		return n, fmt.Errorf("writing geometric height: %w", err)
+	}
+	return n, nil
+}
+
+func (h *GeometricHeight) Validate() error {
+	return validateHeight(h.Height)
+}
+
+func (h *GeometricHeight) String() string {
+	return fmt.Sprintf("%.2f ft", h.Height)
+}
+
+// AltitudeFeet implements asterix.AltitudeProvider
+func (h *GeometricHeight) AltitudeFeet() float64 {
+	return h.Height
+}
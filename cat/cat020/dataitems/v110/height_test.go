@@ -0,0 +1,71 @@
+// cat/cat020/dataitems/v110/height_test.go
+package v110_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v110 "github.com/davidkohl/gobelix/cat/cat020/dataitems/v110"
+)
+
+// heightItem is the common shape of I020/105 and I020/110
+type heightItem interface {
+	asterix.DataItem
+	AltitudeFeet() float64
+}
+
+func TestHeights(t *testing.T) {
+	items := map[string]func() heightItem{
+		"I020/105": func() heightItem { return &v110.GeometricHeight{} },
+		"I020/110": func() heightItem { return &v110.MeasuredHeight{} },
+	}
+	tests := []struct {
+		data []byte
+		feet float64
+	}{
+		{[]byte{0x00, 0x00}, 0},
+		{[]byte{0x00, 0x01}, 6.25},
+		{[]byte{0x06, 0x40}, 10000},
+		{[]byte{0xFF, 0xFF}, -6.25},
+		{[]byte{0xFF, 0x10}, -1500},
+		{[]byte{0x7F, 0xFF}, 204793.75},
+		{[]byte{0x80, 0x00}, -204800},
+	}
+
+	for id, newItem := range items {
+		for _, tt := range tests {
+			item := newItem()
+			if _, err := item.Decode(bytes.NewBuffer(tt.data)); err != nil {
+				t.Fatalf("%s: Decode(% X) error = %v", id, tt.data, err)
+			}
+			if got := item.AltitudeFeet(); got != tt.feet {
+				t.Errorf("%s: Decode(% X) = %v ft, want %v", id, tt.data, got, tt.feet)
+			}
+
+			var buf bytes.Buffer
+			if _, err := item.Encode(&buf); err != nil {
+				t.Fatalf("%s: Encode(%v ft) error = %v", id, tt.feet, err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.data) {
+				t.Errorf("%s: Encode(%v ft) = % X, want % X", id, tt.feet, buf.Bytes(), tt.data)
+			}
+		}
+
+		var samples [][]byte
+		for _, tt := range tests {
+			samples = append(samples, tt.data)
+		}
+		asterixtest.RunItemSuite(t, func() asterix.DataItem { return newItem() }, samples)
+	}
+
+	if got := (&v110.MeasuredHeight{Height: -1500}).String(); got != "-1500.00 ft" {
+		t.Errorf("String() = %q", got)
+	}
+	for _, item := range []asterix.DataItem{&v110.GeometricHeight{Height: 204800}, &v110.MeasuredHeight{Height: -204810}} {
+		if err := item.Validate(); err == nil {
+			t.Errorf("Validate(%v) succeeded", item)
+		}
+	}
+}
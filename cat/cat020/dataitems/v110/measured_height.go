@@ -0,0 +1,68 @@
+// cat/cat020/dataitems/v110/measured_height.go
+package v110
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// MeasuredHeight implements I020/110
+// Height above the local 2D coordinate reference plane
+type MeasuredHeight struct {
+	Height float64 // Height in feet
+}
+
+// heightLSB is the resolution of I020/105 and I020/110 in feet
+const heightLSB = 6.25
+
+// validateHeight checks that feet fits the signed 16-bit field of
+// I020/105 and I020/110
+func validateHeight(feet float64) error {
+	if raw := math.Round(feet / heightLSB); raw < math.MinInt16 || raw > math.MaxInt16 {
+		return fmt.Errorf("height out of range [%.2f,%.2f]: %f", math.MinInt16*heightLSB, math.MaxInt16*heightLSB, feet)
+	}
+	return nil
+}
+
+func (h *MeasuredHeight) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading measured height: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for measured height: got %d bytes, want 2", n)
+	}
+
+	// Two's complement, LSB = 6.25 ft
+	raw := int16(data[0])<<8 | int16(data[1])
+	h.Height = float64(raw) * heightLSB
+	return n, nil
+}
+
+func (h *MeasuredHeight) Encode(buf *bytes.Buffer) (int, error) {
+	if err := h.Validate(); err != nil {
+		return 0, err
+	}
+
+	raw := int16(math.Round(h.Height / heightLSB))
+	n, err := buf.Write([]byte{byte(raw >> 8), byte(raw)})
+	if err != nil {
+		return n, fmt.Errorf("writing measured height: %w", err)
+	}
+	return n, nil
+}
+
+func (h *MeasuredHeight) Validate() error {
+	return validateHeight(h.Height)
+}
+
+func (h *MeasuredHeight) String() string {
+	return fmt.Sprintf("%.2f ft", h.Height)
+}
+
+// AltitudeFeet implements asterix.AltitudeProvider
+func (h *MeasuredHeight) AltitudeFeet() float64 {
+	return h.Height
+}
@@ -0,0 +1,100 @@
+// cat/cat020/dataitems/v110/mode_c_code.go
+package v110
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix/modec"
+)
+
+// ModeCCode implements I020/100
+// Mode-C height in Gray notation as received from the transponder, together
+// with the quality of each reply pulse
+type ModeCCode struct {
+	V    bool   // Code not validated
+	G    bool   // Garbled code
+	Code uint16 // Mode-C reply, C1 A1 C2 A2 C4 A4 B1 D1 B2 D2 B4 D4 from bit 12 down to bit 1
+
+	// QualityBits holds QC1 to QD4 in the bit order of Code. A set bit marks
+	// a low quality pulse.
+	QualityBits uint16
+}
+
+func (m *ModeCCode) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 4)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading Mode-C code: %w", err)
+	}
+	if n != 4 {
+		return n, fmt.Errorf("insufficient data for Mode-C code: got %d bytes, want 4", n)
+	}
+
+	m.V = data[0]&0x80 != 0
+	m.G = data[0]&0x40 != 0
+	// bits 30/29 and 16-13 are spare
+	m.Code = uint16(data[0]&0x0F)<<8 | uint16(data[1])
+	m.QualityBits = uint16(data[2]&0x0F)<<8 | uint16(data[3])
+	return n, nil
+}
+
+func (m *ModeCCode) Encode(buf *bytes.Buffer) (int, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	data := []byte{
+		byte(m.Code >> 8), byte(m.Code),
+		byte(m.QualityBits >> 8), byte(m.QualityBits),
+	}
+	if m.V {
+		data[0] |= 0x80
+	}
+	if m.G {
+		data[0] |= 0x40
+	}
+
+	n, err := buf.Write(data)
+	if err != nil {
+		return n, fmt.Errorf("writing Mode-C code: %w", err)
+	}
+	return n, nil
+}
+
+func (m *ModeCCode) Validate() error {
+	if m.Code > 0xFFF {
+		return fmt.Errorf("Mode-C code exceeds 12 bits: %X", m.Code)
+	}
+	if m.QualityBits > 0xFFF {
+		return fmt.Errorf("Mode-C quality bits exceed 12 bits: %X", m.QualityBits)
+	}
+	return nil
+}
+
+func (m *ModeCCode) String() string {
+	flags := ""
+	if m.V {
+		flags += "V "
+	}
+	if m.G {
+		flags += "G "
+	}
+	quality := "all pulses high quality"
+	if m.HasLowQualityPulses() {
+		quality = fmt.Sprintf("low quality pulses %03X", m.QualityBits)
+	}
+	return fmt.Sprintf("%sGray Code: %03X, %s", flags, m.Code, quality)
+}
+
+// HasLowQualityPulses reports whether any quality bit is set
+func (m *ModeCCode) HasLowQualityPulses() bool {
+	return m.QualityBits != 0
+}
+
+// AltitudeFeet decodes the Gray-coded reply into pressure altitude in feet.
+// ok is false when the code is not a legal Mode-C altitude. The V and G
+// flags are not taken into account.
+func (m *ModeCCode) AltitudeFeet() (int, bool) {
+	return modec.Altitude(m.Code)
+}
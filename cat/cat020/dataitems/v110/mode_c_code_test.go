@@ -0,0 +1,49 @@
+// cat/cat020/dataitems/v110/mode_c_code_test.go
+package v110_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v110 "github.com/davidkohl/gobelix/cat/cat020/dataitems/v110"
+)
+
+func TestModeCCode(t *testing.T) {
+	// G set, C2 A2 A4 B1 B4 (10000 ft), low quality C1 and D4
+	data := []byte{0x43, 0x62, 0x08, 0x01}
+
+	var m v110.ModeCCode
+	if _, err := m.Decode(bytes.NewBuffer(data)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want := v110.ModeCCode{G: true, Code: 0x362, QualityBits: 0x801}
+	if m != want {
+		t.Errorf("Decode() = %+v, want %+v", m, want)
+	}
+	if feet, ok := m.AltitudeFeet(); !ok || feet != 10000 {
+		t.Errorf("AltitudeFeet() = %d, %v, want 10000", feet, ok)
+	}
+	if got := m.String(); got != "G Gray Code: 362, low quality pulses 801" {
+		t.Errorf("String() = %q", got)
+	}
+
+	var buf bytes.Buffer
+	if _, err := m.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("Encode() = % X, want % X", buf.Bytes(), data)
+	}
+
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v110.ModeCCode{} }, [][]byte{
+		data,
+		{0x80, 0x00, 0x00, 0x00},
+		{0x0F, 0xFF, 0x0F, 0xFF},
+	})
+
+	if err := (&v110.ModeCCode{QualityBits: 0x1000}).Validate(); err == nil {
+		t.Error("Validate() accepted 13 quality bits")
+	}
+}
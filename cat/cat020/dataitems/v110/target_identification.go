@@ -0,0 +1,88 @@
+// cat/cat020/dataitems/v110/target_identification.go
+
+// Package v110 implements data items of ASTERIX Category 020 (multilateration
+// target reports) edition 1.10.
+package v110
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix/ident"
+)
+
+// Source of the target identification (STI)
+const (
+	STIDownlinked                uint8 = iota // Callsign or registration downlinked from the transponder
+	STICallsignNotDownlinked                  // Callsign not downlinked from the transponder
+	STIRegistrationNotDownlinked              // Registration not downlinked from the transponder
+	STIInvalid                                // Invalid
+)
+
+// TargetIdentification implements I020/245
+// Target (aircraft or vehicle) identification in 8 characters
+type TargetIdentification struct {
+	STI      uint8
+	Callsign string // Up to 8 characters, without trailing spaces
+}
+
+func (t *TargetIdentification) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 7)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading target identification: %w", err)
+	}
+	if n != 7 {
+		return n, fmt.Errorf("insufficient data for target identification: got %d bytes, want 7", n)
+	}
+
+	// STI in bits 56/55, the rest of the first octet is spare
+	t.STI = data[0] >> 6
+
+	callsign, err := ident.Decode(data[1:])
+	if err != nil {
+		return n, fmt.Errorf("decoding target identification: %w", err)
+	}
+	t.Callsign = callsign
+	return n, nil
+}
+
+func (t *TargetIdentification) Encode(buf *bytes.Buffer) (int, error) {
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+
+	chars, err := ident.Encode(t.Callsign)
+	if err != nil {
+		return 0, err
+	}
+
+	output := make([]byte, 1, 7)
+	output[0] = t.STI << 6
+	output = append(output, chars[:]...)
+
+	n, err := buf.Write(output)
+	if err != nil {
+		return n, fmt.Errorf("writing target identification: %w", err)
+	}
+	return n, nil
+}
+
+func (t *TargetIdentification) Validate() error {
+	if t.STI > STIInvalid {
+		return fmt.Errorf("invalid STI: %d", t.STI)
+	}
+	return ident.Validate(t.Callsign)
+}
+
+func (t *TargetIdentification) String() string {
+	switch t.STI {
+	case STICallsignNotDownlinked:
+		return fmt.Sprintf("%s (callsign not downlinked)", t.Callsign)
+	case STIRegistrationNotDownlinked:
+		return fmt.Sprintf("%s (registration not downlinked)", t.Callsign)
+	case STIInvalid:
+		return fmt.Sprintf("%s (invalid)", t.Callsign)
+	}
+	return t.Callsign
+}
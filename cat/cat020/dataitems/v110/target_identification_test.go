@@ -0,0 +1,60 @@
+// cat/cat020/dataitems/v110/target_identification_test.go
+package v110_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v110 "github.com/davidkohl/gobelix/cat/cat020/dataitems/v110"
+)
+
+func TestTargetIdentification_Decode(t *testing.T) {
+	// STI 01, "DLH4AB" padded with two spaces
+	data := []byte{0x40, 0x10, 0xC2, 0x34, 0x04, 0x28, 0x20}
+
+	var ti v110.TargetIdentification
+	n, err := ti.Decode(bytes.NewBuffer(data))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if n != 7 {
+		t.Errorf("Decode() n = %d, want 7", n)
+	}
+	if ti.Callsign != "DLH4AB" || ti.STI != v110.STICallsignNotDownlinked {
+		t.Errorf("Decode() = %+v, want STI 1, callsign DLH4AB", ti)
+	}
+	if got := ti.String(); got != "DLH4AB (callsign not downlinked)" {
+		t.Errorf("String() = %q", got)
+	}
+
+	var buf bytes.Buffer
+	if _, err := ti.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("Encode() = % X, want % X", buf.Bytes(), data)
+	}
+}
+
+func TestTargetIdentification_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v110.TargetIdentification{} }, [][]byte{
+		{0x00, 0x10, 0xC2, 0x34, 0x04, 0x28, 0x20},
+		{0xC0, 0x82, 0x08, 0x20, 0x82, 0x08, 0x20},
+	})
+}
+
+func TestTargetIdentification_Invalid(t *testing.T) {
+	// Code 0 is reserved
+	var ti v110.TargetIdentification
+	if _, err := ti.Decode(bytes.NewBuffer([]byte{0x00, 0x00, 0xC2, 0x34, 0x04, 0x28, 0x20})); err == nil {
+		t.Error("Decode() of a reserved character code succeeded")
+	}
+
+	for _, bad := range []v110.TargetIdentification{{Callsign: "dlh4ab"}, {Callsign: "DLH4ABCDE"}, {STI: 4}} {
+		if _, err := bad.Encode(new(bytes.Buffer)); err == nil {
+			t.Errorf("Encode(%+v) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// cat/cat020/uap/uap_v110_test.go
+package uap_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat020"
+	v110 "github.com/davidkohl/gobelix/cat/cat020/dataitems/v110"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestUAP020_CheckImplemented(t *testing.T) {
+	uap, err := cat020.NewUAP(cat020.Version110)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	want := []string{
+		"I020/041", "I020/042", "I020/161", "I020/170", "I020/070", "I020/202", "I020/220",
+		"I020/300", "I020/310", "I020/500", "I020/400", "I020/250", "I020/230", "I020/260",
+		"I020/030", "I020/055", "I020/050", "RE020", "SP020",
+	}
+	if missing := asterix.CheckImplemented(uap); !slices.Equal(missing, want) {
+		t.Errorf("CheckImplemented() = %v, want %v", missing, want)
+	}
+}
+
+func TestUAP020_DecodeRecord(t *testing.T) {
+	uap, err := cat020.NewUAP(cat020.Version110)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	data := []byte{
+		20, 0x00, 0x0E, // CAT, LEN
+		0xE1, 0x20, // FSPEC: 010, 020, 140, 090
+		0x19, 0x0A, // I020/010
+		0x41, 0x00, // I020/020: MS, with a first extension
+		0x00, 0x40, 0x00, // I020/140: 128 s
+		0x05, 0x78, // I020/090: FL 350
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat020, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.Decode(data); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	record := block.Records()[0]
+
+	item, _, _ := record.GetDataItem("I020/010")
+	if dsi, ok := item.(*common.DataSourceIdentifier); !ok || dsi.SAC != 25 || dsi.SIC != 10 {
+		t.Errorf("I020/010 = %v, want SAC 25, SIC 10", item)
+	}
+	item, _, _ = record.GetDataItem("I020/020")
+	if raw, ok := item.(*asterix.RawDataItem); !ok || !bytes.Equal(raw.Data, []byte{0x41, 0x00}) {
+		t.Errorf("I020/020 = %v, want raw octets 41 00", item)
+	}
+	item, _, _ = record.GetDataItem("I020/140")
+	if tod, ok := item.(*common.TimeOfDay); !ok || tod.Time != 128 {
+		t.Errorf("I020/140 = %v, want 128 s", item)
+	}
+	item, _, _ = record.GetDataItem("I020/090")
+	if fl, ok := item.(*v110.FlightLevel); !ok || fl.Level != 350 || !fl.Valid {
+		t.Errorf("I020/090 = %v, want valid FL 350", item)
+	}
+
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(encoded, data) {
+		t.Errorf("Encode() = % X, want % X", encoded, data)
+	}
+}
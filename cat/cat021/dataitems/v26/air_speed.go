@@ -77,6 +77,11 @@ func (a *AirSpeed) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (a *AirSpeed) Reset() {
+	*a = AirSpeed{}
+}
+
 func (a *AirSpeed) String() string {
 	if a.IsMach {
 		return fmt.Sprintf("%.3f Mach", a.Speed)
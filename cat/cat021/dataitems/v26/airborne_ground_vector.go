@@ -0,0 +1,93 @@
+// dataitems/cat021/airborne_ground_vector.go
+package v26
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// AirborneGroundVector implements I021/160
+// Ground speed (LSB 2^-14 NM/s) and track angle (LSB 360/2^16 degrees) of an
+// airborne aircraft
+type AirborneGroundVector struct {
+	RangeExceeded bool    // RE: the ground speed exceeds the defined range
+	GroundSpeed   float64 // Ground speed in NM/s
+	TrackAngle    float64 // Track angle in degrees clockwise from true north
+}
+
+const (
+	groundSpeedLSB = 1.0 / (1 << 14)   // NM/s
+	trackAngleLSB  = 360.0 / (1 << 16) // degrees
+)
+
+func (a *AirborneGroundVector) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 4)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading airborne ground vector: %w", err)
+	}
+	if n != 4 {
+		return n, fmt.Errorf("insufficient data for airborne ground vector: got %d bytes, want 4", n)
+	}
+
+	a.RangeExceeded = data[0]&0x80 != 0
+	a.GroundSpeed = float64(uint16(data[0]&0x7F)<<8|uint16(data[1])) * groundSpeedLSB
+	a.TrackAngle = float64(uint16(data[2])<<8|uint16(data[3])) * trackAngleLSB
+
+	return n, nil
+}
+
+func (a *AirborneGroundVector) Encode(buf *bytes.Buffer) (int, error) {
+	if err := a.Validate(); err != nil {
+		return 0, err
+	}
+
+	speed := uint16(math.Round(a.GroundSpeed / groundSpeedLSB))
+	// Angles rounding up to 360° wrap to north
+	angle := uint16(int(math.Round(a.TrackAngle/trackAngleLSB)) & 0xFFFF)
+
+	data := []byte{byte(speed >> 8), byte(speed), byte(angle >> 8), byte(angle)}
+	if a.RangeExceeded {
+		data[0] |= 0x80
+	}
+
+	n, err := buf.Write(data)
+	if err != nil {
+		return n, fmt.Errorf("writing airborne ground vector: %w", err)
+	}
+	return n, nil
+}
+
+func (a *AirborneGroundVector) Validate() error {
+	if max := 0x7FFF * groundSpeedLSB; a.GroundSpeed < 0 || a.GroundSpeed > max {
+		return fmt.Errorf("ground speed out of valid range [0,%f] NM/s: %f", max, a.GroundSpeed)
+	}
+	if a.TrackAngle < 0 || a.TrackAngle >= 360 {
+		return fmt.Errorf("track angle out of valid range [0,360): %f", a.TrackAngle)
+	}
+	return nil
+}
+
+// Reset implements asterix.Resetter
+func (a *AirborneGroundVector) Reset() {
+	*a = AirborneGroundVector{}
+}
+
+// GroundSpeedKt returns the ground speed in knots
+func (a *AirborneGroundVector) GroundSpeedKt() float64 {
+	return a.GroundSpeed * 3600
+}
+
+// TrackAngleDeg returns the track angle in degrees
+func (a *AirborneGroundVector) TrackAngleDeg() float64 {
+	return a.TrackAngle
+}
+
+func (a *AirborneGroundVector) String() string {
+	s := fmt.Sprintf("GS: %.1f kts, TA: %.2f°", a.GroundSpeedKt(), a.TrackAngle)
+	if a.RangeExceeded {
+		s += " [range exceeded]"
+	}
+	return s
+}
@@ -0,0 +1,70 @@
+// dataitems/cat021/airborne_ground_vector_test.go
+package v26_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+)
+
+func TestAirborneGroundVector_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   v26.AirborneGroundVector
+		raw  []byte
+		kt   float64
+	}{
+		{"450 kt east", v26.AirborneGroundVector{GroundSpeed: 0.125, TrackAngle: 90}, []byte{0x08, 0x00, 0x40, 0x00}, 450},
+		{"range exceeded", v26.AirborneGroundVector{RangeExceeded: true, GroundSpeed: 0x7FFF / 16384.0, TrackAngle: 180},
+			[]byte{0xFF, 0xFF, 0x80, 0x00}, 0x7FFF / 16384.0 * 3600},
+		{"track near 360", v26.AirborneGroundVector{GroundSpeed: 0, TrackAngle: 0xFFFF * 360.0 / 65536},
+			[]byte{0x00, 0x00, 0xFF, 0xFF}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := tt.in.Encode(&buf); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.raw) {
+				t.Fatalf("Encode() = % X, want % X", buf.Bytes(), tt.raw)
+			}
+
+			var out v26.AirborneGroundVector
+			if _, err := out.Decode(&buf); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if out != tt.in {
+				t.Errorf("Decode() = %+v, want %+v", out, tt.in)
+			}
+			if math.Abs(out.GroundSpeedKt()-tt.kt) > 1e-9 || out.TrackAngleDeg() != tt.in.TrackAngle {
+				t.Errorf("GroundSpeedKt(), TrackAngleDeg() = %v, %v, want %v, %v",
+					out.GroundSpeedKt(), out.TrackAngleDeg(), tt.kt, tt.in.TrackAngle)
+			}
+		})
+	}
+}
+
+func TestAirborneGroundVector_Limits(t *testing.T) {
+	// Just below 360° rounds up and wraps to north
+	var buf bytes.Buffer
+	if _, err := (&v26.AirborneGroundVector{TrackAngle: 359.999}).Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{0x00, 0x00, 0x00, 0x00}) {
+		t.Errorf("Encode(359.999°) = % X, want 00 00 00 00", buf.Bytes())
+	}
+
+	for _, in := range []v26.AirborneGroundVector{
+		{TrackAngle: 360},
+		{TrackAngle: -1},
+		{GroundSpeed: 2},
+		{GroundSpeed: -0.1},
+	} {
+		if err := in.Validate(); err == nil {
+			t.Errorf("Validate(%+v) accepted an out of range value", in)
+		}
+	}
+}
@@ -78,6 +78,11 @@ func (a *AircraftOperationalStatus) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (a *AircraftOperationalStatus) Reset() {
+	*a = AircraftOperationalStatus{}
+}
+
 func (a *AircraftOperationalStatus) String() string {
 	var parts []string
 
@@ -84,6 +84,11 @@ func (b *BarometricVerticalRate) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (b *BarometricVerticalRate) Reset() {
+	*b = BarometricVerticalRate{}
+}
+
 func (b *BarometricVerticalRate) String() string {
 	if b.RE {
 		return fmt.Sprintf(">%dft/min", b.Rate)
@@ -79,6 +79,11 @@ func (e *EmitterCategory) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (e *EmitterCategory) Reset() {
+	*e = EmitterCategory{}
+}
+
 // String returns a human-readable representation of the EmitterCategory
 func (e *EmitterCategory) String() string {
 	switch e.ECAT {
@@ -0,0 +1,93 @@
+// dataitems/cat021/high_resolution_position.go
+package v26
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// ResolutionWGS84High is the LSB of I021/131 (180/2^30 degrees)
+const ResolutionWGS84High = 180.0 / (1 << 30)
+
+// HighResPosition implements I021/131
+// Position in WGS-84 co-ordinates in high resolution, two 32-bit two's
+// complement values for latitude and longitude.
+type HighResPosition struct {
+	Latitude  float64 // -90° to +90°
+	Longitude float64 // -180° to +180°
+}
+
+func (p *HighResPosition) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 8)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading high resolution position: %w", err)
+	}
+	if n != 8 {
+		return n, fmt.Errorf("insufficient data for high resolution position: got %d bytes, want 8", n)
+	}
+
+	latRaw := int32(uint32(data[0])<<24 | uint32(data[1])<<16 | uint32(data[2])<<8 | uint32(data[3]))
+	lonRaw := int32(uint32(data[4])<<24 | uint32(data[5])<<16 | uint32(data[6])<<8 | uint32(data[7]))
+
+	p.Latitude = float64(latRaw) * ResolutionWGS84High
+	p.Longitude = float64(lonRaw) * ResolutionWGS84High
+
+	return n, p.Validate()
+}
+
+func (p *HighResPosition) Encode(buf *bytes.Buffer) (int, error) {
+	if err := p.Validate(); err != nil {
+		return 0, err
+	}
+
+	// +180° does not fit in 32 bits; clamp to the largest representable value
+	latRaw := int32(math.Round(p.Latitude / ResolutionWGS84High))
+	lonRaw := int32(math.Max(math.Min(math.Round(p.Longitude/ResolutionWGS84High), math.MaxInt32), math.MinInt32))
+
+	b := []byte{
+		byte(latRaw >> 24), byte(latRaw >> 16), byte(latRaw >> 8), byte(latRaw),
+		byte(lonRaw >> 24), byte(lonRaw >> 16), byte(lonRaw >> 8), byte(lonRaw),
+	}
+	n, err := buf.Write(b)
+	if err != nil {
+		return n, fmt.Errorf("writing high resolution position: %w", err)
+	}
+	return n, nil
+}
+
+func (p *HighResPosition) Validate() error {
+	if p.Latitude < -90 || p.Latitude > 90 {
+		return fmt.Errorf("latitude %f outside valid range [-90,+90]", p.Latitude)
+	}
+	if p.Longitude < -180 || p.Longitude > 180 {
+		return fmt.Errorf("longitude %f outside valid range [-180,+180]", p.Longitude)
+	}
+	return nil
+}
+
+// Reset implements asterix.Resetter
+func (p *HighResPosition) Reset() {
+	*p = HighResPosition{}
+}
+
+func (p *HighResPosition) String() string {
+	return fmt.Sprintf("%.8f°N %.8f°E", p.Latitude, p.Longitude)
+}
+
+// WGS84 implements asterix.PositionProvider
+func (p *HighResPosition) WGS84() (float64, float64) {
+	return p.Latitude, p.Longitude
+}
+
+// GetField implements asterix.FieldGetter for Latitude and Longitude
+func (p *HighResPosition) GetField(name string) (any, bool) {
+	switch name {
+	case "Latitude":
+		return p.Latitude, true
+	case "Longitude":
+		return p.Longitude, true
+	}
+	return nil, false
+}
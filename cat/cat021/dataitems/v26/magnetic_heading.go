@@ -61,6 +61,11 @@ func (m *MagneticHeading) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (m *MagneticHeading) Reset() {
+	*m = MagneticHeading{}
+}
+
 func (m *MagneticHeading) String() string {
 	return fmt.Sprintf("%.2f°", m.Heading)
 }
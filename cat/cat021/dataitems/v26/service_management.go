@@ -0,0 +1,52 @@
+// dataitems/cat021/service_management.go
+package v26
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ServiceManagement implements I021/016
+// Identification of services offered by a ground station, expressed as the
+// reporting period of the service (LSB 0.5 s)
+type ServiceManagement struct {
+	Period float64 // Report period in seconds
+}
+
+func (s *ServiceManagement) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading service management: %w", err)
+	}
+	s.Period = float64(b) * 0.5
+	return 1, nil
+}
+
+func (s *ServiceManagement) Encode(buf *bytes.Buffer) (int, error) {
+	if err := s.Validate(); err != nil {
+		return 0, err
+	}
+
+	if err := buf.WriteByte(uint8(math.Round(s.Period / 0.5))); err != nil {
+		return 0, fmt.Errorf("writing service management: %w", err)
+	}
+	return 1, nil
+}
+
+func (s *ServiceManagement) Validate() error {
+	if s.Period < 0 || s.Period > 127.5 {
+		return fmt.Errorf("report period out of valid range [0,127.5]: %f", s.Period)
+	}
+	return nil
+}
+
+// ReportingPeriod returns the report period of the service
+func (s *ServiceManagement) ReportingPeriod() time.Duration {
+	return time.Duration(math.Round(s.Period * float64(time.Second)))
+}
+
+func (s *ServiceManagement) String() string {
+	return fmt.Sprintf("RP: %.1fs", s.Period)
+}
@@ -0,0 +1,70 @@
+// dataitems/cat021/service_management_test.go
+package v26_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestServiceManagement_RoundTrip(t *testing.T) {
+	tests := []struct {
+		period float64
+		raw    byte
+		want   time.Duration
+	}{
+		{0, 0x00, 0},
+		{0.5, 0x01, 500 * time.Millisecond},
+		{1.5, 0x03, 1500 * time.Millisecond},
+		{127.5, 0xFF, 127500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		in := v26.ServiceManagement{Period: tt.period}
+
+		var buf bytes.Buffer
+		if _, err := in.Encode(&buf); err != nil {
+			t.Fatalf("Encode(%v) error = %v", tt.period, err)
+		}
+		if !bytes.Equal(buf.Bytes(), []byte{tt.raw}) {
+			t.Fatalf("Encode(%v) = % X, want %02X", tt.period, buf.Bytes(), tt.raw)
+		}
+
+		var out v26.ServiceManagement
+		if _, err := out.Decode(&buf); err != nil {
+			t.Fatalf("Decode(%02X) error = %v", tt.raw, err)
+		}
+		if out != in {
+			t.Errorf("Decode(%02X) = %+v, want %+v", tt.raw, out, in)
+		}
+		if got := out.ReportingPeriod(); got != tt.want {
+			t.Errorf("ReportingPeriod() = %v, want %v", got, tt.want)
+		}
+	}
+
+	if err := (&v26.ServiceManagement{Period: 128}).Validate(); err == nil {
+		t.Errorf("Validate() accepted a period of 128 s")
+	}
+}
+
+func TestServiceIdentification_RoundTrip(t *testing.T) {
+	in := common.ServiceIdentification{Value: 7}
+
+	var buf bytes.Buffer
+	if _, err := in.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{0x07}) {
+		t.Fatalf("Encode() = % X, want 07", buf.Bytes())
+	}
+
+	var out common.ServiceIdentification
+	if _, err := out.Decode(&buf); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if out != in {
+		t.Errorf("Decode() = %+v, want %+v", out, in)
+	}
+}
@@ -0,0 +1,146 @@
+// dataitems/cat021/surface_capabilities.go
+package v26
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+// SurfaceCapabilities implements I021/271
+// Operational capabilities of the aircraft while on the ground and, in the
+// first extension, its length and width category
+type SurfaceCapabilities struct {
+	// Primary field
+	POA   bool // Position transmitted is not ADS-B position reference point
+	CDTIS bool // CDTI/S operational
+	B2Low bool // Class B2 transmit power less than 70 Watts
+	RAS   bool // Aircraft receiving ATC services
+	IDENT bool // IDENT switch active
+
+	// First extension
+	LengthWidth *uint8 // Length and width category 0-15, nil when absent
+}
+
+// lengthWidthLimits holds the upper length and width bounds in metres of
+// each L+W category; category 15 is longer than 85 m and wider than 80 m
+var lengthWidthLimits = [16][2]float64{
+	{15, 11.5}, {15, 23}, {25, 28.5}, {25, 34},
+	{35, 33}, {35, 38}, {45, 39.5}, {45, 45},
+	{55, 45}, {55, 52}, {65, 59.5}, {65, 67},
+	{75, 72.5}, {75, 80}, {85, 80}, {85, 80},
+}
+
+func (s *SurfaceCapabilities) Decode(buf *bytes.Buffer) (int, error) {
+	// The first extension carries L+W in bits 4-1, so it never has an FX bit
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading surface capabilities: %w", err)
+	}
+
+	// bits 8-7 are spare
+	s.POA = (b & 0x20) != 0
+	s.CDTIS = (b & 0x10) != 0
+	s.B2Low = (b & 0x08) != 0
+	s.RAS = (b & 0x04) != 0
+	s.IDENT = (b & 0x02) != 0
+	s.LengthWidth = nil
+
+	if b&0x01 == 0 {
+		return 1, nil
+	}
+
+	ext, err := buf.ReadByte()
+	if err != nil {
+		return 1, fmt.Errorf("%w: reading surface capabilities extension: %v", asterix.ErrBufferTooShort, err)
+	}
+	lw := ext & 0x0F // bits 8-5 are spare
+	s.LengthWidth = &lw
+
+	return 2, nil
+}
+
+func (s *SurfaceCapabilities) Encode(buf *bytes.Buffer) (int, error) {
+	if err := s.Validate(); err != nil {
+		return 0, err
+	}
+
+	var b uint8
+	if s.POA {
+		b |= 0x20
+	}
+	if s.CDTIS {
+		b |= 0x10
+	}
+	if s.B2Low {
+		b |= 0x08
+	}
+	if s.RAS {
+		b |= 0x04
+	}
+	if s.IDENT {
+		b |= 0x02
+	}
+	if s.LengthWidth == nil {
+		if err := buf.WriteByte(b); err != nil {
+			return 0, fmt.Errorf("writing surface capabilities: %w", err)
+		}
+		return 1, nil
+	}
+
+	n, err := buf.Write([]byte{b | 0x01, *s.LengthWidth})
+	if err != nil {
+		return n, fmt.Errorf("writing surface capabilities: %w", err)
+	}
+	return n, nil
+}
+
+func (s *SurfaceCapabilities) Validate() error {
+	if s.LengthWidth != nil && *s.LengthWidth > 15 {
+		return fmt.Errorf("invalid length and width category: %d", *s.LengthWidth)
+	}
+	return nil
+}
+
+// Dimensions returns the upper bounds in metres of the aircraft length and
+// width. ok is false when the extension is absent. For category 15 the
+// aircraft exceeds both bounds.
+func (s *SurfaceCapabilities) Dimensions() (length, width float64, ok bool) {
+	if s.LengthWidth == nil || *s.LengthWidth > 15 {
+		return 0, 0, false
+	}
+	limits := lengthWidthLimits[*s.LengthWidth]
+	return limits[0], limits[1], true
+}
+
+func (s *SurfaceCapabilities) String() string {
+	var parts []string
+	if s.POA {
+		parts = append(parts, "POA")
+	}
+	if s.CDTIS {
+		parts = append(parts, "CDTI/S")
+	}
+	if s.B2Low {
+		parts = append(parts, "B2 low")
+	}
+	if s.RAS {
+		parts = append(parts, "RAS")
+	}
+	if s.IDENT {
+		parts = append(parts, "IDENT")
+	}
+	if length, width, ok := s.Dimensions(); ok {
+		if *s.LengthWidth == 15 {
+			parts = append(parts, fmt.Sprintf("L>%gm W>%gm", length, width))
+		} else {
+			parts = append(parts, fmt.Sprintf("L<%gm W<%gm", length, width))
+		}
+	}
+	if len(parts) == 0 {
+		return "None"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,71 @@
+// dataitems/cat021/surface_capabilities_test.go
+package v26_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+)
+
+func TestSurfaceCapabilities_RoundTrip(t *testing.T) {
+	lw := func(v uint8) *uint8 { return &v }
+	tests := []struct {
+		name string
+		item v26.SurfaceCapabilities
+		raw  []byte
+		str  string
+	}{
+		{"none", v26.SurfaceCapabilities{}, []byte{0x00}, "None"},
+		{
+			name: "base octet",
+			item: v26.SurfaceCapabilities{POA: true, CDTIS: true, B2Low: true, RAS: true, IDENT: true},
+			raw:  []byte{0x3E},
+			str:  "POA, CDTI/S, B2 low, RAS, IDENT",
+		},
+		{
+			name: "dimensions",
+			item: v26.SurfaceCapabilities{RAS: true, LengthWidth: lw(7)},
+			raw:  []byte{0x05, 0x07},
+			str:  "RAS, L<45m W<45m",
+		},
+		{
+			name: "largest category",
+			item: v26.SurfaceCapabilities{LengthWidth: lw(15)},
+			raw:  []byte{0x01, 0x0F},
+			str:  "L>85m W>80m",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := tt.item.Encode(&buf)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if n != len(tt.raw) || !bytes.Equal(buf.Bytes(), tt.raw) {
+				t.Fatalf("Encode() = % X, want % X", buf.Bytes(), tt.raw)
+			}
+
+			var out v26.SurfaceCapabilities
+			if n, err := out.Decode(&buf); err != nil || n != len(tt.raw) {
+				t.Fatalf("Decode() = %d, %v, want %d bytes", n, err, len(tt.raw))
+			}
+			if !reflect.DeepEqual(out, tt.item) {
+				t.Errorf("Decode() = %+v, want %+v", out, tt.item)
+			}
+			if out.String() != tt.str {
+				t.Errorf("String() = %q, want %q", out.String(), tt.str)
+			}
+		})
+	}
+
+	if err := (&v26.SurfaceCapabilities{LengthWidth: lw(16)}).Validate(); err == nil {
+		t.Errorf("Validate() accepted length and width category 16")
+	}
+	if _, err := new(v26.SurfaceCapabilities).Decode(bytes.NewBuffer([]byte{0x01})); err == nil {
+		t.Errorf("Decode() accepted a truncated extension")
+	}
+}
@@ -52,6 +52,11 @@ func (t *TargetAddress) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TargetAddress) Reset() {
+	*t = TargetAddress{}
+}
+
 // String returns the ICAO address in hex format
 func (t *TargetAddress) String() string {
 	return fmt.Sprintf("%06X", t.Address)
@@ -67,3 +72,11 @@ func (t *TargetAddress) FromString(s string) error {
 	t.Address = addr
 	return t.Validate()
 }
+
+// GetField implements asterix.FieldGetter for Address
+func (t *TargetAddress) GetField(name string) (any, bool) {
+	if name == "Address" {
+		return t.Address, true
+	}
+	return nil, false
+}
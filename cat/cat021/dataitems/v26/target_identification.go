@@ -4,6 +4,9 @@ package v26
 import (
 	"bytes"
 	"fmt"
+	"strings"
+
+	"github.com/davidkohl/gobelix/asterix/ident"
 )
 
 // TargetIdentification implements I021/170
@@ -11,11 +14,6 @@ type TargetIdentification struct {
 	Ident string
 }
 
-// sixBitToASCII implements the ICAO Annex 10 Vol IV character set mapping
-// Each 6-bit code maps to a character in this 64-character array
-// '#' represents undefined/reserved codes that should not appear in valid data
-var sixBitToASCII = []byte("#ABCDEFGHIJKLMNOPQRSTUVWXYZ##### ###############0123456789######")
-
 func (t *TargetIdentification) Decode(buf *bytes.Buffer) (int, error) {
 	data := make([]byte, 6)
 	n, err := buf.Read(data)
@@ -26,35 +24,11 @@ func (t *TargetIdentification) Decode(buf *bytes.Buffer) (int, error) {
 		return n, fmt.Errorf("insufficient data for target identification: got %d bytes, want 6", n)
 	}
 
-	var chars [8]byte
-
-	// First character set - bytes 0,1,2
-	chars[0] = (data[0] & 0xFC) >> 2
-	chars[1] = ((data[0] & 0x03) << 4) | ((data[1] & 0xF0) >> 4)
-	chars[2] = ((data[1] & 0x0F) << 2) | ((data[2] & 0xC0) >> 6)
-	chars[3] = data[2] & 0x3F
-
-	// Second character set - bytes 3,4,5
-	chars[4] = (data[3] & 0xFC) >> 2
-	chars[5] = ((data[3] & 0x03) << 4) | ((data[4] & 0xF0) >> 4)
-	chars[6] = ((data[4] & 0x0F) << 2) | ((data[5] & 0xC0) >> 6)
-	chars[7] = data[5] & 0x3F
-
-	// Convert to ASCII and validate
-	result := make([]byte, 8)
-	for i, code := range chars {
-		if int(code) >= len(sixBitToASCII) {
-			return n, fmt.Errorf("invalid character code %d at position %d (raw bytes: %X)", code, i, data)
-		}
-		ch := sixBitToASCII[code]
-		if ch == '#' {
-			return n, fmt.Errorf("invalid/reserved character code %d at position %d (raw bytes: %X)", code, i, data)
-		}
-		result[i] = ch
+	callsign, err := ident.Decode(data)
+	if err != nil {
+		return n, fmt.Errorf("decoding target identification: %w", err)
 	}
-
-	// Remove any trailing spaces
-	t.Ident = string(bytes.TrimRight(result[:], " "))
+	t.Ident = callsign
 	return n, nil
 }
 
@@ -63,33 +37,12 @@ func (t *TargetIdentification) Encode(buf *bytes.Buffer) (int, error) {
 		return 0, err
 	}
 
-	// Pad to 8 characters with spaces
-	ident := fmt.Sprintf("%-8s", t.Ident)
-
-	// Convert ASCII to 6-bit
-	var chars [8]byte
-	for i := 0; i < 8; i++ {
-		idx := bytes.IndexByte(sixBitToASCII, ident[i])
-		if idx < 0 {
-			return 0, fmt.Errorf("invalid character '%c' at position %d", ident[i], i)
-		}
-		chars[i] = byte(idx)
+	output, err := ident.Encode(t.Ident)
+	if err != nil {
+		return 0, err
 	}
 
-	// Pack into 6 bytes
-	output := make([]byte, 6)
-
-	// First group
-	output[0] = (chars[0] << 2) | (chars[1] >> 4)
-	output[1] = (chars[1] << 4) | (chars[2] >> 2)
-	output[2] = (chars[2] << 6) | chars[3]
-
-	// Second group
-	output[3] = (chars[4] << 2) | (chars[5] >> 4)
-	output[4] = (chars[5] << 4) | (chars[6] >> 2)
-	output[5] = (chars[6] << 6) | chars[7]
-
-	n, err := buf.Write(output)
+	n, err := buf.Write(output[:])
 	if err != nil {
 		return n, fmt.Errorf("writing target identification: %w", err)
 	}
@@ -97,15 +50,29 @@ func (t *TargetIdentification) Encode(buf *bytes.Buffer) (int, error) {
 }
 
 func (t *TargetIdentification) Validate() error {
-	if len(t.Ident) > 8 {
-		return fmt.Errorf("ident too long: max 8 characters")
-	}
+	return ident.Validate(t.Ident)
+}
+
+// Reset implements asterix.Resetter
+func (t *TargetIdentification) Reset() {
+	*t = TargetIdentification{}
+}
+
+// Callsign returns the aircraft identification without trailing spaces
+func (t *TargetIdentification) Callsign() string {
+	return t.Ident
+}
 
-	for i, c := range t.Ident {
-		if bytes.IndexByte(sixBitToASCII, byte(c)) < 0 {
-			return fmt.Errorf("invalid character '%c' at position %d", c, i)
-		}
+// SetCallsign sets the aircraft identification. Trailing spaces are dropped,
+// as they are padding on the wire; the remaining characters must be upper
+// case letters, digits or spaces and fit in 8 characters. On error the
+// current identification is left unchanged.
+func (t *TargetIdentification) SetCallsign(s string) error {
+	next := TargetIdentification{Ident: strings.TrimRight(s, " ")}
+	if err := next.Validate(); err != nil {
+		return err
 	}
+	t.Ident = next.Ident
 	return nil
 }
 
@@ -0,0 +1,48 @@
+// dataitems/cat021/target_identification_test.go
+package v26_test
+
+import (
+	"bytes"
+	"testing"
+
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+)
+
+func TestTargetIdentification_CallsignRoundTrip(t *testing.T) {
+	var ti v26.TargetIdentification
+	if err := ti.SetCallsign("DLH4AB  "); err != nil {
+		t.Fatalf("SetCallsign() error = %v", err)
+	}
+	if got := ti.Callsign(); got != "DLH4AB" {
+		t.Errorf("Callsign() = %q, want %q", got, "DLH4AB")
+	}
+
+	var buf bytes.Buffer
+	if _, err := ti.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := []byte{0x10, 0xC2, 0x34, 0x04, 0x28, 0x20}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("Encode() = % X, want % X", buf.Bytes(), want)
+	}
+
+	var decoded v26.TargetIdentification
+	if _, err := decoded.Decode(&buf); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := decoded.Callsign(); got != "DLH4AB" {
+		t.Errorf("decoded Callsign() = %q, want %q", got, "DLH4AB")
+	}
+}
+
+func TestTargetIdentification_SetCallsignRejectsInvalid(t *testing.T) {
+	for _, s := range []string{"dlh4ab", "DLH!4AB", "DLH#4AB", "DLHÄ", "DLH4ABCDE"} {
+		ti := v26.TargetIdentification{Ident: "KLM12"}
+		if err := ti.SetCallsign(s); err == nil {
+			t.Errorf("SetCallsign(%q) error = nil, want error", s)
+		}
+		if ti.Callsign() != "KLM12" {
+			t.Errorf("SetCallsign(%q) changed callsign to %q", s, ti.Callsign())
+		}
+	}
+}
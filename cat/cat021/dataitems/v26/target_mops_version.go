@@ -43,11 +43,16 @@ const (
 // Link Technology Types
 const (
 	LTTOther  uint8 = iota // Other
-	LTTVAT                 // UAT
+	LTTUAT                 // Universal Access Transceiver
 	LTT1090ES              // 1090 Extended Squitter
 	LTTVDL4                // VDL Mode 4
 )
 
+// LTTVAT is the original, misspelt name of LTTUAT.
+//
+// Deprecated: use LTTUAT.
+const LTTVAT = LTTUAT
+
 // Decode reads the MOPSVersion data from the buffer
 func (m *MOPSVersion) Decode(buf *bytes.Buffer) (int, error) {
 	data := make([]byte, 1)
@@ -105,27 +110,34 @@ func (m *MOPSVersion) Validate() error {
 	return nil
 }
 
-// String returns a human-readable representation of the MOPSVersion
+// Reset implements asterix.Resetter
+func (m *MOPSVersion) Reset() {
+	*m = MOPSVersion{}
+}
+
+// String returns a human-readable representation of the MOPSVersion. The
+// version numbers are only named for 1090 ES, the one link technology for
+// which the specification defines them.
 func (m *MOPSVersion) String() string {
-	var ver string
-	switch m.VN {
-	case VN_ED102_DO260:
-		ver = "ED102/DO-260"
-	case VN_DO260A:
-		ver = "DO-260A"
-	case VN_ED102A_DO260B:
-		ver = "ED102A/DO-260B"
-	case VN_ED102B_DO260C:
-		ver = "ED102B/DO-260C"
-	default:
-		ver = fmt.Sprintf("Unknown(%d)", m.VN)
+	ver := fmt.Sprintf("VN%d", m.VN)
+	if m.LTT == LTT1090ES {
+		switch m.VN {
+		case VN_ED102_DO260:
+			ver = "ED102/DO-260"
+		case VN_DO260A:
+			ver = "DO-260A"
+		case VN_ED102A_DO260B:
+			ver = "ED102A/DO-260B"
+		case VN_ED102B_DO260C:
+			ver = "ED102B/DO-260C"
+		}
 	}
 
 	link := "Unknown"
 	switch m.LTT {
 	case LTTOther:
 		link = "Other"
-	case LTTVAT:
+	case LTTUAT:
 		link = "UAT"
 	case LTT1090ES:
 		link = "1090ES"
@@ -0,0 +1,61 @@
+// dataitems/cat021/mops_version_test.go
+package v26_test
+
+import (
+	"bytes"
+	"testing"
+
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+)
+
+func TestMOPSVersion_RoundTrip(t *testing.T) {
+	for _, ltt := range []uint8{v26.LTTOther, v26.LTTUAT, v26.LTT1090ES, v26.LTTVDL4} {
+		for vn := v26.VN_ED102_DO260; vn <= v26.VN_ED102B_DO260C; vn++ {
+			for _, vns := range []bool{false, true} {
+				in := v26.MOPSVersion{VNS: vns, VN: vn, LTT: ltt}
+				want := vn<<3 | ltt
+				if vns {
+					want |= 0x40
+				}
+
+				var buf bytes.Buffer
+				if _, err := in.Encode(&buf); err != nil {
+					t.Fatalf("Encode(%+v) error = %v", in, err)
+				}
+				if !bytes.Equal(buf.Bytes(), []byte{want}) {
+					t.Fatalf("Encode(%+v) = % X, want %02X", in, buf.Bytes(), want)
+				}
+
+				var out v26.MOPSVersion
+				if _, err := out.Decode(&buf); err != nil {
+					t.Fatalf("Decode(%02X) error = %v", want, err)
+				}
+				if out != in {
+					t.Errorf("Decode(%02X) = %+v, want %+v", want, out, in)
+				}
+			}
+		}
+	}
+}
+
+func TestMOPSVersion_String(t *testing.T) {
+	tests := []struct {
+		item v26.MOPSVersion
+		want string
+	}{
+		{v26.MOPSVersion{VN: v26.VN_ED102A_DO260B, LTT: v26.LTT1090ES}, "ED102A/DO-260B/1090ES"},
+		{v26.MOPSVersion{VNS: true, VN: v26.VN_DO260A, LTT: v26.LTT1090ES}, "DO-260A[!]/1090ES"},
+		{v26.MOPSVersion{VN: 2, LTT: v26.LTTUAT}, "VN2/UAT"},
+		{v26.MOPSVersion{LTT: v26.LTTOther}, "VN0/Other"},
+	}
+	for _, tt := range tests {
+		if got := tt.item.String(); got != tt.want {
+			t.Errorf("String(%+v) = %q, want %q", tt.item, got, tt.want)
+		}
+	}
+
+	// Unassigned link technology types are rejected
+	if err := (&v26.MOPSVersion{LTT: 5}).Validate(); err == nil {
+		t.Error("Validate() accepted LTT 5")
+	}
+}
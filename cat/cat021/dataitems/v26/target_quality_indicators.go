@@ -198,6 +198,11 @@ func (q *QualityIndicators) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (q *QualityIndicators) Reset() {
+	*q = QualityIndicators{}
+}
+
 func (q *QualityIndicators) String() string {
 	var details []string
 
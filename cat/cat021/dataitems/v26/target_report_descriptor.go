@@ -270,6 +270,11 @@ func (t *TargetReportDescriptor) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TargetReportDescriptor) Reset() {
+	*t = TargetReportDescriptor{}
+}
+
 func (t *TargetReportDescriptor) String() string {
 	var details []string
 
@@ -325,3 +330,13 @@ func (t *TargetReportDescriptor) String() string {
 
 	return strings.Join(details, ", ")
 }
+
+// OnGround implements asterix.GroundStatusProvider. The ground bit lives in
+// the first extension, so the status is only known when that extension was
+// present.
+func (t *TargetReportDescriptor) OnGround() (bool, bool) {
+	if t.hasExtensions == 0 {
+		return false, false
+	}
+	return t.GBS, true
+}
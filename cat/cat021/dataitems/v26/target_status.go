@@ -90,6 +90,11 @@ func (t *TargetStatus) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TargetStatus) Reset() {
+	*t = TargetStatus{}
+}
+
 func (t *TargetStatus) String() string {
 	var parts []string
 
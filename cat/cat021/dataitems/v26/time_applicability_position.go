@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"time"
 )
 
 // TimeOfApplicabilityPosition implements I021/071
@@ -56,6 +57,16 @@ func (t *TimeOfApplicabilityPosition) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TimeOfApplicabilityPosition) Reset() {
+	*t = TimeOfApplicabilityPosition{}
+}
+
 func (t *TimeOfApplicabilityPosition) String() string {
 	return fmt.Sprintf("Seconds since midnight %v", t.Time)
 }
+
+// Duration implements asterix.TimedRecord
+func (t *TimeOfApplicabilityPosition) Duration() time.Duration {
+	return time.Duration(math.Round(t.Time * float64(time.Second)))
+}
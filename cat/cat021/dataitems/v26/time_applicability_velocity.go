@@ -55,3 +55,8 @@ func (t *TimeOfApplicabilityVelocity) Validate() error {
 	}
 	return nil
 }
+
+// Reset implements asterix.Resetter
+func (t *TimeOfApplicabilityVelocity) Reset() {
+	*t = TimeOfApplicabilityVelocity{}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"time"
 )
 
 // TimeOfMessageReceptionPosition implements I021/073
@@ -56,6 +57,16 @@ func (t *TimeOfMessageReceptionPosition) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TimeOfMessageReceptionPosition) Reset() {
+	*t = TimeOfMessageReceptionPosition{}
+}
+
 func (t *TimeOfMessageReceptionPosition) String() string {
 	return fmt.Sprintf("Seconds since midnight %v", t.Time)
 }
+
+// Duration implements asterix.TimedRecord
+func (t *TimeOfMessageReceptionPosition) Duration() time.Duration {
+	return time.Duration(math.Round(t.Time * float64(time.Second)))
+}
@@ -71,6 +71,11 @@ func (t *TimeOfMessageReceptionPositionHigh) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TimeOfMessageReceptionPositionHigh) Reset() {
+	*t = TimeOfMessageReceptionPositionHigh{}
+}
+
 func (t *TimeOfMessageReceptionPositionHigh) String() string {
 	return fmt.Sprintf("FSI: %v - Fraction: %v", t.FSI, t.FractionalTime)
 }
@@ -60,3 +60,8 @@ func (t *TimeOfMessageReceptionVelocityHigh) Validate() error {
 	}
 	return nil
 }
+
+// Reset implements asterix.Resetter
+func (t *TimeOfMessageReceptionVelocityHigh) Reset() {
+	*t = TimeOfMessageReceptionVelocityHigh{}
+}
@@ -55,3 +55,8 @@ func (t *TimeOfMessageReceptionVelocity) Validate() error {
 	}
 	return nil
 }
+
+// Reset implements asterix.Resetter
+func (t *TimeOfMessageReceptionVelocity) Reset() {
+	*t = TimeOfMessageReceptionVelocity{}
+}
@@ -2,56 +2,17 @@
 package v26
 
 import (
-	"bytes"
-	"fmt"
-	"math"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
 )
 
 // TimeOfReportTransmission implements I021/077
 // Time of the transmission of the ASTERIX category 021 report in the form
 // of elapsed time since last midnight, expressed as UTC.
 type TimeOfReportTransmission struct {
-	Time float64 // Time in seconds since midnight
+	common.TimeOfDay
 }
 
-func (t *TimeOfReportTransmission) Decode(buf *bytes.Buffer) (int, error) {
-	data := make([]byte, 3)
-	n, err := buf.Read(data)
-	if err != nil {
-		return n, fmt.Errorf("reading time of report transmission: %w", err)
-	}
-	if n != 3 {
-		return n, fmt.Errorf("insufficient data: got %d bytes, want 3", n)
-	}
-
-	counts := uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2])
-	t.Time = float64(counts) / 128.0 // LSB = 1/128 seconds
-
-	return n, t.Validate()
-}
-
-func (t *TimeOfReportTransmission) Encode(buf *bytes.Buffer) (int, error) {
-	if err := t.Validate(); err != nil {
-		return 0, err
-	}
-
-	counts := uint32(math.Round(t.Time * 128.0))
-
-	b := make([]byte, 3)
-	b[0] = byte(counts >> 16)
-	b[1] = byte(counts >> 8)
-	b[2] = byte(counts)
-
-	n, err := buf.Write(b)
-	if err != nil {
-		return n, fmt.Errorf("writing time of report transmission: %w", err)
-	}
-	return n, nil
-}
-
-func (t *TimeOfReportTransmission) Validate() error {
-	if t.Time < 0 || t.Time >= 86400 {
-		return fmt.Errorf("time out of valid range [0,86400): %f", t.Time)
-	}
-	return nil
+// Reset implements asterix.Resetter
+func (t *TimeOfReportTransmission) Reset() {
+	*t = TimeOfReportTransmission{}
 }
@@ -0,0 +1,65 @@
+// dataitems/cat021/track_number.go
+package v26
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// TrackNumber implements I021/161
+// An integer value representing a unique reference to a track record within
+// a particular track file. Only the 12 least significant bits are used, so
+// the number wraps after 4095.
+type TrackNumber struct {
+	Value uint16 // Track number (0-4095)
+}
+
+func (t *TrackNumber) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading track number: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for track number: got %d bytes, want 2", n)
+	}
+
+	// Bits 16-13 are spare
+	t.Value = (uint16(data[0])<<8 | uint16(data[1])) & 0x0FFF
+
+	return n, nil
+}
+
+func (t *TrackNumber) Encode(buf *bytes.Buffer) (int, error) {
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+
+	b := []byte{byte(t.Value >> 8), byte(t.Value)}
+	n, err := buf.Write(b)
+	if err != nil {
+		return n, fmt.Errorf("writing track number: %w", err)
+	}
+	return n, nil
+}
+
+func (t *TrackNumber) Validate() error {
+	if t.Value > 0x0FFF {
+		return fmt.Errorf("track number exceeds maximum value (4095): %d", t.Value)
+	}
+	return nil
+}
+
+// Reset implements asterix.Resetter
+func (t *TrackNumber) Reset() {
+	*t = TrackNumber{}
+}
+
+func (t *TrackNumber) String() string {
+	return fmt.Sprintf("%d", t.Value)
+}
+
+// TrackNumber implements asterix.TrackNumberProvider
+func (t *TrackNumber) TrackNumber() uint16 {
+	return t.Value
+}
@@ -36,6 +36,8 @@ func (u *UAP26) CreateDataItem(id string) (asterix.DataItem, error) {
 		return &common.DataSourceIdentifier{}, nil
 	case "I021/015":
 		return &common.ServiceIdentification{}, nil
+	case "I021/016":
+		return &v26.ServiceManagement{}, nil
 	case "I021/020":
 		return &v26.EmitterCategory{}, nil
 	case "I021/040":
@@ -46,10 +48,16 @@ func (u *UAP26) CreateDataItem(id string) (asterix.DataItem, error) {
 		return &v26.QualityIndicators{}, nil
 	case "I021/130":
 		return &common.Position{}, nil
+	case "I021/131":
+		return &v26.HighResPosition{}, nil
 	case "I021/145":
 		return &common.FlightLevel{}, nil
 	case "I021/152":
 		return &v26.MagneticHeading{}, nil
+	case "I021/160":
+		return &v26.AirborneGroundVector{}, nil
+	case "I021/161":
+		return &v26.TrackNumber{}, nil
 	case "I021/170":
 		return &v26.TargetIdentification{}, nil
 	case "I021/071":
@@ -70,6 +78,8 @@ func (u *UAP26) CreateDataItem(id string) (asterix.DataItem, error) {
 		return &v26.TargetStatus{}, nil
 	case "I021/210":
 		return &v26.MOPSVersion{}, nil
+	case "I021/271":
+		return &v26.SurfaceCapabilities{}, nil
 	case "I021/295":
 		return &v26.DataAges{}, nil
 	case "I021/155":
@@ -81,6 +91,25 @@ func (u *UAP26) CreateDataItem(id string) (asterix.DataItem, error) {
 	}
 }
 
+// PositionItems implements asterix.PositionSelector. Surface movement
+// relies on the high resolution position, so it is preferred for targets on
+// the ground; airborne targets use the nominal I021/130 report and fall back
+// to I021/131.
+func (u *UAP26) PositionItems(onGround bool) []string {
+	if onGround {
+		return []string{"I021/131", "I021/130"}
+	}
+	return []string{"I021/130", "I021/131"}
+}
+
+// TimestampItems implements asterix.TimestampSelector. The time of
+// applicability of the position describes the target state best, followed
+// by the time the position was received; the time of report transmission
+// is only a fallback, as it includes the ground station's processing delay.
+func (u *UAP26) TimestampItems() []string {
+	return []string{"I021/071", "I021/073", "I021/077"}
+}
+
 // Validate implements critical validations for Cat021
 // Note: For high-frequency decode operations, consider if all validations are needed
 func (u *UAP26) Validate(items map[string]asterix.DataItem) error {
@@ -125,6 +154,14 @@ var cat021Fields = []asterix.DataField{
 		Length:      1,
 		Mandatory:   true,
 	},
+	{
+		FRN:         3,
+		DataItem:    "I021/161",
+		Description: "Track Number",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
 	{
 		FRN:         4,
 		DataItem:    "I021/015",
@@ -0,0 +1,49 @@
+// cat/cat021/uap/uap_v26_test.go
+package uap_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat021"
+	"github.com/davidkohl/gobelix/cat/cat048"
+)
+
+func TestUAP26_CheckImplemented(t *testing.T) {
+	uap, err := cat021.NewUAP(cat021.Version26)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	missing := asterix.CheckImplemented(uap)
+	for _, id := range []string{"I021/070", "I021/157", "I021/250", "I021/400"} {
+		if !slices.Contains(missing, id) {
+			t.Errorf("CheckImplemented() = %v, missing %s", missing, id)
+		}
+	}
+	for _, id := range []string{"I021/010", "I021/015", "I021/016", "I021/040", "I021/271", "I021/130", "I021/131", "I021/160", "I021/161"} {
+		if slices.Contains(missing, id) {
+			t.Errorf("CheckImplemented() reports implemented item %s", id)
+		}
+	}
+
+	// The report must agree with the decoder: every listed item fails to be
+	// created, and every other item in the UAP succeeds
+	for _, field := range uap.Fields() {
+		_, err := uap.CreateDataItem(field.DataItem)
+		if listed := slices.Contains(missing, field.DataItem); listed != (err != nil) {
+			t.Errorf("%s: listed = %v, CreateDataItem error = %v", field.DataItem, listed, err)
+		}
+	}
+}
+
+func TestCheckImplemented_Complete(t *testing.T) {
+	uap, err := cat048.NewUAP(cat048.Version132)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	if missing := asterix.CheckImplemented(uap); len(missing) != 0 {
+		t.Errorf("CheckImplemented() = %v, want none", missing)
+	}
+}
@@ -19,7 +19,7 @@ func NewUAP(version string) (asterix.UAP, error) {
 	case Version26:
 		return uap.NewUAP26()
 	default:
-		return nil, fmt.Errorf("unsupported CAT062 version: %s", version)
+		return nil, fmt.Errorf("%w: CAT021 %s", asterix.ErrUnsupportedVersion, version)
 	}
 }
 
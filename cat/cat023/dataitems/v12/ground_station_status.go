@@ -0,0 +1,130 @@
+// cat/cat023/dataitems/v12/ground_station_status.go
+package v12
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+// GroundStationStatus implements I023/100
+// Information concerning the status of a ground station and, in the first
+// extension, how often the status is reported
+type GroundStationStatus struct {
+	// Primary field
+	NOGO bool // Data is not released for operational use
+	ODP  bool // Overload in the data processing
+	OXT  bool // Overload in the transfer of data
+	MSC  bool // Monitoring system disconnected
+	TSV  bool // Time source invalid
+	SPO  bool // Potential spoofing attack detected
+	RN   bool // Track numbering has restarted
+
+	// First extension
+	GSSP *uint8 // Ground station status reporting period in seconds, 0-127, nil when absent
+}
+
+func (g *GroundStationStatus) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading ground station status: %w", err)
+	}
+
+	g.NOGO = (b & 0x80) != 0
+	g.ODP = (b & 0x40) != 0
+	g.OXT = (b & 0x20) != 0
+	g.MSC = (b & 0x10) != 0
+	g.TSV = (b & 0x08) != 0
+	g.SPO = (b & 0x04) != 0
+	g.RN = (b & 0x02) != 0
+	g.GSSP = nil
+
+	if b&0x01 == 0 {
+		return 1, nil
+	}
+
+	ext, err := buf.ReadByte()
+	if err != nil {
+		return 1, fmt.Errorf("%w: reading ground station status extension: %v", asterix.ErrBufferTooShort, err)
+	}
+	if ext&0x01 != 0 {
+		return 2, fmt.Errorf("%w: ground station status: FX bit set in first extension, but no further extension is defined",
+			asterix.ErrCorruptData)
+	}
+	gssp := ext >> 1
+	g.GSSP = &gssp
+
+	return 2, nil
+}
+
+func (g *GroundStationStatus) Encode(buf *bytes.Buffer) (int, error) {
+	if err := g.Validate(); err != nil {
+		return 0, err
+	}
+
+	var b uint8
+	if g.NOGO {
+		b |= 0x80
+	}
+	if g.ODP {
+		b |= 0x40
+	}
+	if g.OXT {
+		b |= 0x20
+	}
+	if g.MSC {
+		b |= 0x10
+	}
+	if g.TSV {
+		b |= 0x08
+	}
+	if g.SPO {
+		b |= 0x04
+	}
+	if g.RN {
+		b |= 0x02
+	}
+	if g.GSSP == nil {
+		if err := buf.WriteByte(b); err != nil {
+			return 0, fmt.Errorf("writing ground station status: %w", err)
+		}
+		return 1, nil
+	}
+
+	n, err := buf.Write([]byte{b | 0x01, *g.GSSP << 1})
+	if err != nil {
+		return n, fmt.Errorf("writing ground station status: %w", err)
+	}
+	return n, nil
+}
+
+func (g *GroundStationStatus) Validate() error {
+	if g.GSSP != nil && *g.GSSP > 127 {
+		return fmt.Errorf("invalid ground station status reporting period: %d s", *g.GSSP)
+	}
+	return nil
+}
+
+func (g *GroundStationStatus) String() string {
+	var parts []string
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"NOGO", g.NOGO}, {"ODP", g.ODP}, {"OXT", g.OXT}, {"MSC", g.MSC},
+		{"TSV", g.TSV}, {"SPO", g.SPO}, {"RN", g.RN},
+	} {
+		if f.set {
+			parts = append(parts, f.name)
+		}
+	}
+	if g.GSSP != nil {
+		parts = append(parts, fmt.Sprintf("GSSP %ds", *g.GSSP))
+	}
+	if len(parts) == 0 {
+		return "Operational"
+	}
+	return strings.Join(parts, ", ")
+}
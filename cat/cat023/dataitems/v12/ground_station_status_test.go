@@ -0,0 +1,42 @@
+// cat/cat023/dataitems/v12/ground_station_status_test.go
+package v12_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v12 "github.com/davidkohl/gobelix/cat/cat023/dataitems/v12"
+)
+
+func TestGroundStationStatus(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v12.GroundStationStatus{} }, [][]byte{
+		{0x00},
+		{0xFE},
+		{0x81, 0xFE},
+	})
+
+	var g v12.GroundStationStatus
+	if _, err := g.Decode(bytes.NewBuffer([]byte{0x85, 0x14})); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !g.NOGO || !g.SPO || g.GSSP == nil || *g.GSSP != 10 {
+		t.Errorf("Decode() = %+v", g)
+	}
+	if got := g.String(); got != "NOGO, SPO, GSSP 10s" {
+		t.Errorf("String() = %q", got)
+	}
+
+	if _, err := new(v12.GroundStationStatus).Decode(bytes.NewBuffer([]byte{0x01, 0x03})); !errors.Is(err, asterix.ErrCorruptData) {
+		t.Errorf("Decode() with a second extension error = %v, want %v", err, asterix.ErrCorruptData)
+	}
+}
+
+func TestServiceTypeAndIdentification(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v12.ServiceTypeAndIdentification{} }, [][]byte{{0x12}, {0xF9}})
+	if got := (&v12.ServiceTypeAndIdentification{SID: 3, STYP: v12.ServiceTypeMLT}).String(); got != "SID: 3, MLT" {
+		t.Errorf("String() = %q", got)
+	}
+}
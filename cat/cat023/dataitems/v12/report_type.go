@@ -0,0 +1,62 @@
+// cat/cat023/dataitems/v12/report_type.go
+package v12
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Report types defined for I023/000
+const (
+	ReportTypeGroundStationStatus uint8 = 1 // Ground station status report
+	ReportTypeServiceStatus       uint8 = 2 // Service status report
+	ReportTypeServiceStatistics   uint8 = 3 // Service statistics report
+)
+
+var reportTypeNames = map[uint8]string{
+	ReportTypeGroundStationStatus: "Ground Station Status",
+	ReportTypeServiceStatus:       "Service Status",
+	ReportTypeServiceStatistics:   "Service Statistics",
+}
+
+// ReportType implements I023/000
+// This Data Item allows for a more convenient handling of the reports at
+// the receiver side by further defining the type of transaction.
+type ReportType struct {
+	Value uint8
+}
+
+func (r *ReportType) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading report type: %w", err)
+	}
+	r.Value = b
+
+	return 1, r.Validate()
+}
+
+func (r *ReportType) Encode(buf *bytes.Buffer) (int, error) {
+	if err := r.Validate(); err != nil {
+		return 0, err
+	}
+
+	if err := buf.WriteByte(r.Value); err != nil {
+		return 0, fmt.Errorf("writing report type: %w", err)
+	}
+	return 1, nil
+}
+
+func (r *ReportType) Validate() error {
+	if _, ok := reportTypeNames[r.Value]; !ok {
+		return fmt.Errorf("invalid report type: %d", r.Value)
+	}
+	return nil
+}
+
+func (r *ReportType) String() string {
+	if name, ok := reportTypeNames[r.Value]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", r.Value)
+}
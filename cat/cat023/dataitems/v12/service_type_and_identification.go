@@ -0,0 +1,79 @@
+// cat/cat023/dataitems/v12/service_type_and_identification.go
+package v12
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Service types defined for the STYP field of I023/015
+const (
+	ServiceTypeADSBVDL4 uint8 = 1 // ADS-B VDL4
+	ServiceTypeADSBES   uint8 = 2 // ADS-B Ext Squitter
+	ServiceTypeADSBUAT  uint8 = 3 // ADS-B UAT
+	ServiceTypeTISBVDL4 uint8 = 4 // TIS-B VDL4
+	ServiceTypeTISBES   uint8 = 5 // TIS-B Ext Squitter
+	ServiceTypeTISBUAT  uint8 = 6 // TIS-B UAT
+	ServiceTypeFISBVDL4 uint8 = 7 // FIS-B VDL4
+	ServiceTypeGRASVDL4 uint8 = 8 // GRAS VDL4
+	ServiceTypeMLT      uint8 = 9 // MLT
+)
+
+var serviceTypeNames = map[uint8]string{
+	ServiceTypeADSBVDL4: "ADS-B VDL4",
+	ServiceTypeADSBES:   "ADS-B Ext Squitter",
+	ServiceTypeADSBUAT:  "ADS-B UAT",
+	ServiceTypeTISBVDL4: "TIS-B VDL4",
+	ServiceTypeTISBES:   "TIS-B Ext Squitter",
+	ServiceTypeTISBUAT:  "TIS-B UAT",
+	ServiceTypeFISBVDL4: "FIS-B VDL4",
+	ServiceTypeGRASVDL4: "GRAS VDL4",
+	ServiceTypeMLT:      "MLT",
+}
+
+// ServiceTypeAndIdentification implements I023/015
+// Identifies the type of service being reported and the service itself
+type ServiceTypeAndIdentification struct {
+	SID  uint8 // Service identification, 0 to 15, allocated by the system
+	STYP uint8 // Type of service
+}
+
+func (s *ServiceTypeAndIdentification) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading service type and identification: %w", err)
+	}
+	s.SID = b >> 4
+	s.STYP = b & 0x0F
+
+	return 1, nil
+}
+
+func (s *ServiceTypeAndIdentification) Encode(buf *bytes.Buffer) (int, error) {
+	if err := s.Validate(); err != nil {
+		return 0, err
+	}
+
+	if err := buf.WriteByte(s.SID<<4 | s.STYP); err != nil {
+		return 0, fmt.Errorf("writing service type and identification: %w", err)
+	}
+	return 1, nil
+}
+
+func (s *ServiceTypeAndIdentification) Validate() error {
+	if s.SID > 15 {
+		return fmt.Errorf("invalid service identification: %d", s.SID)
+	}
+	if s.STYP > 15 {
+		return fmt.Errorf("invalid service type: %d", s.STYP)
+	}
+	return nil
+}
+
+func (s *ServiceTypeAndIdentification) String() string {
+	typ, ok := serviceTypeNames[s.STYP]
+	if !ok {
+		typ = fmt.Sprintf("Type %d", s.STYP)
+	}
+	return fmt.Sprintf("SID: %d, %s", s.SID, typ)
+}
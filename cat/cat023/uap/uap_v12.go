@@ -0,0 +1,155 @@
+// cat/cat023/uap/uap_v12.go
+package uap
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v12 "github.com/davidkohl/gobelix/cat/cat023/dataitems/v12"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// UAP023 implements the User Application Profile for ASTERIX Category 023
+type UAP023 struct {
+	*asterix.BaseUAP
+}
+
+// NewUAP023 creates a new instance of the Category 023 UAP
+func NewUAP023() (*UAP023, error) {
+	base, err := asterix.NewBaseUAP(asterix.Cat023, "1.2", cat023Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UAP023{
+		BaseUAP: base,
+	}, nil
+}
+
+// CreateDataItem creates a new instance of a Cat023 data item
+func (u *UAP023) CreateDataItem(id string) (asterix.DataItem, error) {
+	switch id {
+	case "I023/000":
+		return &v12.ReportType{}, nil
+	case "I023/010":
+		return &common.DataSourceIdentifier{}, nil
+	case "I023/015":
+		return &v12.ServiceTypeAndIdentification{}, nil
+	case "I023/070":
+		return &common.TimeOfDay{}, nil
+	case "I023/100":
+		return &v12.GroundStationStatus{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", asterix.ErrUnknownDataItem, id)
+	}
+}
+
+// Validate implements critical validations for Cat023
+func (u *UAP023) Validate(items map[string]asterix.DataItem) error {
+	// First do base validation (mandatory fields)
+	if err := u.BaseUAP.Validate(items); err != nil {
+		return err
+	}
+
+	// A ground station status report must carry the station status
+	if rt, ok := items["I023/000"].(*v12.ReportType); ok && rt.Value == v12.ReportTypeGroundStationStatus {
+		if _, exists := items["I023/100"]; !exists {
+			return fmt.Errorf("%w: ground station status report without I023/100", asterix.ErrMandatoryField)
+		}
+	}
+
+	return nil
+}
+
+// cat023Fields defines the complete UAP for Category 023. FRNs 10 to 12
+// are spare.
+var cat023Fields = []asterix.DataField{
+	{
+		FRN:         1,
+		DataItem:    "I023/010",
+		Description: "Data Source Identifier",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         2,
+		DataItem:    "I023/000",
+		Description: "Report Type",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   true,
+	},
+	{
+		FRN:         3,
+		DataItem:    "I023/015",
+		Description: "Service Type and Identification",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         4,
+		DataItem:    "I023/070",
+		Description: "Time of Day",
+		Type:        asterix.Fixed,
+		Length:      3,
+		Mandatory:   false,
+	},
+	{
+		FRN:         5,
+		DataItem:    "I023/100",
+		Description: "Ground Station Status",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         6,
+		DataItem:    "I023/101",
+		Description: "Service Configuration",
+		Type:        asterix.Extended,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         7,
+		DataItem:    "I023/200",
+		Description: "Operational Range",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         8,
+		DataItem:    "I023/110",
+		Description: "Service Status",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         9,
+		DataItem:    "I023/120",
+		Description: "Service Statistics",
+		Type:        asterix.Repetitive,
+		Length:      6,
+		Mandatory:   false,
+	},
+	{
+		FRN:         13,
+		DataItem:    "RE023",
+		Description: "Reserved Expansion Field",
+		Type:        asterix.Explicit,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         14,
+		DataItem:    "SP023",
+		Description: "Special Purpose Field",
+		Type:        asterix.Explicit,
+		Length:      1,
+		Mandatory:   false,
+	},
+}
@@ -0,0 +1,108 @@
+// cat/cat023/uap/uap_v12_test.go
+package uap_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat023"
+	v12 "github.com/davidkohl/gobelix/cat/cat023/dataitems/v12"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestUAP023_GroundStationStatusRoundTrip(t *testing.T) {
+	uap, err := cat023.NewUAP(cat023.Version12)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	record, err := asterix.NewRecord(asterix.Cat023, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	gssp := uint8(30)
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I023/010", &common.DataSourceIdentifier{SAC: 25, SIC: 7}},
+		{"I023/000", &v12.ReportType{Value: v12.ReportTypeGroundStationStatus}},
+		{"I023/015", &v12.ServiceTypeAndIdentification{SID: 1, STYP: v12.ServiceTypeADSBES}},
+		{"I023/070", &common.TimeOfDay{Time: 36000.5}},
+		{"I023/100", &v12.GroundStationStatus{TSV: true, GSSP: &gssp}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat023, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(record); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	want := []byte{
+		23, 0x00, 0x0D, // CAT, LEN
+		0xF8,       // FSPEC: 010, 000, 015, 070, 100
+		0x19, 0x07, // I023/010
+		0x01,             // I023/000
+		0x12,             // I023/015
+		0x46, 0x50, 0x40, // I023/070
+		0x09, 0x3C, // I023/100: TSV, GSSP 30 s
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Fatalf("Encode() = % X, want % X", encoded, want)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat023, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatalf("DataBlock.Decode() error = %v", err)
+	}
+	if decoded.Length() != 1 {
+		t.Fatalf("Length() = %d, want 1", decoded.Length())
+	}
+	got := decoded.Records()[0]
+	for _, it := range items {
+		item, _, ok := got.GetDataItem(it.id)
+		if !ok {
+			t.Errorf("%s missing after decode", it.id)
+			continue
+		}
+		if !reflect.DeepEqual(item, it.item) {
+			t.Errorf("%s = %+v, want %+v", it.id, item, it.item)
+		}
+	}
+}
+
+func TestUAP023_GroundStationReportNeedsStatus(t *testing.T) {
+	uap, err := cat023.NewUAP(cat023.Version12)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	items := map[string]asterix.DataItem{
+		"I023/010": &common.DataSourceIdentifier{SAC: 25, SIC: 7},
+		"I023/000": &v12.ReportType{Value: v12.ReportTypeGroundStationStatus},
+	}
+	if err := uap.Validate(items); !errors.Is(err, asterix.ErrMandatoryField) {
+		t.Errorf("Validate() without I023/100 error = %v, want %v", err, asterix.ErrMandatoryField)
+	}
+
+	items["I023/100"] = &v12.GroundStationStatus{}
+	if err := uap.Validate(items); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+// cat/cat023/version.go
+package cat023
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat023/uap"
+)
+
+// Version constants
+const (
+	Version12 = "1.2"
+)
+
+// NewUAP returns the UAP for the specified version of CAT023
+func NewUAP(version string) (asterix.UAP, error) {
+	switch version {
+	case Version12:
+		return uap.NewUAP023()
+	default:
+		return nil, fmt.Errorf("%w: CAT023 %s", asterix.ErrUnsupportedVersion, version)
+	}
+}
+
+// LatestVersion returns the latest available version
+func LatestVersion() string {
+	return Version12
+}
+
+// AvailableVersions returns all supported versions
+func AvailableVersions() []string {
+	return []string{Version12}
+}
@@ -0,0 +1,66 @@
+// cat/cat030/dataitems/v62/calculated_track_position_cartesian.go
+package v62
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// positionLSB is the resolution of the Cartesian position (1/64 NM)
+const positionLSB = 1.0 / 64
+
+// CalculatedTrackPositionCartesian implements I030/100
+// Calculated position of the track in the Cartesian co-ordinates of the
+// ARTAS system plane, as two 16-bit two's complement values with an LSB of
+// 1/64 NM
+type CalculatedTrackPositionCartesian struct {
+	X float64 // NM, positive = east
+	Y float64 // NM, positive = north
+}
+
+func (p *CalculatedTrackPositionCartesian) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 4)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading cartesian position: %w", err)
+	}
+	if n != 4 {
+		return n, fmt.Errorf("insufficient data for cartesian position: got %d bytes, want 4", n)
+	}
+
+	p.X = float64(int16(uint16(data[0])<<8|uint16(data[1]))) * positionLSB
+	p.Y = float64(int16(uint16(data[2])<<8|uint16(data[3]))) * positionLSB
+	return n, nil
+}
+
+func (p *CalculatedTrackPositionCartesian) Encode(buf *bytes.Buffer) (int, error) {
+	if err := p.Validate(); err != nil {
+		return 0, err
+	}
+
+	x := uint16(int16(math.Round(p.X / positionLSB)))
+	y := uint16(int16(math.Round(p.Y / positionLSB)))
+
+	n, err := buf.Write([]byte{byte(x >> 8), byte(x), byte(y >> 8), byte(y)})
+	if err != nil {
+		return n, fmt.Errorf("writing cartesian position: %w", err)
+	}
+	return n, nil
+}
+
+func (p *CalculatedTrackPositionCartesian) Validate() error {
+	minValue := math.MinInt16 * positionLSB
+	maxValue := math.MaxInt16 * positionLSB
+	if p.X < minValue || p.X > maxValue {
+		return fmt.Errorf("X coordinate out of valid range [%.3f,%.3f] NM: %.3f", minValue, maxValue, p.X)
+	}
+	if p.Y < minValue || p.Y > maxValue {
+		return fmt.Errorf("Y coordinate out of valid range [%.3f,%.3f] NM: %.3f", minValue, maxValue, p.Y)
+	}
+	return nil
+}
+
+func (p *CalculatedTrackPositionCartesian) String() string {
+	return fmt.Sprintf("X: %.3f NM, Y: %.3f NM", p.X, p.Y)
+}
@@ -0,0 +1,43 @@
+// cat/cat030/dataitems/v62/user_number.go
+package v62
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// UserNumber implements I030/015
+// Identification of the ARTAS user the message is sent to
+type UserNumber struct {
+	Value uint16
+}
+
+func (u *UserNumber) Decode(buf *bytes.Buffer) (int, error) {
+	data := make([]byte, 2)
+	n, err := buf.Read(data)
+	if err != nil {
+		return n, fmt.Errorf("reading user number: %w", err)
+	}
+	if n != 2 {
+		return n, fmt.Errorf("insufficient data for user number: got %d bytes, want 2", n)
+	}
+
+	u.Value = uint16(data[0])<<8 | uint16(data[1])
+	return n, nil
+}
+
+func (u *UserNumber) Encode(buf *bytes.Buffer) (int, error) {
+	n, err := buf.Write([]byte{byte(u.Value >> 8), byte(u.Value)})
+	if err != nil {
+		return n, fmt.Errorf("writing user number: %w", err)
+	}
+	return n, nil
+}
+
+func (u *UserNumber) Validate() error {
+	return nil
+}
+
+func (u *UserNumber) String() string {
+	return fmt.Sprintf("%d", u.Value)
+}
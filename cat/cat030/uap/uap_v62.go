@@ -0,0 +1,485 @@
+// cat/cat030/uap/uap_v62.go
+package uap
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v62 "github.com/davidkohl/gobelix/cat/cat030/dataitems/v62"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// UAP030 implements the User Application Profile for ASTERIX Category 030
+type UAP030 struct {
+	*asterix.BaseUAP
+}
+
+// NewUAP030 creates a new instance of the Category 030 UAP
+func NewUAP030() (*UAP030, error) {
+	base, err := asterix.NewBaseUAP(asterix.Cat030, "6.2", cat030Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UAP030{
+		BaseUAP: base,
+	}, nil
+}
+
+// CreateDataItem creates a new instance of a Cat030 data item
+func (u *UAP030) CreateDataItem(id string) (asterix.DataItem, error) {
+	switch id {
+	case "I030/010":
+		// The server identification tag has the layout of a SAC/SIC pair
+		return &common.DataSourceIdentifier{}, nil
+	case "I030/015":
+		return &v62.UserNumber{}, nil
+	case "I030/030":
+		return &common.TimeOfDay{}, nil
+	case "I030/040":
+		return &v62.TrackNumber{}, nil
+	case "I030/070":
+		return &common.TimeOfDay{}, nil
+	case "I030/100":
+		return &v62.CalculatedTrackPositionCartesian{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", asterix.ErrUnknownDataItem, id)
+	}
+}
+
+// cat030Fields defines the complete UAP for Category 030. FRNs 55 and 56 are
+// spare.
+var cat030Fields = []asterix.DataField{
+	{
+		FRN:         1,
+		DataItem:    "I030/010",
+		Description: "Server Identification Tag",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         2,
+		DataItem:    "I030/015",
+		Description: "User Number",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         3,
+		DataItem:    "I030/030",
+		Description: "Time of Message",
+		Type:        asterix.Fixed,
+		Length:      3,
+		Mandatory:   true,
+	},
+	{
+		FRN:         4,
+		DataItem:    "I030/035",
+		Description: "Type of Message",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         5,
+		DataItem:    "I030/040",
+		Description: "Track Number",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         6,
+		DataItem:    "I030/070",
+		Description: "Time of Last Update",
+		Type:        asterix.Fixed,
+		Length:      3,
+		Mandatory:   false,
+	},
+	{
+		FRN:         7,
+		DataItem:    "I030/170",
+		Description: "Track Ages",
+		Type:        asterix.Compound,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         8,
+		DataItem:    "I030/100",
+		Description: "Calculated Track Position (Cartesian)",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         9,
+		DataItem:    "I030/180",
+		Description: "Calculated Track Velocity (Polar)",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         10,
+		DataItem:    "I030/181",
+		Description: "Calculated Track Velocity (Cartesian)",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         11,
+		DataItem:    "I030/060",
+		Description: "Track Mode 3/A",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         12,
+		DataItem:    "I030/150",
+		Description: "Measured Track Mode C",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         13,
+		DataItem:    "I030/130",
+		Description: "Calculated Track Altitude",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         14,
+		DataItem:    "I030/160",
+		Description: "Calculated Track Flight Level",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         15,
+		DataItem:    "I030/080",
+		Description: "ARTAS Track Status",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         16,
+		DataItem:    "I030/090",
+		Description: "ARTAS Track Quality",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         17,
+		DataItem:    "I030/200",
+		Description: "Mode of Flight",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         18,
+		DataItem:    "I030/220",
+		Description: "Calculated Rate of Climb/Descent",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         19,
+		DataItem:    "I030/240",
+		Description: "Calculated Rate of Turn",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         20,
+		DataItem:    "I030/290",
+		Description: "Plot Ages",
+		Type:        asterix.Compound,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         21,
+		DataItem:    "I030/260",
+		Description: "Radar Identification Tag",
+		Type:        asterix.Repetitive,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         22,
+		DataItem:    "I030/360",
+		Description: "Measurement Identifier",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         23,
+		DataItem:    "I030/140",
+		Description: "Last Measured Mode C",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         24,
+		DataItem:    "I030/340",
+		Description: "Last Measured Mode 3/A",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         25,
+		DataItem:    "I030/400",
+		Description: "Callsign",
+		Type:        asterix.Fixed,
+		Length:      7,
+		Mandatory:   false,
+	},
+	{
+		FRN:         26,
+		DataItem:    "I030/410",
+		Description: "Plan Number",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         27,
+		DataItem:    "I030/440",
+		Description: "Departure Airport",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         28,
+		DataItem:    "I030/450",
+		Description: "Destination Airport",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         29,
+		DataItem:    "I030/435",
+		Description: "Category of Turbulence",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         30,
+		DataItem:    "I030/430",
+		Description: "Type of Aircraft",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         31,
+		DataItem:    "I030/460",
+		Description: "Allocated SSR Codes",
+		Type:        asterix.Repetitive,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         32,
+		DataItem:    "I030/480",
+		Description: "Current Cleared Flight Level",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         33,
+		DataItem:    "I030/420",
+		Description: "Flight Category",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         34,
+		DataItem:    "I030/490",
+		Description: "Current Control Position",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         35,
+		DataItem:    "I030/020",
+		Description: "Time of Message (Server)",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         36,
+		DataItem:    "I030/382",
+		Description: "Aircraft Address",
+		Type:        asterix.Fixed,
+		Length:      3,
+		Mandatory:   false,
+	},
+	{
+		FRN:         37,
+		DataItem:    "I030/384",
+		Description: "Aircraft Identification",
+		Type:        asterix.Fixed,
+		Length:      7,
+		Mandatory:   false,
+	},
+	{
+		FRN:         38,
+		DataItem:    "I030/386",
+		Description: "Communications Capability and Flight Status",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         39,
+		DataItem:    "I030/110",
+		Description: "Estimated Accuracy of Track Position (Cartesian)",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         40,
+		DataItem:    "I030/190",
+		Description: "Estimated Accuracy of Track Velocity (Polar)",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         41,
+		DataItem:    "I030/191",
+		Description: "Estimated Accuracy of Track Velocity (Cartesian)",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         42,
+		DataItem:    "I030/135",
+		Description: "Calculated Track Barometric Altitude",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         43,
+		DataItem:    "I030/165",
+		Description: "Estimated Accuracy of Calculated Track Flight Level",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         44,
+		DataItem:    "I030/230",
+		Description: "Estimated Accuracy of Rate of Climb/Descent",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         45,
+		DataItem:    "I030/250",
+		Description: "Calculated Longitudinal and Transversal Acceleration",
+		Type:        asterix.Fixed,
+		Length:      4,
+		Mandatory:   false,
+	},
+	{
+		FRN:         46,
+		DataItem:    "I030/210",
+		Description: "Mode of Flight Probabilities",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         47,
+		DataItem:    "I030/120",
+		Description: "Track Mode 2 Code",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         48,
+		DataItem:    "I030/050",
+		Description: "ARTAS Track Number",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         49,
+		DataItem:    "I030/270",
+		Description: "Local Track Number",
+		Type:        asterix.Repetitive,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         50,
+		DataItem:    "I030/370",
+		Description: "Measured Mode S Data",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         51,
+		DataItem:    "I030/305",
+		Description: "Number of Plots Used",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         52,
+		DataItem:    "I030/307",
+		Description: "Number of Measurements Used",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         53,
+		DataItem:    "I030/385",
+		Description: "Aircraft Address Confidence",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         54,
+		DataItem:    "I030/389",
+		Description: "Mode S Capability Report",
+		Type:        asterix.Extended,
+		Length:      1,
+		Mandatory:   false,
+	},
+}
@@ -0,0 +1,121 @@
+// cat/cat030/uap/uap_v62_test.go
+package uap_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat030"
+	v62 "github.com/davidkohl/gobelix/cat/cat030/dataitems/v62"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestUAP030_SystemTrackRoundTrip(t *testing.T) {
+	uap, err := cat030.NewUAP(cat030.Version62)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	record, err := asterix.NewRecord(asterix.Cat030, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I030/010", &common.DataSourceIdentifier{SAC: 25, SIC: 10}},
+		{"I030/015", &v62.UserNumber{Value: 7}},
+		{"I030/030", &common.TimeOfDay{Time: 36000.5}},
+		{"I030/040", &v62.TrackNumber{Value: 1234}},
+		{"I030/100", &v62.CalculatedTrackPositionCartesian{X: 12.5, Y: -40.25}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat030, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(record); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	want := []byte{
+		30, 0x00, 0x12, // CAT, LEN
+		0xE9, 0x80, // FSPEC: 010, 015, 030, 040 | 100
+		0x19, 0x0A, // I030/010
+		0x00, 0x07, // I030/015
+		0x46, 0x50, 0x40, // I030/030
+		0x04, 0xD2, // I030/040
+		0x03, 0x20, 0xF5, 0xF0, // I030/100
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Fatalf("Encode() = % X, want % X", encoded, want)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat030, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatalf("DataBlock.Decode() error = %v", err)
+	}
+	if decoded.Length() != 1 {
+		t.Fatalf("Length() = %d, want 1", decoded.Length())
+	}
+	got := decoded.Records()[0]
+	for _, it := range items {
+		item, _, ok := got.GetDataItem(it.id)
+		if !ok {
+			t.Errorf("%s missing after decode", it.id)
+			continue
+		}
+		if fmt.Sprint(item) != fmt.Sprint(it.item) {
+			t.Errorf("%s = %v, want %v", it.id, item, it.item)
+		}
+	}
+	item, _, _ := got.GetDataItem("I030/040")
+	if tp, ok := item.(asterix.TrackNumberProvider); !ok || tp.TrackNumber() != 1234 {
+		t.Errorf("I030/040 = %v, want track number provider for 1234", item)
+	}
+}
+
+func TestUAP030_UnimplementedItem(t *testing.T) {
+	uap, err := cat030.NewUAP(cat030.Version62)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	data := []byte{
+		30, 0x00, 0x0E, // CAT, LEN
+		0xEA,       // FSPEC: 010, 015, 030, 040, 170
+		0x19, 0x0A, // I030/010
+		0x00, 0x07, // I030/015
+		0x46, 0x50, 0x40, // I030/030
+		0x04, 0xD2, // I030/040
+		0x00, // I030/170
+	}
+	block, err := asterix.NewDataBlock(asterix.Cat030, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	err = block.Decode(data)
+	if !errors.Is(err, asterix.ErrUnknownDataItem) {
+		t.Fatalf("DataBlock.Decode() error = %v, want %v", err, asterix.ErrUnknownDataItem)
+	}
+	var decodeErr *asterix.DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.DataItem != "I030/170" {
+		t.Errorf("DataBlock.Decode() error = %v, want it to name I030/170", err)
+	}
+}
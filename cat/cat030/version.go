@@ -0,0 +1,34 @@
+// cat/cat030/version.go
+package cat030
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat030/uap"
+)
+
+// Version constants
+const (
+	Version62 = "6.2"
+)
+
+// NewUAP returns the UAP for the specified version of CAT030
+func NewUAP(version string) (asterix.UAP, error) {
+	switch version {
+	case Version62:
+		return uap.NewUAP030()
+	default:
+		return nil, fmt.Errorf("%w: CAT030 %s", asterix.ErrUnsupportedVersion, version)
+	}
+}
+
+// LatestVersion returns the latest available version
+func LatestVersion() string {
+	return Version62
+}
+
+// AvailableVersions returns all supported versions
+func AvailableVersions() []string {
+	return []string{Version62}
+}
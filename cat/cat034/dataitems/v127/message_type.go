@@ -0,0 +1,74 @@
+// cat/cat034/dataitems/v127/message_type.go
+package v127
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Message types defined for I034/000
+const (
+	MessageTypeNorthMarker        uint8 = 1
+	MessageTypeSectorCrossing     uint8 = 2
+	MessageTypeGeographicalFilter uint8 = 3
+	MessageTypeJammingStrobe      uint8 = 4
+	MessageTypeSolarStorm         uint8 = 5
+	MessageTypeSSRJammingStrobe   uint8 = 6
+	MessageTypeModeSJammingStrobe uint8 = 7
+)
+
+// MessageType implements I034/000
+// This Data Item allows for a more convenient handling of the messages at
+// the receiver side by further defining the type of transaction.
+type MessageType struct {
+	Value uint8
+}
+
+func (m *MessageType) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading message type: %w", err)
+	}
+	m.Value = b
+
+	return 1, m.Validate()
+}
+
+func (m *MessageType) Encode(buf *bytes.Buffer) (int, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	if err := buf.WriteByte(m.Value); err != nil {
+		return 0, fmt.Errorf("writing message type: %w", err)
+	}
+	return 1, nil
+}
+
+func (m *MessageType) Validate() error {
+	if m.Value < MessageTypeNorthMarker || m.Value > MessageTypeModeSJammingStrobe {
+		return fmt.Errorf("invalid message type: %d", m.Value)
+	}
+	return nil
+}
+
+func (m *MessageType) String() string {
+	switch m.Value {
+	case MessageTypeNorthMarker:
+		return "North Marker"
+	case MessageTypeSectorCrossing:
+		return "Sector Crossing"
+	case MessageTypeGeographicalFilter:
+		return "Geographical Filtering"
+	case MessageTypeJammingStrobe:
+		return "Jamming Strobe"
+	case MessageTypeSolarStorm:
+		return "Solar Storm"
+	case MessageTypeSSRJammingStrobe:
+		return "SSR Jamming Strobe"
+	case MessageTypeModeSJammingStrobe:
+		return "Mode S Jamming Strobe"
+	default:
+		return fmt.Sprintf("Unknown (%d)", m.Value)
+	}
+}
@@ -0,0 +1,65 @@
+// cat/cat034/dataitems/v127/symmetry_test.go
+package v127_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v127 "github.com/davidkohl/gobelix/cat/cat034/dataitems/v127"
+)
+
+func TestSystemConfigurationAndStatus_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v127.SystemConfigurationAndStatus{} }, [][]byte{
+		{0x00},
+		{0x80, 0xFE},
+		{0x10, 0xF8},
+		{0x08, 0x48},
+		{0x04, 0xFF, 0x80},
+		{0x9C, 0x82, 0x20, 0x40, 0x24, 0x00},
+	})
+}
+
+func TestSystemProcessingMode_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v127.SystemProcessingMode{} }, [][]byte{
+		{0x00},
+		{0x80, 0x7E},
+		{0x10, 0xFC},
+		{0x08, 0xE0},
+		{0x04, 0xF0},
+		{0x9C, 0x22, 0x94, 0x40, 0x30},
+	})
+}
+
+func TestSystemConfigurationAndStatus_Decode(t *testing.T) {
+	var s v127.SystemConfigurationAndStatus
+	if _, err := s.Decode(bytes.NewBuffer([]byte{0x84, 0x82, 0xB0, 0x80})); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if s.COM == nil || !s.COM.NOGO || !s.COM.TSV || s.PSR != nil || s.SSR != nil {
+		t.Errorf("COM = %+v, PSR = %v, SSR = %v", s.COM, s.PSR, s.SSR)
+	}
+	if s.MDS == nil || !s.MDS.ANT || s.MDS.CHAB != 1 || !s.MDS.OVLSUR || !s.MDS.OVLDLF {
+		t.Errorf("MDS = %+v", s.MDS)
+	}
+	if got := s.String(); got != "COM[NOGO, TSV] MDS[CH-A/B 1, ANT2, OVL-SUR, OVL-DLF]" {
+		t.Errorf("String() = %q", got)
+	}
+
+	// No extension of the primary subfield is defined
+	if _, err := new(v127.SystemConfigurationAndStatus).Decode(bytes.NewBuffer([]byte{0x81, 0x00})); err == nil {
+		t.Error("Decode() accepted a primary subfield with FX set")
+	}
+}
+
+func TestSectorNumber(t *testing.T) {
+	var s v127.SectorNumber
+	if _, err := s.Decode(bytes.NewBuffer([]byte{0x40})); err != nil || s.Azimuth != 90 {
+		t.Fatalf("Decode(40) = %v, %v, want 90°", s.Azimuth, err)
+	}
+	var buf bytes.Buffer
+	if _, err := (&v127.SectorNumber{Azimuth: 359.9}).Encode(&buf); err != nil || buf.Bytes()[0] != 0 {
+		t.Errorf("Encode(359.9°) = % X, %v, want 00", buf.Bytes(), err)
+	}
+}
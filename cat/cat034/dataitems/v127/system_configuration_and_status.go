@@ -0,0 +1,246 @@
+// cat/cat034/dataitems/v127/system_configuration_and_status.go
+package v127
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Subfield presence bits of the primary subfield shared by I034/050 and
+// I034/060. Bits 7, 6 and 2 are spare.
+const (
+	subfieldCOM byte = 0x80
+	subfieldPSR byte = 0x10
+	subfieldSSR byte = 0x08
+	subfieldMDS byte = 0x04
+	subfieldFX  byte = 0x01
+)
+
+// SystemConfigurationAndStatus implements I034/050
+// Information concerning the configuration and status of a system. Each
+// subfield is present when its pointer is not nil.
+type SystemConfigurationAndStatus struct {
+	COM *COMStatus    // Common part
+	PSR *SensorStatus // Specific status for the PSR sensor
+	SSR *SensorStatus // Specific status for the SSR sensor
+	MDS *ModeSStatus  // Specific status for the Mode S sensor
+}
+
+// COMStatus is the common part of I034/050
+type COMStatus struct {
+	NOGO   bool // System inhibited
+	RDPC   bool // RDP chain 2 selected, chain 1 when false
+	RDPR   bool // Reset of the RDP chain in use
+	OVLRDP bool // RDP overloaded
+	OVLXMT bool // Transmission subsystem overloaded
+	MSC    bool // Monitoring system disconnected
+	TSV    bool // Time source invalid
+}
+
+// SensorStatus is the PSR or SSR subfield of I034/050
+type SensorStatus struct {
+	ANT  bool  // Antenna 2 in use, antenna 1 when false
+	CHAB uint8 // 0 = no chain, 1 = chain A, 2 = chain B, 3 = diversity mode
+	OVL  bool  // Overload
+	MSC  bool  // Monitoring system disconnected
+}
+
+// ModeSStatus is the Mode S subfield of I034/050
+type ModeSStatus struct {
+	ANT    bool  // Antenna 2 in use, antenna 1 when false
+	CHAB   uint8 // 0 = no chain, 1 = chain A, 2 = chain B, 3 = illegal combination
+	OVLSUR bool  // Surveillance overload
+	MSC    bool  // Monitoring system disconnected
+	SCF    bool  // Channel A/B surveillance cooperation failed
+	DLF    bool  // Channel A/B data link failed
+	OVLSCF bool  // Overload in the surveillance coordination function
+	OVLDLF bool  // Overload in the data link function
+}
+
+func (s *SystemConfigurationAndStatus) Decode(buf *bytes.Buffer) (int, error) {
+	*s = SystemConfigurationAndStatus{}
+	primary, err := readPrimarySubfield(buf, "system configuration and status")
+	if err != nil {
+		return 0, err
+	}
+	bytesRead := 1
+
+	if primary&subfieldCOM != 0 {
+		b, err := buf.ReadByte()
+		if err != nil {
+			return bytesRead, fmt.Errorf("reading COM subfield: %w", err)
+		}
+		bytesRead++
+		s.COM = &COMStatus{
+			NOGO:   b&0x80 != 0,
+			RDPC:   b&0x40 != 0,
+			RDPR:   b&0x20 != 0,
+			OVLRDP: b&0x10 != 0,
+			OVLXMT: b&0x08 != 0,
+			MSC:    b&0x04 != 0,
+			TSV:    b&0x02 != 0,
+		}
+	}
+	for _, sensor := range []struct {
+		bit  byte
+		name string
+		dst  **SensorStatus
+	}{{subfieldPSR, "PSR", &s.PSR}, {subfieldSSR, "SSR", &s.SSR}} {
+		if primary&sensor.bit == 0 {
+			continue
+		}
+		b, err := buf.ReadByte()
+		if err != nil {
+			return bytesRead, fmt.Errorf("reading %s subfield: %w", sensor.name, err)
+		}
+		bytesRead++
+		*sensor.dst = &SensorStatus{
+			ANT:  b&0x80 != 0,
+			CHAB: (b >> 5) & 0x03,
+			OVL:  b&0x10 != 0,
+			MSC:  b&0x08 != 0,
+		}
+	}
+	if primary&subfieldMDS != 0 {
+		data := buf.Next(2)
+		bytesRead += len(data)
+		if len(data) != 2 {
+			return bytesRead, fmt.Errorf("insufficient data for MDS subfield: got %d bytes, want 2", len(data))
+		}
+		s.MDS = &ModeSStatus{
+			ANT:    data[0]&0x80 != 0,
+			CHAB:   (data[0] >> 5) & 0x03,
+			OVLSUR: data[0]&0x10 != 0,
+			MSC:    data[0]&0x08 != 0,
+			SCF:    data[0]&0x04 != 0,
+			DLF:    data[0]&0x02 != 0,
+			OVLSCF: data[0]&0x01 != 0,
+			OVLDLF: data[1]&0x80 != 0,
+		}
+	}
+	return bytesRead, nil
+}
+
+func (s *SystemConfigurationAndStatus) Encode(buf *bytes.Buffer) (int, error) {
+	if err := s.Validate(); err != nil {
+		return 0, err
+	}
+
+	var primary byte
+	var data []byte
+	if c := s.COM; c != nil {
+		primary |= subfieldCOM
+		data = append(data, flags(c.NOGO, c.RDPC, c.RDPR, c.OVLRDP, c.OVLXMT, c.MSC, c.TSV, false))
+	}
+	for _, sensor := range []struct {
+		bit    byte
+		status *SensorStatus
+	}{{subfieldPSR, s.PSR}, {subfieldSSR, s.SSR}} {
+		if sensor.status == nil {
+			continue
+		}
+		primary |= sensor.bit
+		data = append(data, flags(sensor.status.ANT, false, false, sensor.status.OVL, sensor.status.MSC,
+			false, false, false)|sensor.status.CHAB<<5)
+	}
+	if m := s.MDS; m != nil {
+		primary |= subfieldMDS
+		data = append(data,
+			flags(m.ANT, false, false, m.OVLSUR, m.MSC, m.SCF, m.DLF, m.OVLSCF)|m.CHAB<<5,
+			flags(m.OVLDLF, false, false, false, false, false, false, false))
+	}
+
+	n, err := buf.Write(append([]byte{primary}, data...))
+	if err != nil {
+		return n, fmt.Errorf("writing system configuration and status: %w", err)
+	}
+	return n, nil
+}
+
+func (s *SystemConfigurationAndStatus) Validate() error {
+	for name, status := range map[string]*SensorStatus{"PSR": s.PSR, "SSR": s.SSR} {
+		if status != nil && status.CHAB > 3 {
+			return fmt.Errorf("invalid %s CH-A/B value: %d", name, status.CHAB)
+		}
+	}
+	if s.MDS != nil && s.MDS.CHAB > 3 {
+		return fmt.Errorf("invalid MDS CH-A/B value: %d", s.MDS.CHAB)
+	}
+	return nil
+}
+
+func (s *SystemConfigurationAndStatus) String() string {
+	var parts []string
+	if c := s.COM; c != nil {
+		parts = append(parts, subfieldString("COM", setFlags(
+			namedFlag{"NOGO", c.NOGO}, namedFlag{"RDPC", c.RDPC}, namedFlag{"RDPR", c.RDPR},
+			namedFlag{"OVL-RDP", c.OVLRDP}, namedFlag{"OVL-XMT", c.OVLXMT},
+			namedFlag{"MSC", c.MSC}, namedFlag{"TSV", c.TSV})))
+	}
+	for _, sensor := range []struct {
+		name   string
+		status *SensorStatus
+	}{{"PSR", s.PSR}, {"SSR", s.SSR}} {
+		if st := sensor.status; st != nil {
+			parts = append(parts, subfieldString(sensor.name, append([]string{fmt.Sprintf("CH-A/B %d", st.CHAB)},
+				setFlags(namedFlag{"ANT2", st.ANT}, namedFlag{"OVL", st.OVL}, namedFlag{"MSC", st.MSC})...)))
+		}
+	}
+	if m := s.MDS; m != nil {
+		parts = append(parts, subfieldString("MDS", append([]string{fmt.Sprintf("CH-A/B %d", m.CHAB)},
+			setFlags(namedFlag{"ANT2", m.ANT}, namedFlag{"OVL-SUR", m.OVLSUR}, namedFlag{"MSC", m.MSC},
+				namedFlag{"SCF", m.SCF}, namedFlag{"DLF", m.DLF}, namedFlag{"OVL-SCF", m.OVLSCF},
+				namedFlag{"OVL-DLF", m.OVLDLF})...)))
+	}
+	if len(parts) == 0 {
+		return "SystemConfigurationAndStatus[empty]"
+	}
+	return strings.Join(parts, " ")
+}
+
+// readPrimarySubfield reads the primary subfield of I034/050 or I034/060.
+// Neither item defines an extension, so a set FX bit is an error.
+func readPrimarySubfield(buf *bytes.Buffer, item string) (byte, error) {
+	primary, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading %s primary subfield: %w", item, err)
+	}
+	if primary&subfieldFX != 0 {
+		return primary, fmt.Errorf("%s: FX bit set in primary subfield, but no extension is defined", item)
+	}
+	return primary, nil
+}
+
+// flags packs eight booleans into an octet, the first into bit 8
+func flags(bits ...bool) byte {
+	var b byte
+	for i, set := range bits {
+		if set {
+			b |= 0x80 >> i
+		}
+	}
+	return b
+}
+
+// namedFlag is a flag of a subfield, as printed by String
+type namedFlag struct {
+	name string
+	set  bool
+}
+
+// setFlags returns the names of the set flags in order
+func setFlags(fs ...namedFlag) []string {
+	var names []string
+	for _, f := range fs {
+		if f.set {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// subfieldString formats a subfield as NAME[value, ...]
+func subfieldString(name string, values []string) string {
+	return name + "[" + strings.Join(values, ", ") + "]"
+}
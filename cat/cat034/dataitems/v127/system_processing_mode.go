@@ -0,0 +1,168 @@
+// cat/cat034/dataitems/v127/system_processing_mode.go
+package v127
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// SystemProcessingMode implements I034/060
+// Status concerning the processing options in use during the last antenna
+// revolution, for the various sensors composing the station. Each subfield
+// is present when its pointer is not nil.
+type SystemProcessingMode struct {
+	COM *COMProcessing   // Common part
+	PSR *PSRProcessing   // Specific processing mode information for the PSR sensor
+	SSR *SensorReduction // Specific processing mode information for the SSR sensor
+	MDS *ModeSProcessing // Specific processing mode information for the Mode S sensor
+}
+
+// COMProcessing is the common part of I034/060
+type COMProcessing struct {
+	REDRDP uint8 // Reduction steps in use for an overload of the RDP, 0 = none
+	REDXMT uint8 // Reduction steps in use for an overload of the transmission subsystem, 0 = none
+}
+
+// PSRProcessing is the PSR subfield of I034/060
+type PSRProcessing struct {
+	POL    bool  // Circular polarization, linear when false
+	REDRAD uint8 // Reduction steps in use as a result of an overload, 0 = none
+	STC    uint8 // STC map 1 to 4, coded 0 to 3
+}
+
+// SensorReduction is the SSR subfield of I034/060
+type SensorReduction struct {
+	REDRAD uint8 // Reduction steps in use as a result of an overload, 0 = none
+}
+
+// ModeSProcessing is the Mode S subfield of I034/060
+type ModeSProcessing struct {
+	REDRAD uint8 // Reduction steps in use as a result of an overload, 0 = none
+	CLU    bool  // Cluster state autonomous, cooperative when false
+}
+
+func (s *SystemProcessingMode) Decode(buf *bytes.Buffer) (int, error) {
+	*s = SystemProcessingMode{}
+	primary, err := readPrimarySubfield(buf, "system processing mode")
+	if err != nil {
+		return 0, err
+	}
+	bytesRead := 1
+
+	for _, sub := range []struct {
+		bit    byte
+		name   string
+		decode func(b byte)
+	}{
+		{subfieldCOM, "COM", func(b byte) { s.COM = &COMProcessing{REDRDP: (b >> 4) & 0x07, REDXMT: (b >> 1) & 0x07} }},
+		{subfieldPSR, "PSR", func(b byte) {
+			s.PSR = &PSRProcessing{POL: b&0x80 != 0, REDRAD: (b >> 4) & 0x07, STC: (b >> 2) & 0x03}
+		}},
+		{subfieldSSR, "SSR", func(b byte) { s.SSR = &SensorReduction{REDRAD: b >> 5} }},
+		{subfieldMDS, "MDS", func(b byte) { s.MDS = &ModeSProcessing{REDRAD: b >> 5, CLU: b&0x10 != 0} }},
+	} {
+		if primary&sub.bit == 0 {
+			continue
+		}
+		b, err := buf.ReadByte()
+		if err != nil {
+			return bytesRead, fmt.Errorf("reading %s subfield: %w", sub.name, err)
+		}
+		bytesRead++
+		sub.decode(b)
+	}
+	return bytesRead, nil
+}
+
+func (s *SystemProcessingMode) Encode(buf *bytes.Buffer) (int, error) {
+	if err := s.Validate(); err != nil {
+		return 0, err
+	}
+
+	var primary byte
+	var data []byte
+	if c := s.COM; c != nil {
+		primary |= subfieldCOM
+		data = append(data, c.REDRDP<<4|c.REDXMT<<1)
+	}
+	if p := s.PSR; p != nil {
+		primary |= subfieldPSR
+		data = append(data, flags(p.POL)|p.REDRAD<<4|p.STC<<2)
+	}
+	if r := s.SSR; r != nil {
+		primary |= subfieldSSR
+		data = append(data, r.REDRAD<<5)
+	}
+	if m := s.MDS; m != nil {
+		primary |= subfieldMDS
+		data = append(data, m.REDRAD<<5|flags(false, false, false, m.CLU))
+	}
+
+	n, err := buf.Write(append([]byte{primary}, data...))
+	if err != nil {
+		return n, fmt.Errorf("writing system processing mode: %w", err)
+	}
+	return n, nil
+}
+
+func (s *SystemProcessingMode) Validate() error {
+	type reduction struct {
+		name  string
+		value uint8
+	}
+	var reductions []reduction
+	if s.COM != nil {
+		reductions = append(reductions, reduction{"COM RED-RDP", s.COM.REDRDP}, reduction{"COM RED-XMT", s.COM.REDXMT})
+	}
+	if s.PSR != nil {
+		if s.PSR.STC > 3 {
+			return fmt.Errorf("invalid PSR STC value: %d", s.PSR.STC)
+		}
+		reductions = append(reductions, reduction{"PSR RED-RAD", s.PSR.REDRAD})
+	}
+	if s.SSR != nil {
+		reductions = append(reductions, reduction{"SSR RED-RAD", s.SSR.REDRAD})
+	}
+	if s.MDS != nil {
+		reductions = append(reductions, reduction{"MDS RED-RAD", s.MDS.REDRAD})
+	}
+	for _, r := range reductions {
+		if r.value > 7 {
+			return fmt.Errorf("invalid %s value: %d", r.name, r.value)
+		}
+	}
+	return nil
+}
+
+func (s *SystemProcessingMode) String() string {
+	var parts []string
+	if c := s.COM; c != nil {
+		parts = append(parts, subfieldString("COM", []string{
+			fmt.Sprintf("RED-RDP %d", c.REDRDP), fmt.Sprintf("RED-XMT %d", c.REDXMT),
+		}))
+	}
+	if p := s.PSR; p != nil {
+		pol := "linear"
+		if p.POL {
+			pol = "circular"
+		}
+		parts = append(parts, subfieldString("PSR", []string{
+			pol, fmt.Sprintf("RED-RAD %d", p.REDRAD), fmt.Sprintf("STC map %d", p.STC+1),
+		}))
+	}
+	if r := s.SSR; r != nil {
+		parts = append(parts, subfieldString("SSR", []string{fmt.Sprintf("RED-RAD %d", r.REDRAD)}))
+	}
+	if m := s.MDS; m != nil {
+		clu := "cooperative"
+		if m.CLU {
+			clu = "autonomous"
+		}
+		parts = append(parts, subfieldString("MDS", []string{fmt.Sprintf("RED-RAD %d", m.REDRAD), clu}))
+	}
+	if len(parts) == 0 {
+		return "SystemProcessingMode[empty]"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,180 @@
+// cat/cat034/uap/uap_v127.go
+package uap
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v127 "github.com/davidkohl/gobelix/cat/cat034/dataitems/v127"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+// UAP034 implements the User Application Profile for ASTERIX Category 034
+type UAP034 struct {
+	*asterix.BaseUAP
+}
+
+// NewUAP034 creates a new instance of the Category 034 UAP
+func NewUAP034() (*UAP034, error) {
+	base, err := asterix.NewBaseUAP(asterix.Cat034, "1.27", cat034Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UAP034{
+		BaseUAP: base,
+	}, nil
+}
+
+// CreateDataItem creates a new instance of a Cat034 data item
+func (u *UAP034) CreateDataItem(id string) (asterix.DataItem, error) {
+	switch id {
+	case "I034/000":
+		return &v127.MessageType{}, nil
+	case "I034/010":
+		return &common.DataSourceIdentifier{}, nil
+	case "I034/020":
+		return &v127.SectorNumber{}, nil
+	case "I034/030":
+		return &common.TimeOfDay{}, nil
+	case "I034/050":
+		return &v127.SystemConfigurationAndStatus{}, nil
+	case "I034/060":
+		return &v127.SystemProcessingMode{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", asterix.ErrUnknownDataItem, id)
+	}
+}
+
+// Validate implements critical validations for Cat034
+func (u *UAP034) Validate(items map[string]asterix.DataItem) error {
+	// First do base validation (mandatory fields)
+	if err := u.BaseUAP.Validate(items); err != nil {
+		return err
+	}
+
+	// A sector crossing message must say which sector was crossed
+	if mt, ok := items["I034/000"].(*v127.MessageType); ok && mt.Value == v127.MessageTypeSectorCrossing {
+		if _, exists := items["I034/020"]; !exists {
+			return fmt.Errorf("%w: sector crossing message without I034/020", asterix.ErrMandatoryField)
+		}
+	}
+
+	return nil
+}
+
+// cat034Fields defines the complete UAP for Category 034
+var cat034Fields = []asterix.DataField{
+	{
+		FRN:         1,
+		DataItem:    "I034/010",
+		Description: "Data Source Identifier",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   true,
+	},
+	{
+		FRN:         2,
+		DataItem:    "I034/000",
+		Description: "Message Type",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   true,
+	},
+	{
+		FRN:         3,
+		DataItem:    "I034/030",
+		Description: "Time of Day",
+		Type:        asterix.Fixed,
+		Length:      3,
+		Mandatory:   true,
+	},
+	{
+		FRN:         4,
+		DataItem:    "I034/020",
+		Description: "Sector Number",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         5,
+		DataItem:    "I034/041",
+		Description: "Antenna Rotation Period",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         6,
+		DataItem:    "I034/050",
+		Description: "System Configuration and Status",
+		Type:        asterix.Compound,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         7,
+		DataItem:    "I034/060",
+		Description: "System Processing Mode",
+		Type:        asterix.Compound,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         8,
+		DataItem:    "I034/070",
+		Description: "Message Count Values",
+		Type:        asterix.Repetitive,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         9,
+		DataItem:    "I034/100",
+		Description: "Generic Polar Window",
+		Type:        asterix.Fixed,
+		Length:      8,
+		Mandatory:   false,
+	},
+	{
+		FRN:         10,
+		DataItem:    "I034/110",
+		Description: "Data Filter",
+		Type:        asterix.Fixed,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         11,
+		DataItem:    "I034/120",
+		Description: "3D Position of Data Source",
+		Type:        asterix.Fixed,
+		Length:      8,
+		Mandatory:   false,
+	},
+	{
+		FRN:         12,
+		DataItem:    "I034/090",
+		Description: "Collimation Error",
+		Type:        asterix.Fixed,
+		Length:      2,
+		Mandatory:   false,
+	},
+	{
+		FRN:         13,
+		DataItem:    "RE034",
+		Description: "Reserved Expansion Field",
+		Type:        asterix.Explicit,
+		Length:      1,
+		Mandatory:   false,
+	},
+	{
+		FRN:         14,
+		DataItem:    "SP034",
+		Description: "Special Purpose Field",
+		Type:        asterix.Explicit,
+		Length:      1,
+		Mandatory:   false,
+	},
+}
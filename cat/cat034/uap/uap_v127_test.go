@@ -0,0 +1,116 @@
+// cat/cat034/uap/uap_v127_test.go
+package uap_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat034"
+	v127 "github.com/davidkohl/gobelix/cat/cat034/dataitems/v127"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestUAP034_NorthMarkerRoundTrip(t *testing.T) {
+	uap, err := cat034.NewUAP(cat034.Version127)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	record, err := asterix.NewRecord(asterix.Cat034, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I034/010", &common.DataSourceIdentifier{SAC: 25, SIC: 10}},
+		{"I034/000", &v127.MessageType{Value: v127.MessageTypeNorthMarker}},
+		{"I034/030", &common.TimeOfDay{Time: 36000.5}},
+		{"I034/050", &v127.SystemConfigurationAndStatus{
+			COM: &v127.COMStatus{},
+			PSR: &v127.SensorStatus{CHAB: 1},
+			SSR: &v127.SensorStatus{CHAB: 1},
+			MDS: &v127.ModeSStatus{CHAB: 1},
+		}},
+		{"I034/060", &v127.SystemProcessingMode{
+			COM: &v127.COMProcessing{},
+			PSR: &v127.PSRProcessing{STC: 1},
+		}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat034, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(record); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	want := []byte{
+		34, 0x00, 0x13, // CAT, LEN
+		0xE6,       // FSPEC: 010, 000, 030, 050, 060
+		0x19, 0x0A, // I034/010
+		0x01,             // I034/000
+		0x46, 0x50, 0x40, // I034/030
+		0x9C, 0x00, 0x20, 0x20, 0x20, 0x00, // I034/050: COM, PSR, SSR, MDS
+		0x90, 0x00, 0x04, // I034/060: COM, PSR
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Fatalf("Encode() = % X, want % X", encoded, want)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat034, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatalf("DataBlock.Decode() error = %v", err)
+	}
+	if decoded.Length() != 1 {
+		t.Fatalf("Length() = %d, want 1", decoded.Length())
+	}
+	got := decoded.Records()[0]
+	for _, it := range items {
+		item, _, ok := got.GetDataItem(it.id)
+		if !ok {
+			t.Errorf("%s missing after decode", it.id)
+			continue
+		}
+		if !reflect.DeepEqual(item, it.item) {
+			t.Errorf("%s = %+v, want %+v", it.id, item, it.item)
+		}
+	}
+}
+
+func TestUAP034_SectorCrossingNeedsSector(t *testing.T) {
+	uap, err := cat034.NewUAP(cat034.Version127)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	items := map[string]asterix.DataItem{
+		"I034/010": &common.DataSourceIdentifier{SAC: 25, SIC: 10},
+		"I034/000": &v127.MessageType{Value: v127.MessageTypeSectorCrossing},
+		"I034/030": &common.TimeOfDay{Time: 36000.5},
+	}
+	if err := uap.Validate(items); !errors.Is(err, asterix.ErrMandatoryField) {
+		t.Errorf("Validate() without I034/020 error = %v, want %v", err, asterix.ErrMandatoryField)
+	}
+
+	items["I034/020"] = &v127.SectorNumber{Azimuth: 90}
+	if err := uap.Validate(items); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
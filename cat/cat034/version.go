@@ -0,0 +1,34 @@
+// cat/cat034/version.go
+package cat034
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat034/uap"
+)
+
+// Version constants
+const (
+	Version127 = "1.27"
+)
+
+// NewUAP returns the UAP for the specified version of CAT034
+func NewUAP(version string) (asterix.UAP, error) {
+	switch version {
+	case Version127:
+		return uap.NewUAP034()
+	default:
+		return nil, fmt.Errorf("%w: CAT034 %s", asterix.ErrUnsupportedVersion, version)
+	}
+}
+
+// LatestVersion returns the latest available version
+func LatestVersion() string {
+	return Version127
+}
+
+// AvailableVersions returns all supported versions
+func AvailableVersions() []string {
+	return []string{Version127}
+}
@@ -55,6 +55,11 @@ func (a *AircraftAddress) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (a *AircraftAddress) Reset() {
+	*a = AircraftAddress{}
+}
+
 // String returns a human-readable representation
 func (a *AircraftAddress) String() string {
 	return fmt.Sprintf("%06X", a.Address)
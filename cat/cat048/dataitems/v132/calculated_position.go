@@ -82,6 +82,11 @@ func (c *CalculatedPosition) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (c *CalculatedPosition) Reset() {
+	*c = CalculatedPosition{}
+}
+
 // String returns a human-readable representation
 func (c *CalculatedPosition) String() string {
 	return fmt.Sprintf("X: %.3f NM, Y: %.3f NM", c.X, c.Y)
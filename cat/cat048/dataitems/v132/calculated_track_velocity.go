@@ -87,6 +87,11 @@ func (c *CalculatedTrackVelocity) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (c *CalculatedTrackVelocity) Reset() {
+	*c = CalculatedTrackVelocity{}
+}
+
 // String returns a human-readable representation
 func (c *CalculatedTrackVelocity) String() string {
 	// Convert ground speed to knots for display (1 NM/s = 3600 knots)
@@ -0,0 +1,157 @@
+// cat/cat048/dataitems/v132/code_confidence_test.go
+package v132_test
+
+import (
+	"bytes"
+	"testing"
+
+	v132 "github.com/davidkohl/gobelix/cat/cat048/dataitems/v132"
+)
+
+func TestMode3ACodeConfidence_RoundTrip(t *testing.T) {
+	data := []byte{0x09, 0x81} // A4, B4, B2 and D1 low quality
+
+	var m v132.Mode3ACodeConfidence
+	if _, err := m.Decode(bytes.NewBuffer(data)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !m.QA4 || !m.QB4 || !m.QB2 || !m.QD1 || m.QA2 || m.QC1 {
+		t.Errorf("Decode() = %+v, want A4, B4, B2 and D1 low quality", m)
+	}
+	if got := m.Confidence(); got != 0x981 {
+		t.Errorf("Confidence() = %#03x, want 0x981", got)
+	}
+
+	var buf bytes.Buffer
+	if _, err := m.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("Encode() = % X, want % X", buf.Bytes(), data)
+	}
+
+	// The code itself is carried by I048/070 and formats octally
+	code := v132.Mode3ACode{}
+	if _, err := code.Decode(bytes.NewBuffer([]byte{0x0F, 0xC0})); err != nil {
+		t.Fatalf("Mode3ACode.Decode() error = %v", err)
+	}
+	if code.String() != "7700" {
+		t.Errorf("Mode3ACode.String() = %q, want %q", code.String(), "7700")
+	}
+}
+
+func TestModeCCodeAndConfidence_RoundTrip(t *testing.T) {
+	data := []byte{0x40, 0x21, 0x08, 0x20} // G, B1 and D4 set, C1 and B1 low quality
+
+	var m v132.ModeCCodeAndConfidence
+	if _, err := m.Decode(bytes.NewBuffer(data)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if m.V || !m.G || m.Code != 0x021 {
+		t.Errorf("Decode() V = %v, G = %v, Code = %#03x; want false, true, 0x021", m.V, m.G, m.Code)
+	}
+	if got := m.Confidence(); got != 0x820 {
+		t.Errorf("Confidence() = %#03x, want 0x820", got)
+	}
+
+	var buf bytes.Buffer
+	if _, err := m.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("Encode() = % X, want % X", buf.Bytes(), data)
+	}
+}
+
+func TestModeCCodeAndConfidence_AltitudeFeet(t *testing.T) {
+	// C2 A2 A4 B1 B4 is 10000 ft
+	data := []byte{0x03, 0x62, 0x00, 0x00}
+
+	var item v132.ModeCCodeAndConfidence
+	if _, err := item.Decode(bytes.NewBuffer(data)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if feet, ok := item.AltitudeFeet(); !ok || feet != 10000 {
+		t.Errorf("AltitudeFeet() = %d, %v, want 10000", feet, ok)
+	}
+
+	item.Code = 0x880 // C1 C4 is not a legal 100 ft pattern
+	if feet, ok := item.AltitudeFeet(); ok {
+		t.Errorf("AltitudeFeet() = %d for an illegal code, want invalid", feet)
+	}
+}
+
+func TestMode1Code_RoundTrip(t *testing.T) {
+	tests := []struct {
+		item v132.Mode1Code
+		raw  byte
+		str  string
+	}{
+		{v132.Mode1Code{Code: 73}, 0x1F, "73"},
+		{v132.Mode1Code{G: true, Code: 12}, 0x46, "G 12"},
+		{v132.Mode1Code{V: true, G: true, L: true, Code: 0}, 0xE0, "V,G,L 00"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if _, err := tt.item.Encode(&buf); err != nil {
+			t.Fatalf("Encode(%+v) error = %v", tt.item, err)
+		}
+		if !bytes.Equal(buf.Bytes(), []byte{tt.raw}) {
+			t.Fatalf("Encode(%+v) = % X, want %02X", tt.item, buf.Bytes(), tt.raw)
+		}
+
+		var out v132.Mode1Code
+		if _, err := out.Decode(&buf); err != nil {
+			t.Fatalf("Decode(%02X) error = %v", tt.raw, err)
+		}
+		if out != tt.item {
+			t.Errorf("Decode(%02X) = %+v, want %+v", tt.raw, out, tt.item)
+		}
+		if out.String() != tt.str {
+			t.Errorf("String() = %q, want %q", out.String(), tt.str)
+		}
+		if out.Validated() == tt.item.V || out.Garbled() != tt.item.G || out.Smoothed() != tt.item.L {
+			t.Errorf("flag accessors disagree with %+v", out)
+		}
+	}
+
+	// Digit B has only two bits
+	if err := (&v132.Mode1Code{Code: 14}).Validate(); err == nil {
+		t.Errorf("Validate() accepted Mode-1 digit B of 4")
+	}
+}
+
+func TestMode2Code_RoundTrip(t *testing.T) {
+	tests := []struct {
+		item v132.Mode2Code
+		raw  []byte
+		str  string
+	}{
+		{v132.Mode2Code{Code: 7777}, []byte{0x0F, 0xFF}, "7777"},
+		{v132.Mode2Code{G: true, Code: 1234}, []byte{0x42, 0x9C}, "G 1234"},
+		{v132.Mode2Code{V: true, L: true, Code: 5}, []byte{0xA0, 0x05}, "V,L 0005"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if _, err := tt.item.Encode(&buf); err != nil {
+			t.Fatalf("Encode(%+v) error = %v", tt.item, err)
+		}
+		if !bytes.Equal(buf.Bytes(), tt.raw) {
+			t.Fatalf("Encode(%+v) = % X, want % X", tt.item, buf.Bytes(), tt.raw)
+		}
+
+		var out v132.Mode2Code
+		if _, err := out.Decode(&buf); err != nil {
+			t.Fatalf("Decode(% X) error = %v", tt.raw, err)
+		}
+		if out != tt.item {
+			t.Errorf("Decode(% X) = %+v, want %+v", tt.raw, out, tt.item)
+		}
+		if out.String() != tt.str {
+			t.Errorf("String() = %q, want %q", out.String(), tt.str)
+		}
+		if out.Validated() == tt.item.V || out.Garbled() != tt.item.G || out.Smoothed() != tt.item.L {
+			t.Errorf("flag accessors disagree with %+v", out)
+		}
+	}
+}
@@ -4,6 +4,8 @@ package v132
 import (
 	"bytes"
 	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
 )
 
 // BDSRegister represents a single BDS register with its data and identification
@@ -13,6 +15,11 @@ type BDSRegister struct {
 	BDS2 uint8  // BDS Register Address 2 (4 bits)
 }
 
+// ModeSMB is the Cat062 I062/380 name for a BDS register entry.
+// The layout (7 data octets, BDS1 in bits 8-5 and BDS2 in bits 4-1 of the
+// last octet) is identical in both categories.
+type ModeSMB = BDSRegister
+
 // BDSRegisterData implements I048/250
 // BDS Register Data as extracted from the aircraft transponder
 type BDSRegisterData struct {
@@ -21,50 +28,19 @@ type BDSRegisterData struct {
 
 // Decode implements the DataItem interface
 func (b *BDSRegisterData) Decode(buf *bytes.Buffer) (int, error) {
-	bytesRead := 0
 	b.Registers = nil
 
-	// Read repetition factor
-	repByte := make([]byte, 1)
-	n, err := buf.Read(repByte)
+	elems, bytesRead, err := asterix.ReadRepetitive(buf, 8)
 	if err != nil {
-		return bytesRead, fmt.Errorf("reading BDS register repetition: %w", err)
-	}
-	bytesRead += n
-
-	repetitions := int(repByte[0])
-	if repetitions == 0 {
-		return bytesRead, nil // No registers
+		return bytesRead, fmt.Errorf("reading BDS registers: %w", err)
 	}
 
-	// Read each register
-	for i := 0; i < repetitions; i++ {
+	for _, elem := range elems {
 		register := BDSRegister{
-			Data: make([]byte, 7),
-		}
-
-		// Read 7 bytes of register data
-		m, err := buf.Read(register.Data)
-		if err != nil {
-			return bytesRead + m, fmt.Errorf("reading BDS register data: %w", err)
+			Data: elem[:7],
+			BDS1: (elem[7] >> 4) & 0x0F, // bits 8-5
+			BDS2: elem[7] & 0x0F,        // bits 4-1
 		}
-		bytesRead += m
-
-		if m != 7 {
-			return bytesRead, fmt.Errorf("insufficient data for BDS register: got %d bytes, want 7", m)
-		}
-
-		// Read BDS1/BDS2
-		bdsBytes := make([]byte, 1)
-		p, err := buf.Read(bdsBytes)
-		if err != nil {
-			return bytesRead + p, fmt.Errorf("reading BDS register code: %w", err)
-		}
-		bytesRead += p
-
-		register.BDS1 = (bdsBytes[0] >> 4) & 0x0F // bits 8-5
-		register.BDS2 = bdsBytes[0] & 0x0F        // bits 4-1
-
 		b.Registers = append(b.Registers, register)
 	}
 
@@ -77,35 +53,19 @@ func (b *BDSRegisterData) Encode(buf *bytes.Buffer) (int, error) {
 		return 0, err
 	}
 
-	bytesWritten := 0
-
-	// Write repetition factor
-	rep := byte(len(b.Registers))
-	err := buf.WriteByte(rep)
-	if err != nil {
-		return bytesWritten, fmt.Errorf("writing BDS register repetition: %w", err)
-	}
-	bytesWritten++
-
-	// Write each register
+	elems := make([][]byte, len(b.Registers))
 	for i, register := range b.Registers {
-		// Write 7 bytes of register data
-		n, err := buf.Write(register.Data)
-		if err != nil {
-			return bytesWritten + n, fmt.Errorf("writing BDS register data: %w", err)
-		}
-		bytesWritten += n
-
-		// Write BDS1/BDS2
-		bdsCode := byte(register.BDS1<<4 | register.BDS2)
-		err = buf.WriteByte(bdsCode)
-		if err != nil {
-			return bytesWritten, fmt.Errorf("writing BDS register code for register %d: %w", i, err)
-		}
-		bytesWritten++
+		elem := make([]byte, 8)
+		copy(elem, register.Data)
+		elem[7] = register.BDS1<<4 | register.BDS2
+		elems[i] = elem
 	}
 
-	return bytesWritten, nil
+	n, err := asterix.WriteRepetitive(buf, 8, elems)
+	if err != nil {
+		return n, fmt.Errorf("writing BDS registers: %w", err)
+	}
+	return n, nil
 }
 
 // Validate implements the DataItem interface
@@ -126,6 +86,11 @@ func (b *BDSRegisterData) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (b *BDSRegisterData) Reset() {
+	*b = BDSRegisterData{}
+}
+
 // String returns a human-readable representation
 func (b *BDSRegisterData) String() string {
 	if len(b.Registers) == 0 {
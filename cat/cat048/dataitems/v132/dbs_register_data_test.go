@@ -0,0 +1,71 @@
+// cat/cat048/dataitems/v132/dbs_register_data_test.go
+package v132_test
+
+import (
+	"bytes"
+	"testing"
+
+	v132 "github.com/davidkohl/gobelix/cat/cat048/dataitems/v132"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+)
+
+func TestBDSRegisterData_EncodeDecode(t *testing.T) {
+	input := v132.BDSRegisterData{
+		Registers: []v132.ModeSMB{
+			{Data: []byte{0x10, 0x20, 0x30, 0x40, 0x50, 0x60, 0x70}, BDS1: 4, BDS2: 0},
+			{Data: []byte{0xA1, 0xB2, 0xC3, 0xD4, 0xE5, 0xF6, 0x07}, BDS1: 6, BDS2: 0},
+		},
+	}
+	expected := []byte{
+		0x02,
+		0x10, 0x20, 0x30, 0x40, 0x50, 0x60, 0x70, 0x40,
+		0xA1, 0xB2, 0xC3, 0xD4, 0xE5, 0xF6, 0x07, 0x60,
+	}
+
+	buf := new(bytes.Buffer)
+	n, err := input.Encode(buf)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if n != len(expected) {
+		t.Errorf("Encode() returned n = %v, want %v", n, len(expected))
+	}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("Encode() = % X, want % X", buf.Bytes(), expected)
+	}
+
+	decoded := &v132.BDSRegisterData{}
+	n, err = decoded.Decode(bytes.NewBuffer(expected))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if n != len(expected) {
+		t.Errorf("Decode() returned n = %v, want %v", n, len(expected))
+	}
+	if len(decoded.Registers) != 2 {
+		t.Fatalf("Decode() registers = %d, want 2", len(decoded.Registers))
+	}
+	for i, want := range input.Registers {
+		got := decoded.Registers[i]
+		if got.BDS1 != want.BDS1 || got.BDS2 != want.BDS2 {
+			t.Errorf("register %d BDS = %X,%X, want %X,%X", i, got.BDS1, got.BDS2, want.BDS1, want.BDS2)
+		}
+		if !bytes.Equal(got.Data, want.Data) {
+			t.Errorf("register %d data = % X, want % X", i, got.Data, want.Data)
+		}
+	}
+
+	// The I062/380 Mode S MB subfield uses the same element layout, so the
+	// Cat062 encoding must end with exactly the same bytes
+	adr := &v117.AircraftDerivedData{}
+	for _, r := range input.Registers {
+		adr.ModeSMBData = append(adr.ModeSMBData, v117.ModeSMB{Data: r.Data, BDS1: r.BDS1, BDS2: r.BDS2})
+	}
+	cat062Buf := new(bytes.Buffer)
+	if _, err := adr.Encode(cat062Buf); err != nil {
+		t.Fatalf("I062/380 Encode() error = %v", err)
+	}
+	if !bytes.HasSuffix(cat062Buf.Bytes(), expected) {
+		t.Errorf("I062/380 Mode S MB = % X, want suffix % X", cat062Buf.Bytes(), expected)
+	}
+}
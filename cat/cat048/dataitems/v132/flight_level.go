@@ -9,7 +9,7 @@ import (
 // FlightLevel implements I048/090
 // Flight Level converted into binary representation.
 type FlightLevel struct {
-	V     bool    // Code validated
+	V     bool    // Code not validated
 	G     bool    // Garbled code
 	Level float64 // Flight Level
 }
@@ -80,6 +80,11 @@ func (f *FlightLevel) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (f *FlightLevel) Reset() {
+	*f = FlightLevel{}
+}
+
 // String returns a human-readable representation
 func (f *FlightLevel) String() string {
 	flags := ""
@@ -82,7 +82,23 @@ func (m *MeasuredPosition) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (m *MeasuredPosition) Reset() {
+	*m = MeasuredPosition{}
+}
+
 // String returns a human-readable representation
 func (m *MeasuredPosition) String() string {
 	return fmt.Sprintf("RHO: %.3f NM, THETA: %.3f°", m.RHO, m.THETA)
 }
+
+// GetField implements asterix.FieldGetter for RHO and THETA
+func (m *MeasuredPosition) GetField(name string) (any, bool) {
+	switch name {
+	case "RHO":
+		return m.RHO, true
+	case "THETA":
+		return m.THETA, true
+	}
+	return nil, false
+}
@@ -9,7 +9,7 @@ import (
 // Mode1Code implements I048/055
 // Reply to Mode-1 interrogation.
 type Mode1Code struct {
-	V    bool  // Code validated
+	V    bool  // Code not validated
 	G    bool  // Garbled code
 	L    bool  // Mode-1 code derived/smoothed
 	Code uint8 // Mode-1 code in octal (2 digits)
@@ -76,12 +76,12 @@ func (m *Mode1Code) Encode(buf *bytes.Buffer) (int, error) {
 
 // Validate implements the DataItem interface
 func (m *Mode1Code) Validate() error {
-	// Check that each digit is a valid octal digit (0-7)
-	a := (m.Code / 10) % 10
+	// Digit A has three bits (0-7), digit B only two (0-3)
+	a := m.Code / 10
 	b := m.Code % 10
 
-	if a > 7 || b > 7 {
-		return fmt.Errorf("invalid octal digit in Mode-1 code: %02o", m.Code)
+	if a > 7 || b > 3 {
+		return fmt.Errorf("invalid digit in Mode-1 code: %02d", m.Code)
 	}
 
 	return nil
@@ -89,20 +89,22 @@ func (m *Mode1Code) Validate() error {
 
 // String returns a human-readable representation
 func (m *Mode1Code) String() string {
-	flags := ""
-	if m.V {
-		flags += "V,"
-	}
-	if m.G {
-		flags += "G,"
-	}
-	if m.L {
-		flags += "L,"
-	}
+	// Code already holds the octal digits as decimal digits
+	return fmt.Sprintf("%s%02d", codeFlags(m.V, m.G, m.L), m.Code)
+}
 
-	if flags != "" {
-		flags = flags[:len(flags)-1] + " " // Remove trailing comma
-	}
+// Validated reports whether the code was validated (V bit clear)
+func (m *Mode1Code) Validated() bool {
+	return !m.V
+}
+
+// Garbled reports whether the reply was garbled (G bit set)
+func (m *Mode1Code) Garbled() bool {
+	return m.G
+}
 
-	return fmt.Sprintf("%s%02o", flags, m.Code)
+// Smoothed reports whether the code was smoothed rather than extracted
+// from the last scan (L bit set)
+func (m *Mode1Code) Smoothed() bool {
+	return m.L
 }
@@ -9,7 +9,7 @@ import (
 // Mode2Code implements I048/050
 // Reply to Mode-2 interrogation.
 type Mode2Code struct {
-	V    bool   // Code validated
+	V    bool   // Code not validated
 	G    bool   // Garbled code
 	L    bool   // Mode-2 code derived/smoothed
 	Code uint16 // Mode-2 code in octal
@@ -92,7 +92,7 @@ func (m *Mode2Code) Validate() error {
 	d := m.Code % 10
 
 	if a > 7 || b > 7 || c > 7 || d > 7 {
-		return fmt.Errorf("invalid octal digit in Mode-2 code: %04o", m.Code)
+		return fmt.Errorf("invalid octal digit in Mode-2 code: %04d", m.Code)
 	}
 
 	return nil
@@ -100,20 +100,22 @@ func (m *Mode2Code) Validate() error {
 
 // String returns a human-readable representation
 func (m *Mode2Code) String() string {
-	flags := ""
-	if m.V {
-		flags += "V,"
-	}
-	if m.G {
-		flags += "G,"
-	}
-	if m.L {
-		flags += "L,"
-	}
+	// Code already holds the octal digits as decimal digits
+	return fmt.Sprintf("%s%04d", codeFlags(m.V, m.G, m.L), m.Code)
+}
 
-	if flags != "" {
-		flags = flags[:len(flags)-1] + " " // Remove trailing comma
-	}
+// Validated reports whether the code was validated (V bit clear)
+func (m *Mode2Code) Validated() bool {
+	return !m.V
+}
+
+// Garbled reports whether the reply was garbled (G bit set)
+func (m *Mode2Code) Garbled() bool {
+	return m.G
+}
 
-	return fmt.Sprintf("%s%04o", flags, m.Code)
+// Smoothed reports whether the code was smoothed rather than extracted
+// from the last scan (L bit set)
+func (m *Mode2Code) Smoothed() bool {
+	return m.L
 }
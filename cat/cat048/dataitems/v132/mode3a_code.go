@@ -4,12 +4,13 @@ package v132
 import (
 	"bytes"
 	"fmt"
+	"strings"
 )
 
 // Mode3ACode implements I048/070
 // Mode-3/A code converted into octal representation.
 type Mode3ACode struct {
-	V    bool   // Code validated
+	V    bool   // Code not validated
 	G    bool   // Garbled code
 	L    bool   // Mode-3/A code derived/not extracted
 	Code uint16 // Mode-3/A reply in octal representation
@@ -92,28 +93,38 @@ func (m *Mode3ACode) Validate() error {
 	d := m.Code % 10
 
 	if a > 7 || b > 7 || c > 7 || d > 7 {
-		return fmt.Errorf("invalid octal digit in Mode-3/A code: %04o", m.Code)
+		return fmt.Errorf("invalid octal digit in Mode-3/A code: %04d", m.Code)
 	}
 
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (m *Mode3ACode) Reset() {
+	*m = Mode3ACode{}
+}
+
 // String returns a human-readable representation
 func (m *Mode3ACode) String() string {
-	flags := ""
-	if m.V {
-		flags += "V,"
+	// Code already holds the octal digits as decimal digits
+	return fmt.Sprintf("%s%04d", codeFlags(m.V, m.G, m.L), m.Code)
+}
+
+// codeFlags formats the V, G and L flags shared by the Mode-1, Mode-2 and
+// Mode-3/A code items as a prefix such as "V,G ", or "" when none is set
+func codeFlags(v, g, l bool) string {
+	var flags []string
+	if v {
+		flags = append(flags, "V")
 	}
-	if m.G {
-		flags += "G,"
+	if g {
+		flags = append(flags, "G")
 	}
-	if m.L {
-		flags += "L,"
+	if l {
+		flags = append(flags, "L")
 	}
-
-	if flags != "" {
-		flags = flags[:len(flags)-1] + " " // Remove trailing comma
+	if len(flags) == 0 {
+		return ""
 	}
-
-	return fmt.Sprintf("%s%04o", flags, m.Code)
+	return strings.Join(flags, ",") + " "
 }
@@ -163,3 +163,17 @@ func (m *Mode3ACodeConfidence) HasLowQualityPulses() bool {
 	return m.QA4 || m.QA2 || m.QA1 || m.QB4 || m.QB2 || m.QB1 ||
 		m.QC4 || m.QC2 || m.QC1 || m.QD4 || m.QD2 || m.QD1
 }
+
+// Confidence returns the quality bits as a 12-bit mask in the order of the
+// item (A4 in bit 12 down to D1 in bit 1), which is also the bit order of
+// the Mode-3/A code in I048/070. A set bit marks a low-quality pulse.
+func (m *Mode3ACodeConfidence) Confidence() uint16 {
+	var mask uint16
+	for i, low := range []bool{m.QA4, m.QA2, m.QA1, m.QB4, m.QB2, m.QB1,
+		m.QC4, m.QC2, m.QC1, m.QD4, m.QD2, m.QD1} {
+		if low {
+			mask |= 0x800 >> i
+		}
+	}
+	return mask
+}
@@ -4,13 +4,15 @@ package v132
 import (
 	"bytes"
 	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix/modec"
 )
 
 // ModeCCodeAndConfidence implements I048/100
 // Mode-C height in Gray notation as received from the transponder together with
 // the confidence level for each reply bit as provided by a MSSR/Mode S station.
 type ModeCCodeAndConfidence struct {
-	V    bool   // Code validated
+	V    bool   // Code not validated
 	G    bool   // Garbled code
 	Code uint16 // Raw Mode-C code in Gray code format
 
@@ -289,3 +291,24 @@ func (m *ModeCCodeAndConfidence) HasLowQualityPulses() bool {
 	return m.QC1 || m.QA1 || m.QC2 || m.QA2 || m.QC4 || m.QA4 ||
 		m.QB1 || m.QD1 || m.QB2 || m.QD2 || m.QB4 || m.QD4
 }
+
+// Confidence returns the quality bits as a 12-bit mask in the order of the
+// item (C1 in bit 12 down to D4 in bit 1), which matches the bit order of
+// Code. A set bit marks a low-quality pulse.
+func (m *ModeCCodeAndConfidence) Confidence() uint16 {
+	var mask uint16
+	for i, low := range []bool{m.QC1, m.QA1, m.QC2, m.QA2, m.QC4, m.QA4,
+		m.QB1, m.QD1, m.QB2, m.QD2, m.QB4, m.QD4} {
+		if low {
+			mask |= 0x800 >> i
+		}
+	}
+	return mask
+}
+
+// AltitudeFeet decodes the Gray-coded reply into pressure altitude in feet.
+// ok is false when the code is not a legal Mode-C altitude. The V and G
+// flags are not taken into account.
+func (m *ModeCCodeAndConfidence) AltitudeFeet() (int, bool) {
+	return modec.Altitude(m.Code)
+}
@@ -289,6 +289,11 @@ func (r *RadarPlotCharacteristics) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (r *RadarPlotCharacteristics) Reset() {
+	*r = RadarPlotCharacteristics{}
+}
+
 // String returns a human-readable representation
 func (r *RadarPlotCharacteristics) String() string {
 	result := "Plot Characteristics:"
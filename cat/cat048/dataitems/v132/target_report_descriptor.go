@@ -361,6 +361,11 @@ func (t *TargetReportDescriptor) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TargetReportDescriptor) Reset() {
+	*t = TargetReportDescriptor{}
+}
+
 // String returns a human-readable representation
 func (t *TargetReportDescriptor) String() string {
 	var parts []string
@@ -67,6 +67,11 @@ func (t *TimeOfDay) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TimeOfDay) Reset() {
+	*t = TimeOfDay{}
+}
+
 // String returns a human-readable representation
 func (t *TimeOfDay) String() string {
 	// Convert to hours, minutes, seconds
@@ -56,6 +56,11 @@ func (t *TrackNumber) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TrackNumber) Reset() {
+	*t = TrackNumber{}
+}
+
 // String returns a human-readable representation
 func (t *TrackNumber) String() string {
 	return fmt.Sprintf("%d", t.Value)
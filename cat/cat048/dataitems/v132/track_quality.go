@@ -110,6 +110,11 @@ func (t *TrackQuality) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TrackQuality) Reset() {
+	*t = TrackQuality{}
+}
+
 // String returns a human-readable representation
 func (t *TrackQuality) String() string {
 	return fmt.Sprintf("σX: %.5f NM, σY: %.5f NM, σV: %.5f NM/s (%.1f kt), σH: %.3f°",
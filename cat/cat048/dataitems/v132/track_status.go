@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
 )
 
 // TrackStatus implements I048/170
@@ -25,44 +27,46 @@ type TrackStatus struct {
 
 	// Track which extensions are present
 	extension bool
+
+	maxFXDepth int // Set by SetMaxFXDepth, 0 for the default depth
 }
 
-// Decode implements the DataItem interface
-func (t *TrackStatus) Decode(buf *bytes.Buffer) (int, error) {
-	bytesRead := 0
+// SetMaxFXDepth implements asterix.FXDepthLimiter
+func (t *TrackStatus) SetMaxFXDepth(octets int) {
+	t.maxFXDepth = octets
+}
 
-	// First Part
-	b, err := buf.ReadByte()
+// Decode implements the DataItem interface. Octets beyond the first
+// extension are not defined by the specification; they are consumed so the
+// record stays aligned, but ignored.
+func (t *TrackStatus) Decode(buf *bytes.Buffer) (int, error) {
+	octets, err := asterix.ReadFXChain(buf, t.maxFXDepth)
 	if err != nil {
-		return bytesRead, fmt.Errorf("reading track status: %w", err)
+		return len(octets), fmt.Errorf("reading track status: %w", err)
 	}
-	bytesRead++
 
+	// First Part
+	b := octets[0]
 	t.CNF = (b & 0x80) != 0 // bit 8
-	t.RAD = (b >> 6) & 0x03 // bits 7-6
-	t.DOU = (b & 0x20) != 0 // bit 5
-	t.MAH = (b & 0x10) != 0 // bit 4
-	t.CDM = (b >> 2) & 0x03 // bits 3-2
-	fx := (b & 0x01) != 0   // bit 1 (FX)
+	t.RAD = (b >> 5) & 0x03 // bits 7-6
+	t.DOU = (b & 0x10) != 0 // bit 5
+	t.MAH = (b & 0x08) != 0 // bit 4
+	t.CDM = (b >> 1) & 0x03 // bits 3-2
 
 	// First Extension
-	if fx {
-		t.extension = true
-		b, err = buf.ReadByte()
-		if err != nil {
-			return bytesRead, fmt.Errorf("reading track status extension: %w", err)
-		}
-		bytesRead++
-
+	t.extension = len(octets) > 1
+	if t.extension {
+		b = octets[1]
 		t.TRE = (b & 0x80) != 0 // bit 8
 		t.GHO = (b & 0x40) != 0 // bit 7
 		t.SUP = (b & 0x20) != 0 // bit 6
 		t.TCC = (b & 0x10) != 0 // bit 5
 		// bits 4-2 are spare
-		// bit 1 (FX) is not used in this specification
+	} else {
+		t.TRE, t.GHO, t.SUP, t.TCC = false, false, false, false
 	}
 
-	return bytesRead, t.Validate()
+	return len(octets), t.Validate()
 }
 
 // Encode implements the DataItem interface
@@ -71,57 +75,48 @@ func (t *TrackStatus) Encode(buf *bytes.Buffer) (int, error) {
 		return 0, err
 	}
 
-	bytesWritten := 0
-
 	// First Part
 	b := byte(0)
 	if t.CNF {
 		b |= 0x80 // bit 8
 	}
-	b |= (t.RAD & 0x03) << 6 // bits 7-6
+	b |= (t.RAD & 0x03) << 5 // bits 7-6
 	if t.DOU {
-		b |= 0x20 // bit 5
+		b |= 0x10 // bit 5
 	}
 	if t.MAH {
-		b |= 0x10 // bit 4
-	}
-	b |= (t.CDM & 0x03) << 2 // bits 3-2
-	if t.extension {
-		b |= 0x01 // bit 1 (FX)
+		b |= 0x08 // bit 4
 	}
-
-	err := buf.WriteByte(b)
-	if err != nil {
-		return bytesWritten, fmt.Errorf("writing track status: %w", err)
+	b |= (t.CDM & 0x03) << 1 // bits 3-2
+	if !t.extension {
+		if err := buf.WriteByte(b); err != nil {
+			return 0, fmt.Errorf("writing track status: %w", err)
+		}
+		return 1, nil
 	}
-	bytesWritten++
+	b |= 0x01 // bit 1 (FX)
 
 	// First Extension
-	if t.extension {
-		b = 0
-		if t.TRE {
-			b |= 0x80 // bit 8
-		}
-		if t.GHO {
-			b |= 0x40 // bit 7
-		}
-		if t.SUP {
-			b |= 0x20 // bit 6
-		}
-		if t.TCC {
-			b |= 0x10 // bit 5
-		}
-		// bits 4-2 are spare
-		// bit 1 (FX) is not used in this specification
-
-		err := buf.WriteByte(b)
-		if err != nil {
-			return bytesWritten, fmt.Errorf("writing track status extension: %w", err)
-		}
-		bytesWritten++
+	ext := byte(0)
+	if t.TRE {
+		ext |= 0x80 // bit 8
+	}
+	if t.GHO {
+		ext |= 0x40 // bit 7
+	}
+	if t.SUP {
+		ext |= 0x20 // bit 6
+	}
+	if t.TCC {
+		ext |= 0x10 // bit 5
 	}
+	// bits 4-2 are spare, bit 1 (FX) is always 0
 
-	return bytesWritten, nil
+	n, err := buf.Write([]byte{b, ext})
+	if err != nil {
+		return n, fmt.Errorf("writing track status: %w", err)
+	}
+	return n, nil
 }
 
 // Validate implements the DataItem interface
@@ -135,6 +130,11 @@ func (t *TrackStatus) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TrackStatus) Reset() {
+	*t = TrackStatus{}
+}
+
 // String returns a human-readable representation
 func (t *TrackStatus) String() string {
 	var parts []string
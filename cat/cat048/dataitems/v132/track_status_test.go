@@ -0,0 +1,90 @@
+// cat/cat048/dataitems/v132/track_status_test.go
+package v132_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	v132 "github.com/davidkohl/gobelix/cat/cat048/dataitems/v132"
+)
+
+func TestTrackStatus_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		status v132.TrackStatus
+		raw    []byte
+		desc   []string
+	}{
+		{
+			name:   "confirmed SSR track",
+			status: v132.TrackStatus{RAD: 2},
+			raw:    []byte{0x40},
+			desc:   []string{"Confirmed", "SSR/Mode S", "Maintaining"},
+		},
+		{
+			name:   "tentative climbing track in manoeuvre",
+			status: v132.TrackStatus{CNF: true, RAD: 1, DOU: true, MAH: true, CDM: 1},
+			raw:    []byte{0xBA},
+			desc:   []string{"Tentative", "PSR", "Low Confidence", "Horizontal Maneuver", "Climbing"},
+		},
+		{
+			name:   "ghost at end of track",
+			status: v132.TrackStatus{CDM: 2, TRE: true, GHO: true},
+			raw:    []byte{0x05, 0xC0},
+			desc:   []string{"Confirmed", "Descending", "End of Track", "Ghost"},
+		},
+		{
+			name:   "slant corrected network track",
+			status: v132.TrackStatus{CNF: true, SUP: true, TCC: true},
+			raw:    []byte{0x81, 0x30},
+			desc:   []string{"Tentative", "Network Assisted", "Slant Corrected"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := tt.status
+			in.SetExtension()
+
+			var buf bytes.Buffer
+			n, err := in.Encode(&buf)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if n != len(tt.raw) || !bytes.Equal(buf.Bytes(), tt.raw) {
+				t.Fatalf("Encode() = % X (%d), want % X", buf.Bytes(), n, tt.raw)
+			}
+
+			var out v132.TrackStatus
+			if n, err := out.Decode(bytes.NewBuffer(tt.raw)); err != nil || n != len(tt.raw) {
+				t.Fatalf("Decode() = %d, %v, want %d bytes", n, err, len(tt.raw))
+			}
+			if out != in {
+				t.Errorf("Decode() = %+v, want %+v", out, in)
+			}
+			for _, want := range tt.desc {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("String() = %q, missing %q", out.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestTrackStatus_DecodeSkipsUndefinedExtensions(t *testing.T) {
+	// A third octet is not defined; it is consumed but ignored
+	buf := bytes.NewBuffer([]byte{0x81, 0x41, 0x00, 0xAA})
+
+	var ts v132.TrackStatus
+	n, err := ts.Decode(buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if n != 3 || buf.Len() != 1 {
+		t.Errorf("Decode() consumed %d bytes, %d left, want 3 and 1", n, buf.Len())
+	}
+	if !ts.CNF || !ts.GHO {
+		t.Errorf("Decode() = %+v, want CNF and GHO", ts)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
 )
 
 // WarningErrorCondition implements I048/030
@@ -13,6 +15,12 @@ import (
 type WarningErrorCondition struct {
 	Codes      []uint8 // List of warning/error/classification codes
 	extensions uint8   // Number of extensions present
+	maxFXDepth int     // Set by SetMaxFXDepth, 0 for the default depth
+}
+
+// SetMaxFXDepth implements asterix.FXDepthLimiter
+func (w *WarningErrorCondition) SetMaxFXDepth(octets int) {
+	w.maxFXDepth = octets
 }
 
 // Code values as defined in the specification
@@ -58,35 +66,21 @@ const (
 
 // Decode implements the DataItem interface
 func (w *WarningErrorCondition) Decode(buf *bytes.Buffer) (int, error) {
-	bytesRead := 0
 	w.Codes = w.Codes[:0] // Reset existing codes
 
-	// Keep reading extensions as long as FX bit is set
-	for {
-		b, err := buf.ReadByte()
-		if err != nil {
-			return bytesRead, fmt.Errorf("reading warning/error condition: %w", err)
-		}
-		bytesRead++
-
-		code := (b >> 1) & 0x7F // bits 8-2
-		if code > 0 {           // Only add non-zero codes
+	// One code per octet, extended as long as the FX bit is set
+	octets, err := asterix.ReadFXChain(buf, w.maxFXDepth)
+	if err != nil {
+		return len(octets), fmt.Errorf("reading warning/error condition: %w", err)
+	}
+	for _, b := range octets {
+		if code := b >> 1; code > 0 { // bits 8-2, only non-zero codes are kept
 			w.Codes = append(w.Codes, code)
 		}
-
-		fx := (b & 0x01) != 0 // bit 1 (FX)
-		if !fx {
-			break // No more extensions
-		}
-		w.extensions++
-
-		// Safety check to prevent infinite loops
-		if w.extensions > 10 { // Arbitrary limit
-			return bytesRead, fmt.Errorf("too many extensions in warning/error condition")
-		}
 	}
+	w.extensions = uint8(len(octets) - 1)
 
-	return bytesRead, w.Validate()
+	return len(octets), w.Validate()
 }
 
 // Encode implements the DataItem interface
@@ -137,6 +131,11 @@ func (w *WarningErrorCondition) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (w *WarningErrorCondition) Reset() {
+	*w = WarningErrorCondition{}
+}
+
 // String returns a human-readable representation
 func (w *WarningErrorCondition) String() string {
 	if len(w.Codes) == 0 {
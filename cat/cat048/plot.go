@@ -0,0 +1,35 @@
+// cat/cat048/plot.go
+package cat048
+
+import (
+	"math"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/geo"
+	v132 "github.com/davidkohl/gobelix/cat/cat048/dataitems/v132"
+)
+
+// metersPerNM is the length of one nautical mile in metres
+const metersPerNM = 1852.0
+
+// PlotToLatLon projects the measured polar position (I048/040) of a record
+// to WGS-84 using the stereographic projection centred on the radar.
+//
+// The measured range is slant range; it is used as ground range, which is
+// the usual approximation for display purposes. ok is false when the record
+// carries no I048/040.
+func PlotToLatLon(rec *asterix.Record, ref geo.Stereographic) (lat, lon float64, ok bool) {
+	item, _, exists := rec.GetDataItem("I048/040")
+	if !exists {
+		return 0, 0, false
+	}
+	pos, isPos := item.(*v132.MeasuredPosition)
+	if !isPos {
+		return 0, 0, false
+	}
+
+	r := pos.RHO * metersPerNM
+	theta := pos.THETA * math.Pi / 180
+	lat, lon = ref.ToLatLon(r*math.Sin(theta), r*math.Cos(theta))
+	return lat, lon, true
+}
@@ -0,0 +1,57 @@
+// cat/cat048/plot_test.go
+package cat048_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/geo"
+	"github.com/davidkohl/gobelix/cat/cat048"
+	v132 "github.com/davidkohl/gobelix/cat/cat048/dataitems/v132"
+)
+
+func TestPlotToLatLon(t *testing.T) {
+	uap, err := cat048.NewUAP(cat048.Version132)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := geo.Stereographic{Latitude: 50, Longitude: 8}
+
+	rec, err := asterix.NewRecord(asterix.Cat048, uap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := cat048.PlotToLatLon(rec, ref); ok {
+		t.Fatal("PlotToLatLon() ok without I048/040")
+	}
+
+	// 60 NM due east of the radar
+	if err := rec.SetDataItem("I048/040", &v132.MeasuredPosition{RHO: 60, THETA: 90}); err != nil {
+		t.Fatal(err)
+	}
+	lat, lon, ok := cat048.PlotToLatLon(rec, ref)
+	if !ok {
+		t.Fatal("PlotToLatLon() not ok")
+	}
+
+	// Great-circle distance and initial bearing from the radar on the same
+	// sphere the projection uses
+	r := ref.Radius()
+	phi0, phi := ref.Latitude*math.Pi/180, lat*math.Pi/180
+	dLambda := (lon - ref.Longitude) * math.Pi / 180
+	a := math.Pow(math.Sin((phi-phi0)/2), 2) + math.Cos(phi0)*math.Cos(phi)*math.Pow(math.Sin(dLambda/2), 2)
+	dist := 2 * r * math.Asin(math.Sqrt(a))
+	bearing := math.Atan2(math.Sin(dLambda)*math.Cos(phi),
+		math.Cos(phi0)*math.Sin(phi)-math.Sin(phi0)*math.Cos(phi)*math.Cos(dLambda)) * 180 / math.Pi
+
+	if math.Abs(dist-60*1852) > 5 {
+		t.Errorf("distance = %.1f m, want ≈ %.1f m", dist, 60*1852.0)
+	}
+	if math.Abs(bearing-90) > 1e-6 {
+		t.Errorf("bearing = %f°, want 90°", bearing)
+	}
+	if math.Abs(lat-49.9897) > 1e-3 || math.Abs(lon-9.5518) > 1e-3 {
+		t.Errorf("PlotToLatLon() = (%f, %f), want ≈ (49.9897, 9.5518)", lat, lon)
+	}
+}
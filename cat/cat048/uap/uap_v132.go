@@ -330,7 +330,7 @@ var cat048Fields = []asterix.DataField{
 		FRN:         27,
 		DataItem:    "SP048",
 		Description: "Special Purpose Field",
-		Type:        asterix.Repetitive,
+		Type:        asterix.Explicit,
 		Length:      1,
 		Mandatory:   false,
 	},
@@ -338,7 +338,7 @@ var cat048Fields = []asterix.DataField{
 		FRN:         28,
 		DataItem:    "RE048",
 		Description: "Reserved Expansion Field",
-		Type:        asterix.Repetitive,
+		Type:        asterix.Explicit,
 		Length:      1,
 		Mandatory:   false,
 	},
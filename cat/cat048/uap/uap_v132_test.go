@@ -0,0 +1,127 @@
+// cat/cat048/uap/uap_v132_test.go
+package uap_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat048"
+	v132 "github.com/davidkohl/gobelix/cat/cat048/dataitems/v132"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestUAP132_FieldOrder(t *testing.T) {
+	uap, err := cat048.NewUAP(cat048.Version132)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	// FRN order of the Edition 1.32 standard UAP
+	want := []string{
+		"I048/010", "I048/140", "I048/020", "I048/040", "I048/070", "I048/090", "I048/130",
+		"I048/220", "I048/240", "I048/250", "I048/161", "I048/042", "I048/200", "I048/170",
+		"I048/210", "I048/030", "I048/080", "I048/100", "I048/110", "I048/120", "I048/230",
+		"I048/260", "I048/055", "I048/050", "I048/065", "I048/060", "SP048", "RE048",
+	}
+	fields := uap.Fields()
+	if len(fields) != len(want) {
+		t.Fatalf("Fields() = %d fields, want %d", len(fields), len(want))
+	}
+	for i, field := range fields {
+		if field.FRN != uint8(i+1) || field.DataItem != want[i] {
+			t.Errorf("field %d = FRN %d %s, want FRN %d %s", i, field.FRN, field.DataItem, i+1, want[i])
+		}
+		mandatory := field.DataItem == "I048/010" || field.DataItem == "I048/140" || field.DataItem == "I048/020"
+		if field.Mandatory != mandatory {
+			t.Errorf("%s Mandatory = %v, want %v", field.DataItem, field.Mandatory, mandatory)
+		}
+		if _, err := uap.CreateDataItem(field.DataItem); err != nil {
+			t.Errorf("CreateDataItem(%s) error = %v", field.DataItem, err)
+		}
+	}
+}
+
+func TestUAP132_ModeSTargetReportRoundTrip(t *testing.T) {
+	uap, err := cat048.NewUAP(cat048.Version132)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	// A combined PSR and Mode S roll-call plot with its track. Values are
+	// multiples of the item LSBs so that they survive the round trip exactly.
+	items := map[string]asterix.DataItem{
+		"I048/010": &common.DataSourceIdentifier{SAC: 25, SIC: 201},
+		"I048/140": &v132.TimeOfDay{Time: 43200.5},
+		"I048/020": &v132.TargetReportDescriptor{TYP: 5},
+		"I048/040": &v132.MeasuredPosition{RHO: 42.5, THETA: 90},
+		"I048/070": &v132.Mode3ACode{Code: 1234},
+		"I048/090": &v132.FlightLevel{Level: 350},
+		"I048/130": &v132.RadarPlotCharacteristics{
+			SRL: true, SRR: true, SAM: true,
+			SSRRunLength:  10 * 360.0 / 8192,
+			SSRReplyCount: 12,
+			SSRAmplitude:  -60,
+		},
+		"I048/220": &v132.AircraftAddress{Address: 0x3C6586},
+		"I048/240": &v132.AircraftIdentification{Ident: "DLH4AB"},
+		"I048/250": &v132.BDSRegisterData{Registers: []v132.BDSRegister{
+			{BDS1: 4, BDS2: 0, Data: []byte{0x85, 0x78, 0x45, 0x78, 0x00, 0x00, 0x00}},
+		}},
+		"I048/161": &v132.TrackNumber{Value: 1234},
+		"I048/042": &v132.CalculatedPosition{X: 12.5, Y: -3.25},
+		"I048/200": &v132.CalculatedTrackVelocity{GroundSpeed: 0.125, Heading: 90},
+		"I048/170": &v132.TrackStatus{RAD: 2, CDM: 1},
+		"I048/230": &v132.CommunicationsCapability{COM: 1, MSSC: true, ARC: true, AIC: true, B1A: true, B1B: 5},
+		"I048/050": &v132.Mode2Code{Code: 4321},
+		"I048/060": &v132.Mode2CodeConfidence{QA4: true, QD1: true},
+	}
+
+	record, err := asterix.NewRecord(asterix.Cat048, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	for id, item := range items {
+		if err := record.SetDataItem(id, item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", id, err)
+		}
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat048, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(record); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	data, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat048, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(data); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if decoded.Length() != 1 {
+		t.Fatalf("Decode() records = %d, want 1", decoded.Length())
+	}
+	got := decoded.Records()[0]
+
+	if n := len(got.PresentFRNs()); n != len(items) {
+		t.Errorf("decoded record has %d items, want %d", n, len(items))
+	}
+	for id, want := range items {
+		item, _, ok := got.GetDataItem(id)
+		if !ok {
+			t.Errorf("%s missing after decode", id)
+			continue
+		}
+		if !reflect.DeepEqual(item, want) {
+			t.Errorf("%s = %+v, want %+v", id, item, want)
+		}
+	}
+}
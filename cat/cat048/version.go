@@ -19,7 +19,7 @@ func NewUAP(version string) (asterix.UAP, error) {
 	case Version132:
 		return uap.NewUAP132()
 	default:
-		return nil, fmt.Errorf("unsupported CAT048 version: %s", version)
+		return nil, fmt.Errorf("%w: CAT048 %s", asterix.ErrUnsupportedVersion, version)
 	}
 }
 
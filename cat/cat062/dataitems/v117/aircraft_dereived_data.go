@@ -4,9 +4,28 @@ package v117
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/ident"
+	"github.com/davidkohl/gobelix/asterix/subfields"
+)
+
+// Errors returned by AircraftDerivedData.Validate for values outside the
+// ranges of their I062/380 subfields. Each wraps asterix.ErrInvalidField.
+var (
+	ErrGroundSpeedRange  = fmt.Errorf("%w: ground speed out of range", asterix.ErrInvalidField)
+	ErrGeoAltitudeRange  = fmt.Errorf("%w: geometric altitude out of range", asterix.ErrInvalidField)
+	ErrVerticalRateRange = fmt.Errorf("%w: vertical rate out of range", asterix.ErrInvalidField)
+	ErrWindRange         = fmt.Errorf("%w: wind out of range", asterix.ErrInvalidField)
+	ErrTemperatureRange  = fmt.Errorf("%w: temperature out of range", asterix.ErrInvalidField)
+	ErrTurbulenceRange   = fmt.Errorf("%w: turbulence out of range", asterix.ErrInvalidField)
 )
 
+// groundSpeedLSB is the LSB of subfield #18 (GS), 2^-14 NM/s, in knots
+const groundSpeedLSB = 3600.0 / (1 << 14)
+
 // AircraftDerivedData implements I062/380
 // Data derived directly by the aircraft
 type AircraftDerivedData struct {
@@ -43,6 +62,14 @@ type AircraftDerivedData struct {
 
 	// Raw data for reporting purposes
 	rawData []byte
+
+	maxFXDepth int // Set by SetMaxFXDepth, 0 for the default depth
+}
+
+// SetMaxFXDepth implements asterix.FXDepthLimiter, bounding the extents of
+// the trajectory intent status subfield
+func (a *AircraftDerivedData) SetMaxFXDepth(octets int) {
+	a.maxFXDepth = octets
 }
 
 // SelectedAlt contains selected altitude information
@@ -71,6 +98,11 @@ type TrajIntent struct {
 type TrajIntentStatus struct {
 	NavigationAvailable bool
 	NavigationValid     bool
+
+	// Extensions holds bits 8-2 of each extension octet, in order. Their
+	// meaning is not defined by edition 1.17; they are kept so the item
+	// re-encodes as received. Encode sets the FX bits.
+	Extensions []uint8
 }
 
 // TrajIntentPoint represents a single trajectory intent point
@@ -140,29 +172,11 @@ func (a *AircraftDerivedData) Decode(buf *bytes.Buffer) (int, error) {
 	a.rawData = nil
 
 	// Read FSPEC bytes (primary field)
-	var fspecBytes []byte
-	hasExtension := true
-
-	for hasExtension && bytesRead < 8 { // Prevent excessive extensions
-		if buf.Len() < 1 {
-			return bytesRead, fmt.Errorf("buffer too short for FSPEC byte")
-		}
-
-		fspecByte, err := buf.ReadByte()
-		if err != nil {
-			return bytesRead, fmt.Errorf("reading FSPEC byte: %w", err)
-		}
-		bytesRead++
-
-		fspecBytes = append(fspecBytes, fspecByte)
-		a.rawData = append(a.rawData, fspecByte)
-
-		// Check if we need to continue reading FSPEC (FX bit)
-		hasExtension = (fspecByte & 0x01) != 0
-	}
-
-	if len(fspecBytes) == 0 {
-		return bytesRead, fmt.Errorf("no FSPEC bytes read")
+	fspecBytes, err := asterix.ReadFXChain(buf, 4) // Edition 1.17 defines four octets
+	bytesRead += len(fspecBytes)
+	a.rawData = append(a.rawData, fspecBytes...)
+	if err != nil {
+		return bytesRead, fmt.Errorf("reading FSPEC: %w", err)
 	}
 
 	// Now we need to determine which subfields are present based on FSPEC bits
@@ -198,8 +212,11 @@ func (a *AircraftDerivedData) Decode(buf *bytes.Buffer) (int, error) {
 			bytesRead += n
 			a.rawData = append(a.rawData, data...)
 
-			ident := parseTargetIdentification(data)
-			a.TargetIdentification = &ident
+			callsign, err := ident.Decode(data)
+			if err != nil {
+				return bytesRead, fmt.Errorf("decoding target identification: %w", err)
+			}
+			a.TargetIdentification = &callsign
 		}
 
 		// FRN 3 (bit 6 of byte 1): Magnetic Heading
@@ -315,23 +332,12 @@ func (a *AircraftDerivedData) Decode(buf *bytes.Buffer) (int, error) {
 	if len(fspecBytes) > 1 {
 		// FRN 8 (bit 8 of byte 2): Trajectory Intent Status
 		if (fspecBytes[1] & 0x80) != 0 {
-			if buf.Len() < 1 {
-				return bytesRead, fmt.Errorf("buffer too short for trajectory intent status")
-			}
-			data := make([]byte, 1)
-			n, err := buf.Read(data)
-			if err != nil || n != 1 {
-				return bytesRead + n, fmt.Errorf("reading trajectory intent status: %w", err)
-			}
-			bytesRead += n
+			// First part followed by one-octet extents while FX is set
+			data, err := asterix.ReadFXChain(buf, a.maxFXDepth)
+			bytesRead += len(data)
 			a.rawData = append(a.rawData, data...)
-
-			// Check FX bit for extended field
-			hasExtension = (data[0] & 0x01) != 0
-			if hasExtension {
-				// Not handling extended fields for trajectory intent status in this implementation
-				// This would require reading additional bytes
-				return bytesRead, fmt.Errorf("trajectory intent status extension not implemented")
+			if err != nil {
+				return bytesRead, fmt.Errorf("reading trajectory intent status: %w", err)
 			}
 
 			if a.TrajectoryIntent == nil {
@@ -342,6 +348,9 @@ func (a *AircraftDerivedData) Decode(buf *bytes.Buffer) (int, error) {
 				NavigationAvailable: (data[0] & 0x80) == 0, // NAV bit (inverted: 0 = available)
 				NavigationValid:     (data[0] & 0x40) == 0, // NVB bit (inverted: 0 = valid)
 			}
+			for _, ext := range data[1:] {
+				a.TrajectoryIntent.Status.Extensions = append(a.TrajectoryIntent.Status.Extensions, ext&0xFE)
+			}
 		}
 
 		// FRN 9 (bit 7 of byte 2): Trajectory Intent Data
@@ -388,11 +397,11 @@ func (a *AircraftDerivedData) Decode(buf *bytes.Buffer) (int, error) {
 				point.Altitude = float64(altVal) * 10.0
 
 				// Parse latitude (180/2^23 degrees resolution)
-				latVal := int32(uint32(data[3])<<16 | uint32(data[4])<<8 | uint32(data[5]))
+				latVal := int32(uint32(data[3])<<24|uint32(data[4])<<16|uint32(data[5])<<8) >> 8 // Sign-extend 24 bits
 				point.Latitude = float64(latVal) * 180.0 / float64(1<<23)
 
 				// Parse longitude (180/2^23 degrees resolution)
-				lonVal := int32(uint32(data[6])<<16 | uint32(data[7])<<8 | uint32(data[8]))
+				lonVal := int32(uint32(data[6])<<24|uint32(data[7])<<16|uint32(data[8])<<8) >> 8
 				point.Longitude = float64(lonVal) * 180.0 / float64(1<<23)
 
 				// Parse point type and turn data
@@ -591,7 +600,7 @@ func (a *AircraftDerivedData) Decode(buf *bytes.Buffer) (int, error) {
 			a.rawData = append(a.rawData, data...)
 
 			// In knots (converted from NM/s)
-			gndSpd := float64(uint16(data[0])<<8|uint16(data[1])) * 0.22 // LSB = 2^-14 NM/s ≈ 0.22 kt
+			gndSpd := float64(uint16(data[0])<<8|uint16(data[1])) * groundSpeedLSB
 			a.GroundSpeed = &gndSpd
 		}
 
@@ -686,11 +695,11 @@ func (a *AircraftDerivedData) Decode(buf *bytes.Buffer) (int, error) {
 			a.rawData = append(a.rawData, data...)
 
 			// Parse latitude (180/2^23 degrees resolution)
-			latVal := int32(uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2]))
+			latVal := int32(uint32(data[0])<<24|uint32(data[1])<<16|uint32(data[2])<<8) >> 8 // Sign-extend 24 bits
 			lat := float64(latVal) * 180.0 / float64(1<<23)
 
 			// Parse longitude (180/2^23 degrees resolution)
-			lonVal := int32(uint32(data[3])<<16 | uint32(data[4])<<8 | uint32(data[5]))
+			lonVal := int32(uint32(data[3])<<24|uint32(data[4])<<16|uint32(data[5])<<8) >> 8
 			lon := float64(lonVal) * 180.0 / float64(1<<23)
 
 			a.Position = &WGS84Position{
@@ -738,41 +747,21 @@ func (a *AircraftDerivedData) Decode(buf *bytes.Buffer) (int, error) {
 
 		// FRN 25 (bit 5 of byte 4): Mode S MB Data
 		if (fspecBytes[3] & 0x10) != 0 {
-			if buf.Len() < 1 {
-				return bytesRead, fmt.Errorf("buffer too short for Mode S MB data REP")
-			}
-
-			// Read repetition factor
-			rep, err := buf.ReadByte()
+			elems, n, err := asterix.ReadRepetitive(buf, 8)
 			if err != nil {
-				return bytesRead, fmt.Errorf("reading Mode S MB data REP: %w", err)
+				return bytesRead + n, fmt.Errorf("reading Mode S MB data: %w", err)
 			}
-			bytesRead++
-			a.rawData = append(a.rawData, rep)
-
-			a.ModeSMBData = make([]ModeSMB, 0, rep)
-
-			for i := 0; i < int(rep); i++ {
-				if buf.Len() < 8 {
-					return bytesRead, fmt.Errorf("buffer too short for Mode S MB data entry %d", i+1)
-				}
-
-				data := make([]byte, 8)
-				n, err := buf.Read(data)
-				if err != nil || n != 8 {
-					return bytesRead + n, fmt.Errorf("reading Mode S MB data entry %d: %w", i+1, err)
-				}
-				bytesRead += n
-				a.rawData = append(a.rawData, data...)
-
-				mbData := ModeSMB{
-					Data: make([]byte, 7),
-					BDS1: (data[7] >> 4) & 0x0F,
-					BDS2: data[7] & 0x0F,
+			bytesRead += n
+			a.rawData = append(a.rawData, byte(len(elems)))
+
+			a.ModeSMBData = make([]ModeSMB, len(elems))
+			for i, elem := range elems {
+				a.rawData = append(a.rawData, elem...)
+				a.ModeSMBData[i] = ModeSMB{
+					Data: elem[:7],
+					BDS1: (elem[7] >> 4) & 0x0F,
+					BDS2: elem[7] & 0x0F,
 				}
-				copy(mbData.Data, data[:7])
-
-				a.ModeSMBData = append(a.ModeSMBData, mbData)
 			}
 		}
 
@@ -840,188 +829,11 @@ func (a *AircraftDerivedData) Encode(buf *bytes.Buffer) (int, error) {
 		return buf.Write(a.rawData)
 	}
 
-	// We need to build the FSPEC based on which fields are present
+	// Build the FSPEC from the subfields that are present
 	bytesWritten := 0
-	fspec := make([]byte, 0, 4) // Maximum of 4 FSPEC bytes
-
-	// First FSPEC byte
-	fspecByte1 := byte(0)
-	// FRN 1 (bit 8): Target Address
-	if a.TargetAddress != nil {
-		fspecByte1 |= 0x80
-	}
-	// FRN 2 (bit 7): Target Identification
-	if a.TargetIdentification != nil {
-		fspecByte1 |= 0x40
-	}
-	// FRN 3 (bit 6): Magnetic Heading
-	if a.MagneticHeading != nil {
-		fspecByte1 |= 0x20
-	}
-	// FRN 4 (bit 5): IAS/Mach
-	if a.AirspeedMach != nil {
-		fspecByte1 |= 0x10
-	}
-	// FRN 5 (bit 4): True Airspeed
-	if a.TrueAirspeed != nil {
-		fspecByte1 |= 0x08
-	}
-	// FRN 6 (bit 3): Selected Altitude
-	if a.SelectedAltitude != nil {
-		fspecByte1 |= 0x04
-	}
-	// FRN 7 (bit 2): Final State Selected Altitude
-	if a.FinalStateSelectedAlt != nil {
-		fspecByte1 |= 0x02
-	}
-
-	// Check if we need a second FSPEC byte
-	needSecondByte := a.TrajectoryIntent != nil ||
-		a.ServiceStatus != nil ||
-		a.ACASStatus != nil ||
-		a.ACASResolution != nil ||
-		a.BarometricVertRate != nil ||
-		a.GeometricVertRate != nil
-
-	// Second FSPEC byte (if needed)
-	fspecByte2 := byte(0)
-	if needSecondByte {
-		// Set FX bit in first byte
-		fspecByte1 |= 0x01
-
-		// FRN 8 (bit 8): Trajectory Intent Status
-		if a.TrajectoryIntent != nil && a.TrajectoryIntent.StatusPresent {
-			fspecByte2 |= 0x80
-		}
-		// FRN 9 (bit 7): Trajectory Intent Data
-		if a.TrajectoryIntent != nil && len(a.TrajectoryIntent.Points) > 0 {
-			fspecByte2 |= 0x40
-		}
-		// FRN 10 (bit 6): Communications/ACAS Capability and Flight Status
-		if a.ServiceStatus != nil {
-			fspecByte2 |= 0x20
-		}
-		// FRN 11 (bit 5): Status reported by ADS-B
-		if a.ACASStatus != nil {
-			fspecByte2 |= 0x10
-		}
-		// FRN 12 (bit 4): ACAS Resolution Advisory Report
-		if a.ACASResolution != nil {
-			fspecByte2 |= 0x08
-		}
-		// FRN 13 (bit 3): Barometric Vertical Rate
-		if a.BarometricVertRate != nil {
-			fspecByte2 |= 0x04
-		}
-		// FRN 14 (bit 2): Geometric Vertical Rate
-		if a.GeometricVertRate != nil {
-			fspecByte2 |= 0x02
-		}
-	}
-
-	// Check if we need a third FSPEC byte
-	needThirdByte := a.RollAngle != nil ||
-		a.TrackAngleRate != nil ||
-		a.TrackAngle != nil ||
-		a.GroundSpeed != nil ||
-		a.VelocityUncertainty != nil ||
-		a.MetData != nil ||
-		a.EmitterCategory != nil
-
-	// Third FSPEC byte (if needed)
-	fspecByte3 := byte(0)
-	if needThirdByte {
-		// Set FX bit in second byte
-		fspecByte2 |= 0x01
-
-		// FRN 15 (bit 8): Roll Angle
-		if a.RollAngle != nil {
-			fspecByte3 |= 0x80
-		}
-		// FRN 16 (bit 7): Track Angle Rate
-		if a.TrackAngleRate != nil {
-			fspecByte3 |= 0x40
-		}
-		// FRN 17 (bit 6): Track Angle
-		if a.TrackAngle != nil {
-			fspecByte3 |= 0x20
-		}
-		// FRN 18 (bit 5): Ground Speed
-		if a.GroundSpeed != nil {
-			fspecByte3 |= 0x10
-		}
-		// FRN 19 (bit 4): Velocity Uncertainty
-		if a.VelocityUncertainty != nil {
-			fspecByte3 |= 0x08
-		}
-		// FRN 20 (bit 3): Meteorological Data
-		if a.MetData != nil {
-			fspecByte3 |= 0x04
-		}
-		// FRN 21 (bit 2): Emitter Category
-		if a.EmitterCategory != nil {
-			fspecByte3 |= 0x02
-		}
-	}
-
-	// Check if we need a fourth FSPEC byte
-	needFourthByte := a.Position != nil ||
-		a.GeoAltitude != nil ||
-		a.PositionUncertainty != nil ||
-		a.ModeSMBData != nil ||
-		a.IAS != nil ||
-		a.Mach != nil ||
-		a.BarometricPressure != nil
-
-	// Fourth FSPEC byte (if needed)
-	fspecByte4 := byte(0)
-	if needFourthByte {
-		// Set FX bit in third byte
-		fspecByte3 |= 0x01
-
-		// FRN 22 (bit 8): Position
-		if a.Position != nil {
-			fspecByte4 |= 0x80
-		}
-		// FRN 23 (bit 7): Geometric Altitude
-		if a.GeoAltitude != nil {
-			fspecByte4 |= 0x40
-		}
-		// FRN 24 (bit 6): Position Uncertainty
-		if a.PositionUncertainty != nil {
-			fspecByte4 |= 0x20
-		}
-		// FRN 25 (bit 5): Mode S MB Data
-		if a.ModeSMBData != nil {
-			fspecByte4 |= 0x10
-		}
-		// FRN 26 (bit 4): Indicated Airspeed
-		if a.IAS != nil {
-			fspecByte4 |= 0x08
-		}
-		// FRN 27 (bit 3): Mach Number
-		if a.Mach != nil {
-			fspecByte4 |= 0x04
-		}
-		// FRN 28 (bit 2): Barometric Pressure Setting
-		if a.BarometricPressure != nil {
-			fspecByte4 |= 0x02
-		}
-	}
-
-	// Add FSPEC bytes to buffer
-	fspec = append(fspec, fspecByte1)
-	if needSecondByte {
-		fspec = append(fspec, fspecByte2)
-	}
-	if needThirdByte {
-		fspec = append(fspec, fspecByte3)
-	}
-	if needFourthByte {
-		fspec = append(fspec, fspecByte4)
-	}
+	fspec, fspecLen := a.buildFSPEC()
 
-	n, err := buf.Write(fspec)
+	n, err := buf.Write(fspec[:fspecLen])
 	if err != nil {
 		return bytesWritten, fmt.Errorf("writing FSPEC: %w", err)
 	}
@@ -1046,8 +858,11 @@ func (a *AircraftDerivedData) Encode(buf *bytes.Buffer) (int, error) {
 
 	// FRN 2: Target Identification
 	if a.TargetIdentification != nil {
-		data := encodeTargetIdentification(*a.TargetIdentification)
-		n, err := buf.Write(data)
+		data, err := ident.Encode(*a.TargetIdentification)
+		if err != nil {
+			return bytesWritten, fmt.Errorf("encoding target identification: %w", err)
+		}
+		n, err := buf.Write(data[:])
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing target identification: %w", err)
 		}
@@ -1159,27 +974,39 @@ func (a *AircraftDerivedData) Encode(buf *bytes.Buffer) (int, error) {
 		bytesWritten += n
 	}
 
-	// Continue with each field that's present
-	// For brevity, I'll skip ahead to show the pattern for a more complex field
-
 	// FRN 8: Trajectory Intent Status
 	if a.TrajectoryIntent != nil && a.TrajectoryIntent.StatusPresent {
-		// For now, only basic status fields, no extensions
+		status := a.TrajectoryIntent.Status
+		if status == nil {
+			status = &TrajIntentStatus{}
+		}
 		statusByte := byte(0)
-		if !a.TrajectoryIntent.Status.NavigationAvailable {
+		if !status.NavigationAvailable {
 			statusByte |= 0x80 // NAV bit
 		}
-		if !a.TrajectoryIntent.Status.NavigationValid {
+		if !status.NavigationValid {
 			statusByte |= 0x40 // NVB bit
 		}
-		// No extension
-		statusByte |= 0x00
+		if len(status.Extensions) > 0 {
+			statusByte |= 0x01 // FX
+		}
 
 		err := buf.WriteByte(statusByte)
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing trajectory intent status: %w", err)
 		}
-		bytesWritten += n
+		bytesWritten++
+
+		for i, ext := range status.Extensions {
+			ext &= 0xFE
+			if i < len(status.Extensions)-1 {
+				ext |= 0x01
+			}
+			if err := buf.WriteByte(ext); err != nil {
+				return bytesWritten, fmt.Errorf("writing trajectory intent status extension %d: %w", i+1, err)
+			}
+			bytesWritten++
+		}
 	}
 
 	// FRN 9: Trajectory Intent Data
@@ -1190,11 +1017,11 @@ func (a *AircraftDerivedData) Encode(buf *bytes.Buffer) (int, error) {
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing trajectory intent rep factor: %w", err)
 		}
-		bytesWritten += n
+		bytesWritten++
 
 		// Then encode each point
 		for i, point := range a.TrajectoryIntent.Points {
-			data := make([]byte, 15)
+			var data [15]byte
 
 			// TCP header byte
 			if !point.TCPAvailable {
@@ -1242,7 +1069,7 @@ func (a *AircraftDerivedData) Encode(buf *bytes.Buffer) (int, error) {
 			data[13] = byte(ttrVal >> 8)
 			data[14] = byte(ttrVal)
 
-			n, err := buf.Write(data)
+			n, err := buf.Write(data[:])
 			if err != nil {
 				return bytesWritten, fmt.Errorf("writing trajectory intent point %d: %w", i+1, err)
 			}
@@ -1250,12 +1077,184 @@ func (a *AircraftDerivedData) Encode(buf *bytes.Buffer) (int, error) {
 		}
 	}
 
-	// Continuing with other fields would follow the same pattern
-	// For brevity, I'll skip most of them and just show a few more interesting cases
+	// FRN 10: Communications/ACAS Capability and Flight Status
+	if a.ServiceStatus != nil {
+		s := a.ServiceStatus
+		if s.CommCapability > 7 || s.FlightStatus > 7 || s.BDS1_0Bits37to40 > 15 {
+			return bytesWritten, fmt.Errorf("%w: communications capability %d, flight status %d or BDS 1,0 bits %d too wide",
+				asterix.ErrInvalidField, s.CommCapability, s.FlightStatus, s.BDS1_0Bits37to40)
+		}
+		var data [2]byte
+		data[0] = s.CommCapability<<5 | s.FlightStatus<<2
+		if s.SpecificService {
+			data[1] |= 0x80
+		}
+		if s.AltitudeReporting {
+			data[1] |= 0x40
+		}
+		if s.AircraftIdent {
+			data[1] |= 0x20
+		}
+		if s.BDS1_0Bit16 {
+			data[1] |= 0x10
+		}
+		data[1] |= s.BDS1_0Bits37to40
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing communications capability and flight status: %w", err)
+		}
+		bytesWritten += n
+	}
+
+	// FRN 11: Status reported by ADS-B
+	if a.ACASStatus != nil {
+		s := a.ACASStatus
+		if s.FlightStatus > 7 {
+			return bytesWritten, fmt.Errorf("%w: ADS-B flight status out of range [0,7]: %d",
+				asterix.ErrInvalidField, s.FlightStatus)
+		}
+		var data [2]byte
+		if s.ACASOperational {
+			data[0] |= 0x80
+		}
+		if s.MultipleNavigational {
+			data[0] |= 0x40
+		}
+		if s.DifferentialCorrection {
+			data[0] |= 0x20
+		}
+		if s.GroundBit {
+			data[0] |= 0x10
+		}
+		data[1] = s.FlightStatus
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing ADS-B status: %w", err)
+		}
+		bytesWritten += n
+	}
+
+	// FRN 12: ACAS Resolution Advisory Report
+	if a.ACASResolution != nil {
+		if len(a.ACASResolution) != 7 {
+			return bytesWritten, fmt.Errorf("%w: ACAS resolution advisory report has %d bytes, want 7",
+				asterix.ErrInvalidLength, len(a.ACASResolution))
+		}
+		n, err := buf.Write(a.ACASResolution)
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing ACAS resolution advisory report: %w", err)
+		}
+		bytesWritten += n
+	}
+
+	// FRN 13: Barometric Vertical Rate
+	if a.BarometricVertRate != nil {
+		rate, err := scaledSigned("barometric vertical rate", *a.BarometricVertRate, 6.25, 16) // LSB = 6.25 ft/min
+		if err != nil {
+			return bytesWritten, err
+		}
+		data := [2]byte{byte(rate >> 8), byte(rate)}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing barometric vertical rate: %w", err)
+		}
+		bytesWritten += n
+	}
+
+	// FRN 14: Geometric Vertical Rate
+	if a.GeometricVertRate != nil {
+		rate, err := scaledSigned("geometric vertical rate", *a.GeometricVertRate, 6.25, 16) // LSB = 6.25 ft/min
+		if err != nil {
+			return bytesWritten, err
+		}
+		data := [2]byte{byte(rate >> 8), byte(rate)}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing geometric vertical rate: %w", err)
+		}
+		bytesWritten += n
+	}
+
+	// FRN 15: Roll Angle
+	if a.RollAngle != nil {
+		roll, err := scaledSigned("roll angle", *a.RollAngle, 0.01, 16) // LSB = 0.01 degree
+		if err != nil {
+			return bytesWritten, err
+		}
+		data := [2]byte{byte(roll >> 8), byte(roll)}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing roll angle: %w", err)
+		}
+		bytesWritten += n
+	}
+
+	// FRN 16: Track Angle Rate
+	if a.TrackAngleRate != nil {
+		var ti uint8
+		if a.TurnIndicator != nil {
+			if ti = *a.TurnIndicator; ti > 3 {
+				return bytesWritten, fmt.Errorf("%w: turn indicator out of range [0,3]: %d",
+					asterix.ErrInvalidField, ti)
+			}
+		}
+		// Rate of turn in bits 14-7, LSB = 1/4 °/s
+		rate, err := scaledSigned("track angle rate", *a.TrackAngleRate, 0.25, 8)
+		if err != nil {
+			return bytesWritten, err
+		}
+		rot := byte(rate)
+		data := [2]byte{ti<<6 | rot>>2, rot << 6}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing track angle rate: %w", err)
+		}
+		bytesWritten += n
+	}
+
+	// FRN 17: Track Angle
+	if a.TrackAngle != nil {
+		if !(*a.TrackAngle >= 0 && *a.TrackAngle < 360) {
+			return bytesWritten, fmt.Errorf("%w: track angle out of range [0,360): %f",
+				asterix.ErrInvalidField, *a.TrackAngle)
+		}
+		// An angle rounding up to 360° wraps to 0
+		angle := uint16(uint32(math.Round(*a.TrackAngle * 65536.0 / 360.0)))
+		data := [2]byte{byte(angle >> 8), byte(angle)}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing track angle: %w", err)
+		}
+		bytesWritten += n
+	}
+
+	// FRN 18: Ground Speed
+	if a.GroundSpeed != nil {
+		gndSpd, err := scaledUnsigned("ground speed", *a.GroundSpeed, groundSpeedLSB, 16)
+		if err != nil {
+			return bytesWritten, err
+		}
+		data := [2]byte{byte(gndSpd >> 8), byte(gndSpd)}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing ground speed: %w", err)
+		}
+		bytesWritten += n
+	}
+
+	// FRN 19: Velocity Uncertainty
+	if a.VelocityUncertainty != nil {
+		data := [1]byte{*a.VelocityUncertainty}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing velocity uncertainty: %w", err)
+		}
+		bytesWritten += n
+	}
 
 	// FRN 20: Meteorological Data
 	if a.MetData != nil {
-		data := make([]byte, 8)
+		var data [8]byte
 
 		// Set validity bits
 		if a.MetData.WindSpeedValid {
@@ -1297,102 +1296,140 @@ func (a *AircraftDerivedData) Encode(buf *bytes.Buffer) (int, error) {
 			data[7] = *a.MetData.Turbulence
 		}
 
-		n, err := buf.Write(data)
+		n, err := buf.Write(data[:])
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing meteorological data: %w", err)
 		}
 		bytesWritten += n
 	}
 
-	// FRN 25: Mode S MB Data
-	if a.ModeSMBData != nil {
-		// First write repetition factor
-		numEntries := byte(len(a.ModeSMBData))
-		err := buf.WriteByte(numEntries)
+	// FRN 21: Emitter Category
+	if a.EmitterCategory != nil {
+		data := [1]byte{*a.EmitterCategory}
+		n, err := buf.Write(data[:])
 		if err != nil {
-			return bytesWritten, fmt.Errorf("writing Mode S MB data rep factor: %w", err)
+			return bytesWritten, fmt.Errorf("writing emitter category: %w", err)
 		}
 		bytesWritten += n
-
-		// Then encode each entry
-		for i, mb := range a.ModeSMBData {
-			data := make([]byte, 8)
-
-			// MB data (7 bytes)
-			copy(data[:7], mb.Data)
-
-			// BDS register address (8th byte)
-			data[7] = (mb.BDS1 << 4) | (mb.BDS2 & 0x0F)
-
-			n, err := buf.Write(data)
-			if err != nil {
-				return bytesWritten, fmt.Errorf("writing Mode S MB data entry %d: %w", i+1, err)
-			}
-			bytesWritten += n
-		}
 	}
 
-	// The encoding would continue for all remaining fields
-	// Following the patterns shown above
-
-	return bytesWritten, nil
-}
-
-// String returns a human-readable representation of the data item
-func (a *AircraftDerivedData) String() string {
-	parts := []string{}
-
-	if a.TargetAddress != nil {
-		parts = append(parts, fmt.Sprintf("Addr: %06X", *a.TargetAddress))
-	}
-
-	if a.TargetIdentification != nil {
-		parts = append(parts, fmt.Sprintf("ID: %s", *a.TargetIdentification))
-	}
-
-	if a.MagneticHeading != nil {
-		parts = append(parts, fmt.Sprintf("HDG: %.1f°", *a.MagneticHeading))
+	// FRN 22: Position
+	if a.Position != nil {
+		// Latitude and longitude (180/2^23 degrees resolution)
+		lat, err := scaledSigned("latitude", a.Position.Latitude, 180.0/(1<<23), 24)
+		if err != nil {
+			return bytesWritten, err
+		}
+		lon, err := scaledSigned("longitude", a.Position.Longitude, 180.0/(1<<23), 24)
+		if err != nil {
+			return bytesWritten, err
+		}
+		data := [6]byte{
+			byte(lat >> 16), byte(lat >> 8), byte(lat),
+			byte(lon >> 16), byte(lon >> 8), byte(lon),
+		}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing position: %w", err)
+		}
+		bytesWritten += n
 	}
 
-	if a.AirspeedMach != nil {
-		if a.IsMach {
-			parts = append(parts, fmt.Sprintf("Mach: %.3f", *a.AirspeedMach))
-		} else {
-			parts = append(parts, fmt.Sprintf("IAS: %.1f kt", *a.AirspeedMach))
+	// FRN 23: Geometric Altitude
+	if a.GeoAltitude != nil {
+		alt, err := scaledSigned("geometric altitude", *a.GeoAltitude, 6.25, 16) // LSB = 6.25 ft
+		if err != nil {
+			return bytesWritten, err
+		}
+		data := [2]byte{byte(alt >> 8), byte(alt)}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing geometric altitude: %w", err)
 		}
+		bytesWritten += n
 	}
 
-	if a.TrueAirspeed != nil {
-		parts = append(parts, fmt.Sprintf("TAS: %.0f kt", *a.TrueAirspeed))
+	// FRN 24: Position Uncertainty
+	if a.PositionUncertainty != nil {
+		if *a.PositionUncertainty > 15 {
+			return bytesWritten, fmt.Errorf("%w: position uncertainty out of range [0,15]: %d",
+				asterix.ErrInvalidField, *a.PositionUncertainty)
+		}
+		data := [1]byte{*a.PositionUncertainty}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing position uncertainty: %w", err)
+		}
+		bytesWritten += n
 	}
 
-	if a.SelectedAltitude != nil {
-		parts = append(parts, fmt.Sprintf("SelAlt: %.0f ft", a.SelectedAltitude.Altitude))
-	}
+	// FRN 25: Mode S MB Data
+	if a.ModeSMBData != nil {
+		elems := make([][]byte, len(a.ModeSMBData))
+		for i, mb := range a.ModeSMBData {
+			elem := make([]byte, 8)
+			copy(elem, mb.Data)                         // MB data (7 bytes)
+			elem[7] = (mb.BDS1 << 4) | (mb.BDS2 & 0x0F) // BDS register address
+			elems[i] = elem
+		}
 
-	if a.TrackAngle != nil {
-		parts = append(parts, fmt.Sprintf("TRK: %.1f°", *a.TrackAngle))
+		n, err := asterix.WriteRepetitive(buf, 8, elems)
+		bytesWritten += n
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing Mode S MB data: %w", err)
+		}
 	}
 
-	if a.GroundSpeed != nil {
-		parts = append(parts, fmt.Sprintf("GS: %.1f kt", *a.GroundSpeed))
+	// FRN 26: Indicated Airspeed
+	if a.IAS != nil {
+		ias, err := scaledUnsigned("indicated airspeed", *a.IAS, 1, 16)
+		if err != nil {
+			return bytesWritten, err
+		}
+		data := [2]byte{byte(ias >> 8), byte(ias)}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing indicated airspeed: %w", err)
+		}
+		bytesWritten += n
 	}
 
-	if a.Position != nil {
-		parts = append(parts, fmt.Sprintf("Pos: %.6f,%.6f", a.Position.Latitude, a.Position.Longitude))
+	// FRN 27: Mach Number
+	if a.Mach != nil {
+		mach, err := scaledUnsigned("mach number", *a.Mach, 0.008, 16)
+		if err != nil {
+			return bytesWritten, err
+		}
+		data := [2]byte{byte(mach >> 8), byte(mach)}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing mach number: %w", err)
+		}
+		bytesWritten += n
 	}
 
-	if a.GeoAltitude != nil {
-		parts = append(parts, fmt.Sprintf("GAlt: %.0f ft", *a.GeoAltitude))
+	// FRN 28: Barometric Pressure Setting
+	if a.BarometricPressure != nil {
+		// 12 bits above 800 mb, LSB = 0.1 mb
+		pressure, err := scaledUnsigned("barometric pressure setting above 800 mb", *a.BarometricPressure-800.0, 0.1, 12)
+		if err != nil {
+			return bytesWritten, err
+		}
+		data := [2]byte{byte(pressure >> 8), byte(pressure)}
+		n, err := buf.Write(data[:])
+		if err != nil {
+			return bytesWritten, fmt.Errorf("writing barometric pressure setting: %w", err)
+		}
+		bytesWritten += n
 	}
 
-	// Add other fields as needed
-
-	if len(parts) == 0 {
-		return "AircraftDerivedData[empty]"
-	}
+	return bytesWritten, nil
+}
 
-	return fmt.Sprintf("AircraftDerivedData[%s]", strings.Join(parts, ", "))
+// String returns a human-readable representation of the data item listing
+// every present subfield
+func (a *AircraftDerivedData) String() string {
+	return aircraftDerivedStringer.String(a)
 }
 
 // Validate performs validation on the data item
@@ -1402,6 +1439,13 @@ func (a *AircraftDerivedData) Validate() error {
 		return fmt.Errorf("target address exceeds 24-bit limit: %d", *a.TargetAddress)
 	}
 
+	// Validate TargetIdentification
+	if a.TargetIdentification != nil {
+		if err := ident.Validate(*a.TargetIdentification); err != nil {
+			return fmt.Errorf("target identification: %w", err)
+		}
+	}
+
 	// Validate MagneticHeading
 	if a.MagneticHeading != nil && (*a.MagneticHeading < 0 || *a.MagneticHeading >= 360) {
 		return fmt.Errorf("magnetic heading out of range [0,360): %f", *a.MagneticHeading)
@@ -1439,77 +1483,347 @@ func (a *AircraftDerivedData) Validate() error {
 		return fmt.Errorf("mach number out of range [0,4.092]: %f", *a.Mach)
 	}
 
-	// Add validation for other fields as needed
+	// Validate vertical rates (16-bit two's complement, LSB 6.25 ft/min)
+	if a.BarometricVertRate != nil && (*a.BarometricVertRate < -204800 || *a.BarometricVertRate > 204793.75) {
+		return fmt.Errorf("%w: barometric [-204800,204793.75]: %f", ErrVerticalRateRange, *a.BarometricVertRate)
+	}
+	if a.GeometricVertRate != nil && (*a.GeometricVertRate < -204800 || *a.GeometricVertRate > 204793.75) {
+		return fmt.Errorf("%w: geometric [-204800,204793.75]: %f", ErrVerticalRateRange, *a.GeometricVertRate)
+	}
+
+	// Validate GroundSpeed (maximum 2 NM/s)
+	if a.GroundSpeed != nil && (*a.GroundSpeed < 0 || *a.GroundSpeed > 7200) {
+		return fmt.Errorf("%w [0,7200]: %f", ErrGroundSpeedRange, *a.GroundSpeed)
+	}
+
+	// Validate GeoAltitude
+	if a.GeoAltitude != nil && (*a.GeoAltitude < -1500 || *a.GeoAltitude > 150000) {
+		return fmt.Errorf("%w [-1500,150000]: %f", ErrGeoAltitudeRange, *a.GeoAltitude)
+	}
+
+	if a.MetData != nil {
+		if err := a.MetData.Validate(); err != nil {
+			return err
+		}
+	}
 
+	if StrictValidation() {
+		return a.validateStrict()
+	}
 	return nil
 }
 
-// parseTargetIdentification converts the binary encoded aircraft identification to a string
-// This is a simplified implementation - a full implementation would follow ICAO Annex 10 encoding
-func parseTargetIdentification(data []byte) string {
-	// Simplified implementation - in a real system this would decode according to ICAO standards
-	chars := make([]byte, 8)
-
-	// Extract 6-bit characters from the data
-	chars[0] = (data[0] & 0xFC) >> 2
-	chars[1] = ((data[0] & 0x03) << 4) | ((data[1] & 0xF0) >> 4)
-	chars[2] = ((data[1] & 0x0F) << 2) | ((data[2] & 0xC0) >> 6)
-	chars[3] = data[2] & 0x3F
-	chars[4] = (data[3] & 0xFC) >> 2
-	chars[5] = ((data[3] & 0x03) << 4) | ((data[4] & 0xF0) >> 4)
-	chars[6] = ((data[4] & 0x0F) << 2) | ((data[5] & 0xC0) >> 6)
-	chars[7] = data[5] & 0x3F
-
-	// Convert to ASCII
-	result := make([]byte, 8)
-	for i, c := range chars {
-		// ICAO character set (space, A-Z, 0-9)
-		if c == 0 {
-			result[i] = ' ' // Space
-		} else if c <= 26 {
-			result[i] = 'A' + c - 1 // A-Z
-		} else if c <= 36 {
-			result[i] = '0' + c - 27 // 0-9
-		} else {
-			result[i] = ' ' // Default to space for invalid values
+// Reset implements asterix.Resetter
+func (a *AircraftDerivedData) Reset() {
+	*a = AircraftDerivedData{}
+}
+
+// validateStrict checks the populated fields Validate leaves alone outside
+// strict validation against the ranges of their encodings
+func (a *AircraftDerivedData) validateStrict() error {
+	if a.AirspeedMach != nil {
+		if a.IsMach && (*a.AirspeedMach < 0 || *a.AirspeedMach > 32.767) {
+			return fmt.Errorf("mach out of range [0,32.767]: %f", *a.AirspeedMach)
+		}
+		if !a.IsMach && (*a.AirspeedMach < 0 || *a.AirspeedMach > 0x7FFF*0.00006103515625) {
+			return fmt.Errorf("IAS out of range [0,2) NM/s: %f", *a.AirspeedMach)
+		}
+	}
+	if a.SelectedAltitude != nil && a.SelectedAltitude.Source > 3 {
+		return fmt.Errorf("selected altitude source out of range [0,3]: %d", a.SelectedAltitude.Source)
+	}
+	if f := a.FinalStateSelectedAlt; f != nil && (f.Altitude < -102400 || f.Altitude > 102375) {
+		return fmt.Errorf("final state selected altitude out of range [-102400,102375]: %f", f.Altitude)
+	}
+	if a.TrajectoryIntent != nil {
+		if len(a.TrajectoryIntent.Points) > 255 {
+			return fmt.Errorf("too many trajectory intent points: %d, max 255", len(a.TrajectoryIntent.Points))
+		}
+		for i, p := range a.TrajectoryIntent.Points {
+			if err := p.validate(); err != nil {
+				return fmt.Errorf("trajectory intent point %d: %w", i+1, err)
+			}
+		}
+	}
+	if s := a.ServiceStatus; s != nil {
+		if s.CommCapability > 7 || s.FlightStatus > 7 {
+			return fmt.Errorf("communications capability %d or flight status %d out of range [0,7]",
+				s.CommCapability, s.FlightStatus)
+		}
+		if s.BDS1_0Bits37to40 > 15 {
+			return fmt.Errorf("BDS 1,0 bits 37/40 out of range [0,15]: %d", s.BDS1_0Bits37to40)
+		}
+	}
+	if a.ACASStatus != nil && a.ACASStatus.FlightStatus > 7 {
+		return fmt.Errorf("ADS-B flight status out of range [0,7]: %d", a.ACASStatus.FlightStatus)
+	}
+	if a.ACASResolution != nil && len(a.ACASResolution) != 7 {
+		return fmt.Errorf("%w: ACAS resolution advisory report has %d bytes, want 7",
+			asterix.ErrInvalidLength, len(a.ACASResolution))
+	}
+	if a.TrackAngleRate != nil && (*a.TrackAngleRate < -32 || *a.TrackAngleRate > 31.75) {
+		return fmt.Errorf("track angle rate out of range [-32,31.75]: %f", *a.TrackAngleRate)
+	}
+	if a.TurnIndicator != nil && *a.TurnIndicator > 3 {
+		return fmt.Errorf("turn indicator out of range [0,3]: %d", *a.TurnIndicator)
+	}
+	if p := a.Position; p != nil {
+		if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude >= 180 {
+			return fmt.Errorf("position out of range: %f, %f", p.Latitude, p.Longitude)
+		}
+	}
+	if a.PositionUncertainty != nil && *a.PositionUncertainty > 15 {
+		return fmt.Errorf("position uncertainty out of range [0,15]: %d", *a.PositionUncertainty)
+	}
+	if len(a.ModeSMBData) > 255 {
+		return fmt.Errorf("too many Mode S MB data entries: %d, max 255", len(a.ModeSMBData))
+	}
+	for i, mb := range a.ModeSMBData {
+		if len(mb.Data) != 7 {
+			return fmt.Errorf("%w: Mode S MB data entry %d has %d bytes, want 7",
+				asterix.ErrInvalidLength, i+1, len(mb.Data))
+		}
+		if mb.BDS1 > 15 || mb.BDS2 > 15 {
+			return fmt.Errorf("Mode S MB data entry %d: BDS %d,%d out of range [0,15]", i+1, mb.BDS1, mb.BDS2)
 		}
 	}
+	if a.BarometricPressure != nil && (*a.BarometricPressure < 800 || *a.BarometricPressure > 1209.5) {
+		return fmt.Errorf("barometric pressure setting out of range [800,1209.5]: %f", *a.BarometricPressure)
+	}
+	return nil
+}
+
+// validate checks the point against the ranges of its encoding
+func (p TrajIntentPoint) validate() error {
+	switch {
+	case p.TCPNumber > 63:
+		return fmt.Errorf("TCP number out of range [0,63]: %d", p.TCPNumber)
+	case p.Altitude < -327680 || p.Altitude > 327670:
+		return fmt.Errorf("altitude out of range [-327680,327670]: %f", p.Altitude)
+	case p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude >= 180:
+		return fmt.Errorf("position out of range: %f, %f", p.Latitude, p.Longitude)
+	case p.PointType > 15:
+		return fmt.Errorf("point type out of range [0,15]: %d", p.PointType)
+	case p.TurnDirection > 3:
+		return fmt.Errorf("turn direction out of range [0,3]: %d", p.TurnDirection)
+	case p.TimeOverPoint > 0xFFFFFF:
+		return fmt.Errorf("time over point exceeds 24-bit limit: %d", p.TimeOverPoint)
+	case p.TCPTurnRadius < 0 || p.TCPTurnRadius > 655.35:
+		return fmt.Errorf("TCP turn radius out of range [0,655.35]: %f", p.TCPTurnRadius)
+	}
+	return nil
+}
 
-	// Trim trailing spaces
-	return strings.TrimRight(string(result), " ")
+// scaledSigned rounds v to a whole number of lsb and checks that it fits a
+// two's complement field of the given width
+func scaledSigned(name string, v, lsb float64, bits uint) (int32, error) {
+	limit := float64(int64(1) << (bits - 1))
+	counts := math.Round(v / lsb)
+	if !(counts >= -limit && counts < limit) {
+		return 0, fmt.Errorf("%w: %s out of range [%g,%g]: %g",
+			asterix.ErrInvalidField, name, -limit*lsb, (limit-1)*lsb, v)
+	}
+	return int32(counts), nil
 }
 
-// encodeTargetIdentification converts a string to the binary encoding for aircraft identification
-func encodeTargetIdentification(ident string) []byte {
-	// Pad with spaces to 8 characters
-	if len(ident) < 8 {
-		ident = ident + strings.Repeat(" ", 8-len(ident))
-	} else if len(ident) > 8 {
-		ident = ident[:8]
-	}
-
-	// Convert to 6-bit representation
-	chars := make([]byte, 8)
-	for i, c := range ident {
-		if c == ' ' {
-			chars[i] = 0
-		} else if c >= 'A' && c <= 'Z' {
-			chars[i] = byte(c - 'A' + 1)
-		} else if c >= '0' && c <= '9' {
-			chars[i] = byte(c - '0' + 27)
-		} else {
-			chars[i] = 0 // Default to space for invalid characters
+// scaledUnsigned rounds v to a whole number of lsb and checks that it fits an
+// unsigned field of the given width
+func scaledUnsigned(name string, v, lsb float64, bits uint) (uint32, error) {
+	limit := float64(int64(1) << bits)
+	counts := math.Round(v / lsb)
+	if !(counts >= 0 && counts < limit) {
+		return 0, fmt.Errorf("%w: %s out of range [0,%g]: %g",
+			asterix.ErrInvalidField, name, (limit-1)*lsb, v)
+	}
+	return uint32(counts), nil
+}
+
+// Validate checks the meteorological values against the ranges of I062/380
+// subfield #20
+func (m *Meteorological) Validate() error {
+	if m.WindSpeed != nil && (*m.WindSpeed < 0 || *m.WindSpeed > 300) {
+		return fmt.Errorf("%w: speed [0,300]: %f", ErrWindRange, *m.WindSpeed)
+	}
+	if m.WindDirection != nil && (*m.WindDirection < 0 || *m.WindDirection > 360) {
+		return fmt.Errorf("%w: direction [0,360]: %f", ErrWindRange, *m.WindDirection)
+	}
+	if m.Temperature != nil && (*m.Temperature < -100 || *m.Temperature > 100) {
+		return fmt.Errorf("%w [-100,100]: %f", ErrTemperatureRange, *m.Temperature)
+	}
+	if m.Turbulence != nil && *m.Turbulence > 15 {
+		return fmt.Errorf("%w [0,15]: %d", ErrTurbulenceRange, *m.Turbulence)
+	}
+	return nil
+}
+
+// DiscardRaw implements asterix.RawRetainer
+func (a *AircraftDerivedData) DiscardRaw() {
+	a.rawData = nil
+}
+
+// RawBytes returns the bytes retained from the last Decode, which Encode
+// replays; ok is false once they have been discarded
+func (a *AircraftDerivedData) RawBytes() ([]byte, bool) {
+	return a.rawData, len(a.rawData) > 0
+}
+
+// aircraftDerivedSubfields lists the I062/380 subfields in FRN order. The
+// FSPEC built by Encode, PresentSubfields and String all derive from this
+// table.
+var aircraftDerivedSubfields = []subfields.Field[*AircraftDerivedData]{
+	subfield("TargetAddress", func(a *AircraftDerivedData) bool { return a.TargetAddress != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%06X", *a.TargetAddress) }),
+	subfield("TargetIdentification", func(a *AircraftDerivedData) bool { return a.TargetIdentification != nil },
+		func(a *AircraftDerivedData) string { return *a.TargetIdentification }),
+	subfield("MagneticHeading", func(a *AircraftDerivedData) bool { return a.MagneticHeading != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.1f°", *a.MagneticHeading) }),
+	subfield("AirspeedMach", func(a *AircraftDerivedData) bool { return a.AirspeedMach != nil },
+		func(a *AircraftDerivedData) string {
+			if a.IsMach {
+				return fmt.Sprintf("Mach %.3f", *a.AirspeedMach)
+			}
+			return fmt.Sprintf("IAS %.1f kt", *a.AirspeedMach)
+		}),
+	subfield("TrueAirspeed", func(a *AircraftDerivedData) bool { return a.TrueAirspeed != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.0f kt", *a.TrueAirspeed) }),
+	subfield("SelectedAltitude", func(a *AircraftDerivedData) bool { return a.SelectedAltitude != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.0f ft", a.SelectedAltitude.Altitude) }),
+	subfield("FinalStateSelectedAlt", func(a *AircraftDerivedData) bool { return a.FinalStateSelectedAlt != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.0f ft", a.FinalStateSelectedAlt.Altitude) }),
+	subfield("TrajectoryIntentStatus", func(a *AircraftDerivedData) bool {
+		return a.TrajectoryIntent != nil && a.TrajectoryIntent.StatusPresent
+	}, func(a *AircraftDerivedData) string {
+		if a.TrajectoryIntent.Status == nil {
+			return "unknown"
+		}
+		return fmt.Sprintf("%+v", *a.TrajectoryIntent.Status)
+	}),
+	subfield("TrajectoryIntentData", func(a *AircraftDerivedData) bool {
+		return a.TrajectoryIntent != nil && len(a.TrajectoryIntent.Points) > 0
+	}, func(a *AircraftDerivedData) string { return fmt.Sprintf("%d points", len(a.TrajectoryIntent.Points)) }),
+	subfield("ServiceStatus", func(a *AircraftDerivedData) bool { return a.ServiceStatus != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%+v", *a.ServiceStatus) }),
+	subfield("ACASStatus", func(a *AircraftDerivedData) bool { return a.ACASStatus != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%+v", *a.ACASStatus) }),
+	subfield("ACASResolution", func(a *AircraftDerivedData) bool { return a.ACASResolution != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("% X", a.ACASResolution) }),
+	subfield("BarometricVertRate", func(a *AircraftDerivedData) bool { return a.BarometricVertRate != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.2f ft/min", *a.BarometricVertRate) }),
+	subfield("GeometricVertRate", func(a *AircraftDerivedData) bool { return a.GeometricVertRate != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.2f ft/min", *a.GeometricVertRate) }),
+	subfield("RollAngle", func(a *AircraftDerivedData) bool { return a.RollAngle != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.2f°", *a.RollAngle) }),
+	subfield("TrackAngleRate", func(a *AircraftDerivedData) bool { return a.TrackAngleRate != nil },
+		func(a *AircraftDerivedData) string {
+			if a.TurnIndicator != nil {
+				return fmt.Sprintf("%.2f°/s (turn indicator %d)", *a.TrackAngleRate, *a.TurnIndicator)
+			}
+			return fmt.Sprintf("%.2f°/s", *a.TrackAngleRate)
+		}),
+	subfield("TrackAngle", func(a *AircraftDerivedData) bool { return a.TrackAngle != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.1f°", *a.TrackAngle) }),
+	subfield("GroundSpeed", func(a *AircraftDerivedData) bool { return a.GroundSpeed != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.1f kt", *a.GroundSpeed) }),
+	subfield("VelocityUncertainty", func(a *AircraftDerivedData) bool { return a.VelocityUncertainty != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%d", *a.VelocityUncertainty) }),
+	subfield("MetData", func(a *AircraftDerivedData) bool { return a.MetData != nil },
+		func(a *AircraftDerivedData) string { return a.MetData.String() }),
+	subfield("EmitterCategory", func(a *AircraftDerivedData) bool { return a.EmitterCategory != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%d", *a.EmitterCategory) }),
+	subfield("Position", func(a *AircraftDerivedData) bool { return a.Position != nil },
+		func(a *AircraftDerivedData) string {
+			return fmt.Sprintf("%.6f,%.6f", a.Position.Latitude, a.Position.Longitude)
+		}),
+	subfield("GeoAltitude", func(a *AircraftDerivedData) bool { return a.GeoAltitude != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.0f ft", *a.GeoAltitude) }),
+	subfield("PositionUncertainty", func(a *AircraftDerivedData) bool { return a.PositionUncertainty != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%d", *a.PositionUncertainty) }),
+	subfield("ModeSMBData", func(a *AircraftDerivedData) bool { return a.ModeSMBData != nil },
+		func(a *AircraftDerivedData) string {
+			bds := make([]string, len(a.ModeSMBData))
+			for i, mb := range a.ModeSMBData {
+				bds[i] = fmt.Sprintf("BDS %d,%d", mb.BDS1, mb.BDS2)
+			}
+			return strings.Join(bds, " ")
+		}),
+	subfield("IAS", func(a *AircraftDerivedData) bool { return a.IAS != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.0f kt", *a.IAS) }),
+	subfield("Mach", func(a *AircraftDerivedData) bool { return a.Mach != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.3f", *a.Mach) }),
+	subfield("BarometricPressure", func(a *AircraftDerivedData) bool { return a.BarometricPressure != nil },
+		func(a *AircraftDerivedData) string { return fmt.Sprintf("%.1f mb", *a.BarometricPressure) }),
+}
+
+// subfield builds an entry of aircraftDerivedSubfields
+func subfield(name string, present func(*AircraftDerivedData) bool, format func(*AircraftDerivedData) string) subfields.Field[*AircraftDerivedData] {
+	return subfields.Field[*AircraftDerivedData]{Name: name, Present: present, Format: format}
+}
+
+var aircraftDerivedStringer = subfields.Stringer[*AircraftDerivedData]{
+	Type:   "AircraftDerivedData",
+	Fields: aircraftDerivedSubfields,
+}
+
+// String lists the meteorological values that are present
+func (m *Meteorological) String() string {
+	var parts []string
+	if m.WindSpeed != nil {
+		parts = append(parts, fmt.Sprintf("wind %.0f kt", *m.WindSpeed))
+	}
+	if m.WindDirection != nil {
+		parts = append(parts, fmt.Sprintf("from %.0f°", *m.WindDirection))
+	}
+	if m.Temperature != nil {
+		parts = append(parts, fmt.Sprintf("%.2f °C", *m.Temperature))
+	}
+	if m.Turbulence != nil {
+		parts = append(parts, fmt.Sprintf("turbulence %d", *m.Turbulence))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, " ")
+}
+
+// buildFSPEC returns the primary subfield for the present subfields, 7 FRNs
+// per octet with FX set on every octet but the last. At least one octet is
+// always produced.
+func (a *AircraftDerivedData) buildFSPEC() ([4]byte, int) {
+	var fspec [4]byte
+	fspecLen := 1
+	for i, sf := range aircraftDerivedSubfields {
+		if !sf.Present(a) {
+			continue
+		}
+		octet := i / 7
+		fspec[octet] |= 0x80 >> (i % 7)
+		if octet+1 > fspecLen {
+			fspecLen = octet + 1
 		}
 	}
+	for i := 0; i < fspecLen-1; i++ {
+		fspec[i] |= 0x01
+	}
+	return fspec, fspecLen
+}
 
-	// Pack into 6 bytes
-	result := make([]byte, 6)
-	result[0] = (chars[0] << 2) | (chars[1] >> 4)
-	result[1] = ((chars[1] & 0x0F) << 4) | (chars[2] >> 2)
-	result[2] = ((chars[2] & 0x03) << 6) | chars[3]
-	result[3] = (chars[4] << 2) | (chars[5] >> 4)
-	result[4] = ((chars[5] & 0x0F) << 4) | (chars[6] >> 2)
-	result[5] = ((chars[6] & 0x03) << 6) | chars[7]
+// FSPECLen returns the number of primary subfield octets (1-4) Encode will
+// emit. Retained raw bytes are replayed as they are, so their own FX chain
+// is measured instead of the present subfields.
+func (a *AircraftDerivedData) FSPECLen() int {
+	if len(a.rawData) > 0 {
+		n := 1
+		for n < len(a.rawData) && a.rawData[n-1]&0x01 != 0 {
+			n++
+		}
+		return n
+	}
+	_, fspecLen := a.buildFSPEC()
+	return fspecLen
+}
 
-	return result
+// PresentSubfields returns the names of the subfields present, in FRN order.
+// The list matches the FSPEC bits Encode writes.
+func (a *AircraftDerivedData) PresentSubfields() []string {
+	return aircraftDerivedStringer.Present(a)
 }
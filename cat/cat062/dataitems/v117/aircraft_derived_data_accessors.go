@@ -0,0 +1,83 @@
+// dataitems/cat062/aircraft_derived_data_accessors.go
+package v117
+
+// Value-or-default accessors for the commonly used I062/380 subfields. Each
+// HasX reports whether the subfield is present; XOr returns its value or def.
+
+// HasTargetAddress reports whether subfield #1 (ADR) is present
+func (a *AircraftDerivedData) HasTargetAddress() bool {
+	return a.TargetAddress != nil
+}
+
+// TargetAddressOr returns the 24-bit target address, or def if absent
+func (a *AircraftDerivedData) TargetAddressOr(def uint32) uint32 {
+	if a.TargetAddress == nil {
+		return def
+	}
+	return *a.TargetAddress
+}
+
+// HasTargetIdentification reports whether subfield #2 (ID) is present
+func (a *AircraftDerivedData) HasTargetIdentification() bool {
+	return a.TargetIdentification != nil
+}
+
+// TargetIdentificationOr returns the target identification, or def if absent
+func (a *AircraftDerivedData) TargetIdentificationOr(def string) string {
+	if a.TargetIdentification == nil {
+		return def
+	}
+	return *a.TargetIdentification
+}
+
+// HasTrackAngle reports whether subfield #17 (TAN) is present
+func (a *AircraftDerivedData) HasTrackAngle() bool {
+	return a.TrackAngle != nil
+}
+
+// TrackAngleOr returns the track angle in degrees, or def if absent
+func (a *AircraftDerivedData) TrackAngleOr(def float64) float64 {
+	if a.TrackAngle == nil {
+		return def
+	}
+	return *a.TrackAngle
+}
+
+// HasGroundSpeed reports whether subfield #18 (GS) is present
+func (a *AircraftDerivedData) HasGroundSpeed() bool {
+	return a.GroundSpeed != nil
+}
+
+// GroundSpeedOr returns the ground speed in knots, or def if absent
+func (a *AircraftDerivedData) GroundSpeedOr(def float64) float64 {
+	if a.GroundSpeed == nil {
+		return def
+	}
+	return *a.GroundSpeed
+}
+
+// HasPosition reports whether subfield #22 (POS) is present
+func (a *AircraftDerivedData) HasPosition() bool {
+	return a.Position != nil
+}
+
+// PositionOr returns the WGS-84 latitude and longitude, or the defaults if absent
+func (a *AircraftDerivedData) PositionOr(defLat, defLon float64) (float64, float64) {
+	if a.Position == nil {
+		return defLat, defLon
+	}
+	return a.Position.Latitude, a.Position.Longitude
+}
+
+// HasGeoAltitude reports whether subfield #23 (GAL) is present
+func (a *AircraftDerivedData) HasGeoAltitude() bool {
+	return a.GeoAltitude != nil
+}
+
+// GeoAltitudeOr returns the geometric altitude in feet, or def if absent
+func (a *AircraftDerivedData) GeoAltitudeOr(def float64) float64 {
+	if a.GeoAltitude == nil {
+		return def
+	}
+	return *a.GeoAltitude
+}
@@ -0,0 +1,209 @@
+// dataitems/cat062/aircraft_derived_data_accessors_test.go
+package v117_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+)
+
+func TestAircraftDerivedData_Accessors(t *testing.T) {
+	populated := &v117.AircraftDerivedData{
+		TargetAddress:        ptr(uint32(0x3C6586)),
+		TargetIdentification: ptr("DLH4AB"),
+		TrackAngle:           ptr(271.5),
+		GroundSpeed:          ptr(432.0),
+		Position:             &v117.WGS84Position{Latitude: 50.1, Longitude: 8.6},
+		GeoAltitude:          ptr(36125.0),
+	}
+	empty := &v117.AircraftDerivedData{}
+
+	if !populated.HasTargetAddress() || populated.TargetAddressOr(0) != 0x3C6586 {
+		t.Errorf("TargetAddressOr() = %06X, want 3C6586", populated.TargetAddressOr(0))
+	}
+	if !populated.HasTargetIdentification() || populated.TargetIdentificationOr("") != "DLH4AB" {
+		t.Errorf("TargetIdentificationOr() = %q, want DLH4AB", populated.TargetIdentificationOr(""))
+	}
+	if !populated.HasTrackAngle() || populated.TrackAngleOr(-1) != 271.5 {
+		t.Errorf("TrackAngleOr() = %v, want 271.5", populated.TrackAngleOr(-1))
+	}
+	if !populated.HasGroundSpeed() || populated.GroundSpeedOr(-1) != 432.0 {
+		t.Errorf("GroundSpeedOr() = %v, want 432", populated.GroundSpeedOr(-1))
+	}
+	if lat, lon := populated.PositionOr(0, 0); !populated.HasPosition() || lat != 50.1 || lon != 8.6 {
+		t.Errorf("PositionOr() = (%v, %v), want (50.1, 8.6)", lat, lon)
+	}
+	if !populated.HasGeoAltitude() || populated.GeoAltitudeOr(-1) != 36125.0 {
+		t.Errorf("GeoAltitudeOr() = %v, want 36125", populated.GeoAltitudeOr(-1))
+	}
+
+	if empty.HasTargetAddress() || empty.HasTargetIdentification() || empty.HasTrackAngle() ||
+		empty.HasGroundSpeed() || empty.HasPosition() || empty.HasGeoAltitude() {
+		t.Error("empty item reports a subfield as present")
+	}
+	if got := empty.TargetAddressOr(0xFFFFFF); got != 0xFFFFFF {
+		t.Errorf("TargetAddressOr() = %06X, want default FFFFFF", got)
+	}
+	if got := empty.TargetIdentificationOr("UNKNOWN"); got != "UNKNOWN" {
+		t.Errorf("TargetIdentificationOr() = %q, want default", got)
+	}
+	if got := empty.TrackAngleOr(-1); got != -1 {
+		t.Errorf("TrackAngleOr() = %v, want default -1", got)
+	}
+	if got := empty.GroundSpeedOr(-1); got != -1 {
+		t.Errorf("GroundSpeedOr() = %v, want default -1", got)
+	}
+	if lat, lon := empty.PositionOr(1, 2); lat != 1 || lon != 2 {
+		t.Errorf("PositionOr() = (%v, %v), want defaults (1, 2)", lat, lon)
+	}
+	if got := empty.GeoAltitudeOr(-1); got != -1 {
+		t.Errorf("GeoAltitudeOr() = %v, want default -1", got)
+	}
+}
+
+func TestAircraftDerivedData_PresentSubfields(t *testing.T) {
+	item := &v117.AircraftDerivedData{
+		TargetAddress: ptr(uint32(0x3C6586)),
+		GroundSpeed:   ptr(432.0),
+		Position:      &v117.WGS84Position{Latitude: 50.1, Longitude: 8.6},
+	}
+
+	want := []string{"TargetAddress", "GroundSpeed", "Position"}
+	if got := item.PresentSubfields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PresentSubfields() = %v, want %v", got, want)
+	}
+
+	// FRN 1, 18 and 22 spread over four FSPEC octets
+	var buf bytes.Buffer
+	if _, err := item.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	wantFSPEC := []byte{0x81, 0x01, 0x11, 0x80}
+	if got := buf.Bytes()[:4]; !bytes.Equal(got, wantFSPEC) {
+		t.Errorf("FSPEC = % X, want % X", got, wantFSPEC)
+	}
+
+	if got := (&v117.AircraftDerivedData{}).PresentSubfields(); len(got) != 0 {
+		t.Errorf("PresentSubfields() of empty item = %v, want none", got)
+	}
+}
+
+func TestAircraftDerivedData_String(t *testing.T) {
+	item := newAircraftDerivedData()
+	item.RollAngle = ptr(-12.5)
+	item.GroundSpeed = ptr(432.0)
+	item.Position = &v117.WGS84Position{Latitude: 50.1, Longitude: 8.6}
+	item.GeoAltitude = ptr(36125.0)
+
+	got := item.String()
+	for _, want := range []string{
+		"TargetAddress: 3C6586",
+		"RollAngle: -12.50°",
+		"GroundSpeed: 432.0 kt",
+		"Position: 50.100000,8.600000",
+		"GeoAltitude: 36125 ft",
+		"MetData: wind 45 kt -52.25 °C",
+		"ModeSMBData: BDS 4,0 BDS 5,0",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %q, missing %q", got, want)
+		}
+	}
+
+	// Every present subfield is listed
+	for _, name := range item.PresentSubfields() {
+		if !strings.Contains(got, name) {
+			t.Errorf("String() omits present subfield %s", name)
+		}
+	}
+
+	if got := (&v117.AircraftDerivedData{}).String(); got != "AircraftDerivedData[empty]" {
+		t.Errorf("String() of empty item = %q", got)
+	}
+}
+
+func TestAircraftDerivedData_ValidateRanges(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(a *v117.AircraftDerivedData)
+		want error
+	}{
+		{"negative ground speed", func(a *v117.AircraftDerivedData) { a.GroundSpeed = ptr(-1.0) }, v117.ErrGroundSpeedRange},
+		{"ground speed above 2 NM/s", func(a *v117.AircraftDerivedData) { a.GroundSpeed = ptr(7201.0) }, v117.ErrGroundSpeedRange},
+		{"geo altitude below range", func(a *v117.AircraftDerivedData) { a.GeoAltitude = ptr(-1600.0) }, v117.ErrGeoAltitudeRange},
+		{"geo altitude above range", func(a *v117.AircraftDerivedData) { a.GeoAltitude = ptr(150001.0) }, v117.ErrGeoAltitudeRange},
+		{"barometric vertical rate", func(a *v117.AircraftDerivedData) { a.BarometricVertRate = ptr(204800.0) }, v117.ErrVerticalRateRange},
+		{"geometric vertical rate", func(a *v117.AircraftDerivedData) { a.GeometricVertRate = ptr(-204806.25) }, v117.ErrVerticalRateRange},
+		{"wind speed", func(a *v117.AircraftDerivedData) { a.MetData.WindSpeed = ptr(301.0) }, v117.ErrWindRange},
+		{"wind direction", func(a *v117.AircraftDerivedData) { a.MetData.WindDirection = ptr(361.0) }, v117.ErrWindRange},
+		{"temperature", func(a *v117.AircraftDerivedData) { a.MetData.Temperature = ptr(-100.25) }, v117.ErrTemperatureRange},
+		{"turbulence", func(a *v117.AircraftDerivedData) { a.MetData.Turbulence = ptr(uint8(16)) }, v117.ErrTurbulenceRange},
+	}
+
+	if err := newAircraftDerivedData().Validate(); err != nil {
+		t.Fatalf("Validate() of valid item error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newAircraftDerivedData()
+			tt.set(item)
+			err := item.Validate()
+			if !errors.Is(err, tt.want) || !errors.Is(err, asterix.ErrInvalidField) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAircraftDerivedData_FSPECLen(t *testing.T) {
+	value := 90.0
+	tests := []struct {
+		name string
+		item v117.AircraftDerivedData
+		want int
+	}{
+		{"empty", v117.AircraftDerivedData{}, 1},
+		{"first octet", v117.AircraftDerivedData{TargetAddress: ptr(uint32(0x3C6586))}, 1},
+		{"second octet", v117.AircraftDerivedData{BarometricVertRate: &value}, 2},
+		{"third octet", v117.AircraftDerivedData{TrackAngle: &value}, 3},
+		{"fourth octet", v117.AircraftDerivedData{Position: &v117.WGS84Position{Latitude: 50, Longitude: 8}}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.FSPECLen(); got != tt.want {
+				t.Errorf("FSPECLen() = %d, want %d", got, tt.want)
+			}
+
+			// Encode writes that many octets, FX set on all but the last
+			var buf bytes.Buffer
+			if _, err := tt.item.Encode(&buf); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			octets := 1
+			for buf.Bytes()[octets-1]&0x01 != 0 {
+				octets++
+			}
+			if octets != tt.want {
+				t.Errorf("Encode() wrote %d FSPEC octets, want %d", octets, tt.want)
+			}
+		})
+	}
+
+	// Decoded items replay their raw bytes, FSPEC included
+	var buf bytes.Buffer
+	if _, err := newAircraftDerivedData().Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var decoded v117.AircraftDerivedData
+	if _, err := decoded.Decode(&buf); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := decoded.FSPECLen(); got != 4 {
+		t.Errorf("FSPECLen() of decoded item = %d, want 4", got)
+	}
+}
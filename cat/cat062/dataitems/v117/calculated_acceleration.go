@@ -67,6 +67,11 @@ func (c *CalculatedAcceleration) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (c *CalculatedAcceleration) Reset() {
+	*c = CalculatedAcceleration{}
+}
+
 func (c *CalculatedAcceleration) String() string {
 	magnitude := math.Sqrt(c.Ax*c.Ax + c.Ay*c.Ay)
 	direction := math.Atan2(c.Ax, c.Ay) * 180 / math.Pi
@@ -0,0 +1,53 @@
+// dataitems/cat062/calculated_rate_of_climb_descent_test.go
+package v117_test
+
+import (
+	"bytes"
+	"testing"
+
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+)
+
+func TestCalculatedRateOfClimbDescent_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		rate float64
+		raw  []byte
+		str  string
+	}{
+		{"climb", 1500, []byte{0x00, 0xF0}, "Rate of Climb: 1500 ft/min"},
+		{"descent", -2006.25, []byte{0xFE, 0xBF}, "Rate of Descent: 2006 ft/min"},
+		{"level", 0, []byte{0x00, 0x00}, "Level Flight"},
+		{"most negative", -204800, []byte{0x80, 0x00}, "Rate of Descent: 204800 ft/min"},
+		{"most positive", 204793.75, []byte{0x7F, 0xFF}, "Rate of Climb: 204794 ft/min"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := v117.CalculatedRateOfClimbDescent{Rate: tt.rate}
+
+			var buf bytes.Buffer
+			if _, err := in.Encode(&buf); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.raw) {
+				t.Fatalf("Encode() = % X, want % X", buf.Bytes(), tt.raw)
+			}
+
+			var out v117.CalculatedRateOfClimbDescent
+			if _, err := out.Decode(&buf); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if out.RateFtMin() != tt.rate {
+				t.Errorf("RateFtMin() = %v, want %v", out.RateFtMin(), tt.rate)
+			}
+			if out.String() != tt.str {
+				t.Errorf("String() = %q, want %q", out.String(), tt.str)
+			}
+		})
+	}
+
+	if err := (&v117.CalculatedRateOfClimbDescent{Rate: 204800}).Validate(); err == nil {
+		t.Errorf("Validate() accepted a rate beyond the 16-bit range")
+	}
+}
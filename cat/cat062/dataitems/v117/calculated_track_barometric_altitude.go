@@ -67,6 +67,11 @@ func (c *CalculatedTrackBarometricAltitude) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (c *CalculatedTrackBarometricAltitude) Reset() {
+	*c = CalculatedTrackBarometricAltitude{}
+}
+
 func (c *CalculatedTrackBarometricAltitude) String() string {
 	// Convert flight levels to feet (1 FL = 100 ft)
 	altitudeInFeet := c.Altitude * 100
@@ -78,3 +83,8 @@ func (c *CalculatedTrackBarometricAltitude) String() string {
 
 	return fmt.Sprintf("Barometric Altitude: %.0f ft (FL %.2f)%s", altitudeInFeet, c.Altitude, qnhInfo)
 }
+
+// AltitudeFeet implements asterix.AltitudeProvider
+func (c *CalculatedTrackBarometricAltitude) AltitudeFeet() float64 {
+	return c.Altitude * 100
+}
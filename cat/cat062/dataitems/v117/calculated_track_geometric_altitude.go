@@ -58,6 +58,16 @@ func (c *CalculatedTrackGeometricAltitude) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (c *CalculatedTrackGeometricAltitude) Reset() {
+	*c = CalculatedTrackGeometricAltitude{}
+}
+
 func (c *CalculatedTrackGeometricAltitude) String() string {
 	return fmt.Sprintf("Geometric Altitude: %.2f ft", c.Altitude)
 }
+
+// AltitudeFeet implements asterix.AltitudeProvider
+func (c *CalculatedTrackGeometricAltitude) AltitudeFeet() float64 {
+	return c.Altitude
+}
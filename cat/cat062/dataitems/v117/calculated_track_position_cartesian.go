@@ -87,6 +87,11 @@ func (p *CalculatedTrackPositionCartesian) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (p *CalculatedTrackPositionCartesian) Reset() {
+	*p = CalculatedTrackPositionCartesian{}
+}
+
 func (p *CalculatedTrackPositionCartesian) String() string {
 	return fmt.Sprintf("X: %.1fm, Y: %.1fm", p.X, p.Y)
 }
@@ -144,6 +144,11 @@ func (p *CalculatedPositionWGS84) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (p *CalculatedPositionWGS84) Reset() {
+	*p = CalculatedPositionWGS84{}
+}
+
 // formatCoordinate formats a coordinate value in degrees, minutes, seconds format
 // with appropriate precision
 func formatCoordinate(value float64) string {
@@ -156,3 +161,19 @@ func formatCoordinate(value float64) string {
 	// for the data item's resolution (approximately 0.5 meters at the equator)
 	return fmt.Sprintf("%d°%d'%.4f\"", degrees, minutes, seconds)
 }
+
+// WGS84 implements asterix.PositionProvider
+func (p *CalculatedPositionWGS84) WGS84() (float64, float64) {
+	return p.Latitude, p.Longitude
+}
+
+// GetField implements asterix.FieldGetter for Latitude and Longitude
+func (p *CalculatedPositionWGS84) GetField(name string) (any, bool) {
+	switch name {
+	case "Latitude":
+		return p.Latitude, true
+	case "Longitude":
+		return p.Longitude, true
+	}
+	return nil, false
+}
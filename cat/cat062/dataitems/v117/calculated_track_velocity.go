@@ -126,3 +126,8 @@ func (v *CalculatedTrackVelocity) Validate() error {
 
 	return nil
 }
+
+// Reset implements asterix.Resetter
+func (v *CalculatedTrackVelocity) Reset() {
+	*v = CalculatedTrackVelocity{}
+}
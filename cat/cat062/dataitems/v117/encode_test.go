@@ -0,0 +1,339 @@
+// dataitems/cat062/encode_test.go
+package v117_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func newAircraftDerivedData() *v117.AircraftDerivedData {
+	return &v117.AircraftDerivedData{
+		TargetAddress:         ptr(uint32(0x3C6586)),
+		TargetIdentification:  ptr("DLH4AB"),
+		MagneticHeading:       ptr(90.0),
+		AirspeedMach:          ptr(0.78),
+		IsMach:                true,
+		TrueAirspeed:          ptr(450.0),
+		SelectedAltitude:      &v117.SelectedAlt{SourceAvailable: true, Source: 2, Altitude: 35000},
+		FinalStateSelectedAlt: &v117.FinalStateAlt{AltitudeHold: true, Altitude: 35000},
+		TrajectoryIntent: &v117.TrajIntent{
+			StatusPresent: true,
+			Status:        &v117.TrajIntentStatus{NavigationAvailable: true, NavigationValid: true},
+			Points: []v117.TrajIntentPoint{
+				{TCPAvailable: true, TCPCompliance: true, TCPNumber: 1, Altitude: 35000, Latitude: 50.1, Longitude: 8.6, PointType: 1, TOAAvailable: true, TimeOverPoint: 3600, TCPTurnRadius: 1.5},
+				{TCPAvailable: true, TCPNumber: 2, Altitude: 12000, Latitude: 48.3, Longitude: 11.7, PointType: 2, TurnDirection: 1, TurnRadiusAvail: true, TimeOverPoint: 7200},
+			},
+		},
+		MetData: &v117.Meteorological{
+			WindSpeedValid:   true,
+			TemperatureValid: true,
+			WindSpeed:        ptr(45.0),
+			Temperature:      ptr(-52.25),
+		},
+		ModeSMBData: []v117.ModeSMB{
+			{BDS1: 4, BDS2: 0, Data: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}},
+			{BDS1: 5, BDS2: 0, Data: []byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17}},
+		},
+	}
+}
+
+func newFlightPlanRelatedData() *v117.FlightPlanRelatedData {
+	return &v117.FlightPlanRelatedData{
+		FPPSSAC:                 ptr(uint8(25)),
+		FPPSSIC:                 ptr(uint8(100)),
+		Callsign:                ptr("DLH4AB"),
+		IFPSFlightIDType:        ptr(uint8(1)),
+		IFPSFlightIDNum:         ptr(uint32(12345678)),
+		FlightCategory:          ptr(uint8(1)),
+		FlightRules:             ptr(uint8(0)),
+		RVSM:                    ptr(uint8(1)),
+		HighPriorityFlight:      true,
+		TypeOfAircraft:          ptr("A32"),
+		WakeTurbulenceCategory:  ptr(byte('M')),
+		DepartureAirport:        ptr("EDDF"),
+		DestinationAirport:      ptr("EDDM"),
+		RunwayNumber1:           ptr(uint8('2')),
+		RunwayNumber2:           ptr(uint8('5')),
+		RunwayLetter:            ptr(uint8('L')),
+		ClearedFlightLevel:      ptr(350.0),
+		ControlCentre:           ptr(uint8(3)),
+		ControlPosition:         ptr(uint8(7)),
+		TimeTypeList:            []uint8{1, 2},
+		DayList:                 []uint8{0, 0},
+		HourList:                []uint8{10, 11},
+		MinuteList:              []uint8{30, 45},
+		SecondList:              []uint8{15, 0},
+		SecondAvailList:         []bool{true, false},
+		AircraftStand:           ptr("V12"),
+		StandEmpty:              ptr(uint8(1)),
+		StandAvailable:          ptr(uint8(2)),
+		SID:                     ptr("ANEKI1L"),
+		STAR:                    ptr("ROKI"),
+		PreEmergencyMode3A:      ptr(uint16(0o1234)),
+		PreEmergencyMode3AValid: true,
+		PreEmergencyCallsign:    ptr("DLH4ABCDEF"),
+	}
+}
+
+func TestEncode_GoldenBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		item interface {
+			Encode(*bytes.Buffer) (int, error)
+		}
+		want []byte
+	}{
+		{"I062/380", newAircraftDerivedData(), []byte{
+			0xFF, 0xC1, 0x05, 0x10, 0x3C, 0x65, 0x86, 0x10, 0xC2, 0x34, 0x04, 0x28,
+			0x20, 0x40, 0x00, 0x83, 0x0C, 0x01, 0xC2, 0x85, 0x78, 0x45, 0x78, 0x00,
+			0x02, 0x01, 0x0D, 0xAC, 0x23, 0xA0, 0x6D, 0x06, 0x1D, 0x95, 0x10, 0x00,
+			0x0E, 0x10, 0x00, 0x96, 0x42, 0x04, 0xB0, 0x22, 0x58, 0xBF, 0x08, 0x51,
+			0xEB, 0x27, 0x00, 0x1C, 0x20, 0x00, 0x00, 0xA0, 0x00, 0x2D, 0x00, 0x00,
+			0xFF, 0x2F, 0x00, 0x02, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x40,
+			0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x50,
+		}},
+		{"I062/390", newFlightPlanRelatedData(), []byte{
+			0xFF, 0xFF, 0xF0, 0x19, 0x64, 0x44, 0x4C, 0x48, 0x34, 0x41, 0x42, 0x20,
+			0x40, 0xBC, 0x61, 0x4E, 0x46, 0x41, 0x33, 0x32, 0x20, 0x4D, 0x45, 0x44,
+			0x44, 0x46, 0x45, 0x44, 0x44, 0x4D, 0x32, 0x35, 0x4C, 0x05, 0x78, 0x03,
+			0x07, 0x02, 0x08, 0x28, 0x3C, 0x1E, 0x10, 0x2C, 0x5A, 0x80, 0x56, 0x31,
+			0x32, 0x20, 0x20, 0x20, 0x60, 0x41, 0x4E, 0x45, 0x4B, 0x49, 0x31, 0x4C,
+			0x52, 0x4F, 0x4B, 0x49, 0x20, 0x20, 0x20, 0x0A, 0x9C, 0x44, 0x4C, 0x48,
+			0x34, 0x41, 0x42, 0x43,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := tt.item.Encode(&buf)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("Encode() = % X, want % X", buf.Bytes(), tt.want)
+			}
+			if n != buf.Len() {
+				t.Errorf("Encode() reported %d bytes, wrote %d", n, buf.Len())
+			}
+		})
+	}
+}
+
+// TestAircraftDerivedData_EncodeEachSubfield sets one subfield at a time and
+// checks that its encoding decodes back to the same struct and re-encodes to
+// the same bytes. Values are multiples of the subfield LSBs.
+func TestAircraftDerivedData_EncodeEachSubfield(t *testing.T) {
+	tests := []struct {
+		name string
+		item v117.AircraftDerivedData
+	}{
+		{"ServiceStatus", v117.AircraftDerivedData{ServiceStatus: &v117.SvcStatus{
+			CommCapability: 1, FlightStatus: 1, SpecificService: true, AltitudeReporting: true,
+			AircraftIdent: true, BDS1_0Bit16: true, BDS1_0Bits37to40: 5}}},
+		{"ACASStatus", v117.AircraftDerivedData{ACASStatus: &v117.ADSBStatus{
+			ACASOperational: true, DifferentialCorrection: true, GroundBit: true, FlightStatus: 3}}},
+		{"ACASResolution", v117.AircraftDerivedData{ACASResolution: []byte{1, 2, 3, 4, 5, 6, 7}}},
+		{"BarometricVertRate", v117.AircraftDerivedData{BarometricVertRate: ptr(-1600.0)}},
+		{"GeometricVertRate", v117.AircraftDerivedData{GeometricVertRate: ptr(2500.0)}},
+		{"RollAngle", v117.AircraftDerivedData{RollAngle: ptr(-12.5)}},
+		{"TrackAngleRate", v117.AircraftDerivedData{TrackAngleRate: ptr(-2.25), TurnIndicator: ptr(uint8(2))}},
+		{"TrackAngle", v117.AircraftDerivedData{TrackAngle: ptr(270.0)}},
+		{"GroundSpeed", v117.AircraftDerivedData{GroundSpeed: ptr(56.25)}},
+		{"VelocityUncertainty", v117.AircraftDerivedData{VelocityUncertainty: ptr(uint8(3))}},
+		{"EmitterCategory", v117.AircraftDerivedData{EmitterCategory: ptr(uint8(5))}},
+		{"Position", v117.AircraftDerivedData{Position: &v117.WGS84Position{Latitude: 45, Longitude: -90}}},
+		{"GeoAltitude", v117.AircraftDerivedData{GeoAltitude: ptr(37000.0)}},
+		{"PositionUncertainty", v117.AircraftDerivedData{PositionUncertainty: ptr(uint8(7))}},
+		{"IAS", v117.AircraftDerivedData{IAS: ptr(280.0)}},
+		{"Mach", v117.AircraftDerivedData{Mach: ptr(100 * 0.008)}},
+		{"BarometricPressure", v117.AircraftDerivedData{BarometricPressure: ptr(800 + 2130*0.1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := tt.item.Encode(&buf)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if n != buf.Len() {
+				t.Errorf("Encode() reported %d bytes, wrote %d", n, buf.Len())
+			}
+			encoded := bytes.Clone(buf.Bytes())
+
+			var decoded v117.AircraftDerivedData
+			if _, err := decoded.Decode(&buf); err != nil {
+				t.Fatalf("Decode(% X) error = %v", encoded, err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("Decode(% X) left %d bytes", encoded, buf.Len())
+			}
+			decoded.DiscardRaw()
+			if !reflect.DeepEqual(decoded, tt.item) {
+				t.Errorf("Decode(% X) = %v, want %v", encoded, &decoded, &tt.item)
+			}
+
+			var again bytes.Buffer
+			if _, err := decoded.Encode(&again); err != nil {
+				t.Fatalf("re-Encode() error = %v", err)
+			}
+			if !bytes.Equal(again.Bytes(), encoded) {
+				t.Errorf("re-Encode() = % X, want % X", again.Bytes(), encoded)
+			}
+		})
+	}
+}
+
+func TestAircraftDerivedData_GroundSpeedScaling(t *testing.T) {
+	// LSB = 2^-14 NM/s, i.e. 3600/16384 kt
+	tests := []struct {
+		raw []byte
+		kt  float64
+	}{
+		{[]byte{0x00, 0x01}, 0.2197265625},
+		{[]byte{0x10, 0x00}, 900},
+		{[]byte{0x40, 0x00}, 3600},
+		{[]byte{0x80, 0x00}, 7200},
+	}
+	for _, tt := range tests {
+		sample := append([]byte{0x01, 0x01, 0x10}, tt.raw...)
+		var item v117.AircraftDerivedData
+		if _, err := item.Decode(bytes.NewBuffer(sample)); err != nil {
+			t.Fatalf("Decode(% X) error = %v", sample, err)
+		}
+		if got := item.GroundSpeedOr(-1); math.Abs(got-tt.kt) > 1e-9 {
+			t.Errorf("Decode(% X) ground speed = %v kt, want %v", sample, got, tt.kt)
+		}
+
+		item.DiscardRaw()
+		var buf bytes.Buffer
+		if _, err := item.Encode(&buf); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		if !bytes.Equal(buf.Bytes(), sample) {
+			t.Errorf("Encode() = % X, want % X", buf.Bytes(), sample)
+		}
+	}
+}
+
+func TestAircraftDerivedData_TrajectoryIntentStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample []byte
+		want   v117.TrajIntentStatus
+	}{
+		{"single octet", []byte{0x01, 0x80, 0x40}, v117.TrajIntentStatus{NavigationAvailable: true}},
+		{"zero extension", []byte{0x01, 0x80, 0x81, 0x00},
+			v117.TrajIntentStatus{NavigationValid: true, Extensions: []uint8{0x00}}},
+		{"two extensions", []byte{0x01, 0x80, 0x01, 0x03, 0xA0},
+			v117.TrajIntentStatus{NavigationAvailable: true, NavigationValid: true, Extensions: []uint8{0x02, 0xA0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var item v117.AircraftDerivedData
+			n, err := item.Decode(bytes.NewBuffer(tt.sample))
+			if err != nil {
+				t.Fatalf("Decode(% X) error = %v", tt.sample, err)
+			}
+			if n != len(tt.sample) {
+				t.Errorf("Decode() consumed %d bytes, want %d", n, len(tt.sample))
+			}
+			if item.TrajectoryIntent == nil || !reflect.DeepEqual(item.TrajectoryIntent.Status, &tt.want) {
+				t.Fatalf("Decode() trajectory intent = %+v, want status %+v", item.TrajectoryIntent, tt.want)
+			}
+
+			item.DiscardRaw()
+			var buf bytes.Buffer
+			if _, err := item.Encode(&buf); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.sample) {
+				t.Errorf("Encode() = % X, want % X", buf.Bytes(), tt.sample)
+			}
+		})
+	}
+
+	truncated := []byte{0x01, 0x80, 0x01}
+	var item v117.AircraftDerivedData
+	if _, err := item.Decode(bytes.NewBuffer(truncated)); !errors.Is(err, asterix.ErrBufferTooShort) {
+		t.Errorf("Decode(% X) error = %v, want %v", truncated, err, asterix.ErrBufferTooShort)
+	}
+}
+
+func TestAircraftDerivedData_EncodeACASResolutionLength(t *testing.T) {
+	item := v117.AircraftDerivedData{ACASResolution: []byte{1, 2, 3}}
+	var buf bytes.Buffer
+	if _, err := item.Encode(&buf); !errors.Is(err, asterix.ErrInvalidLength) {
+		t.Errorf("Encode() error = %v, want %v", err, asterix.ErrInvalidLength)
+	}
+}
+
+func TestAircraftDerivedData_EncodeOutOfRange(t *testing.T) {
+	tests := []struct {
+		name string
+		item v117.AircraftDerivedData
+	}{
+		{"ServiceStatus", v117.AircraftDerivedData{ServiceStatus: &v117.SvcStatus{CommCapability: 8}}},
+		{"ACASStatus", v117.AircraftDerivedData{ACASStatus: &v117.ADSBStatus{FlightStatus: 8}}},
+		{"BarometricVertRate", v117.AircraftDerivedData{BarometricVertRate: ptr(32768 * 6.25)}},
+		{"GeometricVertRate", v117.AircraftDerivedData{GeometricVertRate: ptr(-32769 * 6.25)}},
+		{"RollAngle", v117.AircraftDerivedData{RollAngle: ptr(327.68)}},
+		{"TrackAngleRate", v117.AircraftDerivedData{TrackAngleRate: ptr(32.0)}},
+		{"TurnIndicator", v117.AircraftDerivedData{TrackAngleRate: ptr(1.0), TurnIndicator: ptr(uint8(4))}},
+		{"TrackAngle", v117.AircraftDerivedData{TrackAngle: ptr(360.0)}},
+		{"GroundSpeed", v117.AircraftDerivedData{GroundSpeed: ptr(-1.0)}},
+		{"Position", v117.AircraftDerivedData{Position: &v117.WGS84Position{Latitude: 45, Longitude: 180}}},
+		{"GeoAltitude", v117.AircraftDerivedData{GeoAltitude: ptr(math.NaN())}},
+		{"PositionUncertainty", v117.AircraftDerivedData{PositionUncertainty: ptr(uint8(16))}},
+		{"IAS", v117.AircraftDerivedData{IAS: ptr(65536.0)}},
+		{"Mach", v117.AircraftDerivedData{Mach: ptr(-0.1)}},
+		{"BarometricPressure", v117.AircraftDerivedData{BarometricPressure: ptr(800 + 4096*0.1)}},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if _, err := tt.item.Encode(&buf); !errors.Is(err, asterix.ErrInvalidField) {
+			t.Errorf("%s: Encode() error = %v, want %v", tt.name, err, asterix.ErrInvalidField)
+		}
+	}
+
+	// A track angle rounding up to 360° wraps to 0
+	item := v117.AircraftDerivedData{TrackAngle: ptr(359.999)}
+	var buf bytes.Buffer
+	if _, err := item.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if want := []byte{0x01, 0x01, 0x20, 0x00, 0x00}; !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Encode() = % X, want % X", buf.Bytes(), want)
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	items := map[string]interface {
+		Encode(*bytes.Buffer) (int, error)
+	}{
+		"I062/380": newAircraftDerivedData(),
+		"I062/390": newFlightPlanRelatedData(),
+	}
+
+	for name, item := range items {
+		b.Run(name, func(b *testing.B) {
+			var buf bytes.Buffer
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				if _, err := item.Encode(&buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
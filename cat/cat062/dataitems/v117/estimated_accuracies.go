@@ -4,7 +4,10 @@ package v117
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
 )
 
 // EstimatedAccuracies implements I062/500
@@ -118,18 +121,8 @@ func (e *EstimatedAccuracies) Decode(buf *bytes.Buffer) (int, error) {
 		}
 		bytesRead += n
 
-		// Extract covariance as two's complement
-		covBits := uint16(data[0])<<8 | uint16(data[1])
-		var covValue int16
-		if (covBits & 0x8000) != 0 {
-			// Negative value (two's complement)
-			covValue = -int16(^covBits + 1)
-		} else {
-			// Positive value
-			covValue = int16(covBits)
-		}
-
-		cov := float64(covValue) * 0.5 // LSB = 0.5m
+		// Extract covariance as 16-bit two's complement
+		cov := covarianceMetres(uint16(data[0])<<8 | uint16(data[1]))
 		e.Covariance = &cov
 	}
 
@@ -347,14 +340,10 @@ func (e *EstimatedAccuracies) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Subfield #2: XY Covariance
 	if hasCovariance {
-		// Convert to binary (0.5m resolution)
-		var covBits uint16
-		covValue := int16(*e.Covariance / 0.5)
-		if covValue < 0 {
-			// Two's complement for negative values
-			covBits = uint16(^(-covValue) + 1)
-		} else {
-			covBits = uint16(covValue)
+		// Convert to 16-bit two's complement (0.5m resolution)
+		covBits, err := covarianceBits(*e.Covariance)
+		if err != nil {
+			return bytesWritten, err
 		}
 
 		data := []byte{
@@ -513,6 +502,11 @@ func (e *EstimatedAccuracies) Validate() error {
 	}
 
 	// Covariance can be positive or negative
+	if e.Covariance != nil {
+		if _, err := covarianceBits(*e.Covariance); err != nil {
+			return err
+		}
+	}
 
 	if e.PositionAccuracyLat != nil && *e.PositionAccuracyLat < 0 {
 		return fmt.Errorf("position accuracy latitude cannot be negative: %.8f", *e.PositionAccuracyLat)
@@ -553,6 +547,29 @@ func (e *EstimatedAccuracies) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (e *EstimatedAccuracies) Reset() {
+	*e = EstimatedAccuracies{}
+}
+
+// covarianceBits converts an XY covariance component in metres to its
+// 16-bit two's complement representation with an LSB of 0.5 m. It is the
+// inverse of covarianceMetres.
+func covarianceBits(cov float64) (uint16, error) {
+	counts := math.Round(cov / 0.5)
+	if counts < math.MinInt16 || counts > math.MaxInt16 {
+		return 0, fmt.Errorf("covariance out of range [%.1f,%.1f]: %.2f",
+			math.MinInt16*0.5, math.MaxInt16*0.5, cov)
+	}
+	return uint16(int16(counts)), nil
+}
+
+// covarianceMetres converts the 16-bit two's complement representation of
+// an XY covariance component to metres
+func covarianceMetres(bits uint16) float64 {
+	return float64(asterix.SignExtend(uint32(bits), 16)) * 0.5
+}
+
 // max returns the maximum of two float64 values
 func max(a, b float64) float64 {
 	if a > b {
@@ -0,0 +1,45 @@
+// dataitems/cat062/estimated_accuracies_test.go
+package v117_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+)
+
+func TestEstimatedAccuracies_CovarianceFullRange(t *testing.T) {
+	for v := math.MinInt16; v <= math.MaxInt16; v++ {
+		data := []byte{0x40, byte(uint16(v) >> 8), byte(uint16(v))}
+
+		var e v117.EstimatedAccuracies
+		if _, err := e.Decode(bytes.NewBuffer(data)); err != nil {
+			t.Fatalf("Decode(% X) error = %v", data, err)
+		}
+		if *e.Covariance != float64(v)*0.5 {
+			t.Fatalf("Decode(% X) covariance = %v, want %v", data, *e.Covariance, float64(v)*0.5)
+		}
+
+		var buf bytes.Buffer
+		if _, err := e.Encode(&buf); err != nil {
+			t.Fatalf("Encode(%v) error = %v", *e.Covariance, err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("Encode(%v) = % X, want % X", *e.Covariance, buf.Bytes(), data)
+		}
+	}
+}
+
+// Values beyond the 16-bit range used to wrap around silently
+func TestEstimatedAccuracies_CovarianceOutOfRange(t *testing.T) {
+	for _, cov := range []float64{16384, 16383.75, -16384.5, 1e6} {
+		e := v117.EstimatedAccuracies{Covariance: &cov}
+		if _, err := e.Encode(new(bytes.Buffer)); err == nil {
+			t.Errorf("Encode(%v) accepted an out-of-range covariance", cov)
+		}
+		if err := e.Validate(); err == nil {
+			t.Errorf("Validate(%v) accepted an out-of-range covariance", cov)
+		}
+	}
+}
@@ -667,15 +667,7 @@ func (f *FlightPlanRelatedData) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Write Subfield #2: Callsign
 	if hasCallsign {
-		// Pad with spaces if needed
-		callsign := *f.Callsign
-		if len(callsign) > 7 {
-			callsign = callsign[:7]
-		} else if len(callsign) < 7 {
-			callsign = callsign + strings.Repeat(" ", 7-len(callsign))
-		}
-
-		n, err := buf.WriteString(callsign)
+		n, err := writePadded(buf, *f.Callsign, 7)
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing callsign: %w", err)
 		}
@@ -722,15 +714,7 @@ func (f *FlightPlanRelatedData) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Write Subfield #5: Type of Aircraft
 	if hasTypeAircraft {
-		// Pad with spaces if needed
-		typeAircraft := *f.TypeOfAircraft
-		if len(typeAircraft) > 4 {
-			typeAircraft = typeAircraft[:4]
-		} else if len(typeAircraft) < 4 {
-			typeAircraft = typeAircraft + strings.Repeat(" ", 4-len(typeAircraft))
-		}
-
-		n, err := buf.WriteString(typeAircraft)
+		n, err := writePadded(buf, *f.TypeOfAircraft, 4)
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing type of aircraft: %w", err)
 		}
@@ -748,15 +732,7 @@ func (f *FlightPlanRelatedData) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Write Subfield #7: Departure Airport
 	if hasDeparture {
-		// Ensure exactly 4 characters
-		depAirport := *f.DepartureAirport
-		if len(depAirport) > 4 {
-			depAirport = depAirport[:4]
-		} else if len(depAirport) < 4 {
-			depAirport = depAirport + strings.Repeat(" ", 4-len(depAirport))
-		}
-
-		n, err := buf.WriteString(depAirport)
+		n, err := writePadded(buf, *f.DepartureAirport, 4)
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing departure airport: %w", err)
 		}
@@ -765,15 +741,7 @@ func (f *FlightPlanRelatedData) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Write Subfield #8: Destination Airport
 	if hasDestination {
-		// Ensure exactly 4 characters
-		destAirport := *f.DestinationAirport
-		if len(destAirport) > 4 {
-			destAirport = destAirport[:4]
-		} else if len(destAirport) < 4 {
-			destAirport = destAirport + strings.Repeat(" ", 4-len(destAirport))
-		}
-
-		n, err := buf.WriteString(destAirport)
+		n, err := writePadded(buf, *f.DestinationAirport, 4)
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing destination airport: %w", err)
 		}
@@ -859,15 +827,7 @@ func (f *FlightPlanRelatedData) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Write Subfield #13: Aircraft Stand
 	if hasStand {
-		// Pad with spaces if needed
-		stand := *f.AircraftStand
-		if len(stand) > 6 {
-			stand = stand[:6]
-		} else if len(stand) < 6 {
-			stand = stand + strings.Repeat(" ", 6-len(stand))
-		}
-
-		n, err := buf.WriteString(stand)
+		n, err := writePadded(buf, *f.AircraftStand, 6)
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing aircraft stand: %w", err)
 		}
@@ -891,15 +851,7 @@ func (f *FlightPlanRelatedData) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Write Subfield #15: Standard Instrument Departure
 	if hasSID {
-		// Pad with spaces if needed
-		sid := *f.SID
-		if len(sid) > 7 {
-			sid = sid[:7]
-		} else if len(sid) < 7 {
-			sid = sid + strings.Repeat(" ", 7-len(sid))
-		}
-
-		n, err := buf.WriteString(sid)
+		n, err := writePadded(buf, *f.SID, 7)
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing SID: %w", err)
 		}
@@ -908,15 +860,7 @@ func (f *FlightPlanRelatedData) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Write Subfield #16: Standard Instrument Arrival
 	if hasSTAR {
-		// Pad with spaces if needed
-		star := *f.STAR
-		if len(star) > 7 {
-			star = star[:7]
-		} else if len(star) < 7 {
-			star = star + strings.Repeat(" ", 7-len(star))
-		}
-
-		n, err := buf.WriteString(star)
+		n, err := writePadded(buf, *f.STAR, 7)
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing STAR: %w", err)
 		}
@@ -945,15 +889,7 @@ func (f *FlightPlanRelatedData) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Write Subfield #18: Pre-Emergency Callsign
 	if hasPreCallsign {
-		// Pad with spaces if needed
-		callsign := *f.PreEmergencyCallsign
-		if len(callsign) > 7 {
-			callsign = callsign[:7]
-		} else if len(callsign) < 7 {
-			callsign = callsign + strings.Repeat(" ", 7-len(callsign))
-		}
-
-		n, err := buf.WriteString(callsign)
+		n, err := writePadded(buf, *f.PreEmergencyCallsign, 7)
 		if err != nil {
 			return bytesWritten, fmt.Errorf("writing pre-emergency callsign: %w", err)
 		}
@@ -1176,3 +1112,21 @@ func GetFormattedTimeType(timeType uint8) string {
 	}
 	return fmt.Sprintf("Unknown Time Type (%d)", timeType)
 }
+
+// writePadded writes s truncated or space-padded to exactly width bytes,
+// without building an intermediate padded string
+func writePadded(buf *bytes.Buffer, s string, width int) (int, error) {
+	if len(s) > width {
+		s = s[:width]
+	}
+	n, err := buf.WriteString(s)
+	if err != nil {
+		return n, err
+	}
+	for ; n < width; n++ {
+		if err := buf.WriteByte(' '); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
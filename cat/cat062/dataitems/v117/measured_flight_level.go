@@ -57,6 +57,11 @@ func (m *MeasuredFlightLevel) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (m *MeasuredFlightLevel) Reset() {
+	*m = MeasuredFlightLevel{}
+}
+
 func (m *MeasuredFlightLevel) String() string {
 	// Convert flight levels to feet (1 FL = 100 ft)
 	altitudeInFeet := m.FlightLevel * 100
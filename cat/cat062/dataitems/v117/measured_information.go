@@ -4,7 +4,10 @@ package v117
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
 )
 
 // MeasuredInformation implements I062/340
@@ -18,7 +21,9 @@ type MeasuredInformation struct {
 	MeasuredRange   *float64 // In nautical miles
 	MeasuredAzimuth *float64 // In degrees (0-360)
 
-	// Subfield #3: Measured 3-D Height
+	// Subfield #3: Measured 3-D Height. Unlike the Mode C subfield, the
+	// height carries no validated/garbled bits in this edition: all 16 bits
+	// are a two's complement value.
 	Measured3DHeight *float64 // In feet
 
 	// Subfield #4: Last Measured Mode C code
@@ -119,9 +124,8 @@ func (m *MeasuredInformation) Decode(buf *bytes.Buffer) (int, error) {
 		}
 		bytesRead += n
 
-		// Extract height (16 bits)
-		heightBits := uint16(data[0])<<8 | uint16(data[1])
-		// LSB = 25 feet
+		// Extract height (16 bits, two's complement), LSB = 25 feet
+		heightBits := int16(uint16(data[0])<<8 | uint16(data[1]))
 		height := float64(heightBits) * 25.0
 		m.Measured3DHeight = &height
 	}
@@ -219,6 +223,14 @@ func (m *MeasuredInformation) Encode(buf *bytes.Buffer) (int, error) {
 	hasMode3A := m.LastMode3A != nil
 	hasReportType := m.ReportType != nil
 
+	var heightBits uint16
+	if hasHeight {
+		var err error
+		if heightBits, err = measuredHeightBits(*m.Measured3DHeight); err != nil {
+			return 0, err
+		}
+	}
+
 	// Build FSPEC
 	fspec := byte(0)
 	if hasSensor {
@@ -285,9 +297,6 @@ func (m *MeasuredInformation) Encode(buf *bytes.Buffer) (int, error) {
 
 	// Write Subfield #3: Measured 3-D Height
 	if hasHeight {
-		// Convert height to binary (25 feet resolution)
-		heightBits := uint16(*m.Measured3DHeight / 25.0)
-
 		data := []byte{
 			byte(heightBits >> 8),
 			byte(heightBits),
@@ -392,6 +401,19 @@ func (m *MeasuredInformation) Encode(buf *bytes.Buffer) (int, error) {
 }
 
 // String returns a human-readable representation of the measured information
+// IsSSRDerived reports whether the last report used to update the track
+// came from a secondary (SSR or Mode S) detection, with or without a
+// primary one. It implements asterix.DetectionTypeProvider.
+func (m *MeasuredInformation) IsSSRDerived() bool {
+	return m.ReportType != nil && *m.ReportType >= 2 && *m.ReportType <= 7
+}
+
+// IsPSROnly reports whether the last report used to update the track was a
+// single primary detection. It implements asterix.DetectionTypeProvider.
+func (m *MeasuredInformation) IsPSROnly() bool {
+	return m.ReportType != nil && *m.ReportType == 1
+}
+
 func (m *MeasuredInformation) String() string {
 	parts := []string{}
 
@@ -490,6 +512,13 @@ func (m *MeasuredInformation) Validate() error {
 		return fmt.Errorf("measured azimuth out of range [0,360): %.2f°", *m.MeasuredAzimuth)
 	}
 
+	// Check measured 3-D height
+	if m.Measured3DHeight != nil {
+		if _, err := measuredHeightBits(*m.Measured3DHeight); err != nil {
+			return err
+		}
+	}
+
 	// Check Mode C code
 	if m.LastModeC != nil && (*m.LastModeC < -12 || *m.LastModeC > 1270) {
 		return fmt.Errorf("Mode C flight level out of range [-12,1270]: %.2f", *m.LastModeC)
@@ -508,6 +537,17 @@ func (m *MeasuredInformation) Validate() error {
 	return nil
 }
 
+// measuredHeightBits converts a measured 3-D height in feet to its 16-bit
+// two's complement representation with an LSB of 25 ft
+func measuredHeightBits(height float64) (uint16, error) {
+	counts := math.Round(height / 25.0)
+	if !(counts >= math.MinInt16 && counts <= math.MaxInt16) {
+		return 0, fmt.Errorf("%w: measured 3-D height out of range [%d,%d]: %.2f ft",
+			asterix.ErrInvalidField, math.MinInt16*25, math.MaxInt16*25, height)
+	}
+	return uint16(int16(counts)), nil
+}
+
 // formatMode3A formats a Mode 3/A code as an octal string (4 digits)
 func formatMode3A(code uint16) string {
 	// Extract each octal digit
@@ -0,0 +1,139 @@
+// dataitems/cat062/measured_information_test.go
+package v117_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+	v120 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v120"
+)
+
+func TestMeasuredInformation_Height(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		height float64
+	}{
+		{"positive", []byte{0x20, 0x01, 0x90}, 10000},
+		{"top bits are height", []byte{0x20, 0x7F, 0xFF}, 32767 * 25},
+		{"negative", []byte{0x20, 0xFF, 0xFE}, -50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m v117.MeasuredInformation
+			n, err := m.Decode(bytes.NewBuffer(tt.data))
+			if err != nil || n != len(tt.data) {
+				t.Fatalf("Decode() = %d, %v", n, err)
+			}
+			if m.Measured3DHeight == nil || *m.Measured3DHeight != tt.height {
+				t.Fatalf("Measured3DHeight = %v, want %v", m.Measured3DHeight, tt.height)
+			}
+
+			var buf bytes.Buffer
+			if _, err := m.Encode(&buf); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.data) {
+				t.Errorf("Encode() = % X, want % X", buf.Bytes(), tt.data)
+			}
+		})
+	}
+}
+
+func TestMeasuredInformation_HeightEncodeEdges(t *testing.T) {
+	tests := []struct {
+		name   string
+		height float64
+		want   []byte
+	}{
+		{"rounds up", 37.5, []byte{0x20, 0x00, 0x02}},
+		{"rounds down", 36, []byte{0x20, 0x00, 0x01}},
+		{"negative rounds", -37.5, []byte{0x20, 0xFF, 0xFE}},
+		{"maximum", 32767 * 25, []byte{0x20, 0x7F, 0xFF}},
+		{"minimum", -32768 * 25, []byte{0x20, 0x80, 0x00}},
+	}
+	for _, tt := range tests {
+		m := v117.MeasuredInformation{Measured3DHeight: &tt.height}
+		var buf bytes.Buffer
+		if _, err := m.Encode(&buf); err != nil {
+			t.Fatalf("%s: Encode(%v) error = %v", tt.name, tt.height, err)
+		}
+		if !bytes.Equal(buf.Bytes(), tt.want) {
+			t.Errorf("%s: Encode(%v) = % X, want % X", tt.name, tt.height, buf.Bytes(), tt.want)
+		}
+	}
+
+	for _, height := range []float64{32768 * 25, -32769 * 25, math.NaN()} {
+		m := v117.MeasuredInformation{Measured3DHeight: &height}
+		var buf bytes.Buffer
+		if _, err := m.Encode(&buf); !errors.Is(err, asterix.ErrInvalidField) {
+			t.Errorf("Encode(%v) error = %v, want %v", height, err, asterix.ErrInvalidField)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("Encode(%v) wrote % X before failing", height, buf.Bytes())
+		}
+		if err := m.Validate(); !errors.Is(err, asterix.ErrInvalidField) {
+			t.Errorf("Validate(%v) error = %v, want %v", height, err, asterix.ErrInvalidField)
+		}
+	}
+}
+
+// The validated/garbled flags belong to the Mode C subfield, which follows
+// the height; they must not leak into the height value
+func TestMeasuredInformation_HeightAndModeCFlags(t *testing.T) {
+	data := []byte{0x30, 0x01, 0x90, 0xC1, 0x90}
+
+	var m v117.MeasuredInformation
+	if _, err := m.Decode(bytes.NewBuffer(data)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if *m.Measured3DHeight != 10000 {
+		t.Errorf("Measured3DHeight = %v, want 10000", *m.Measured3DHeight)
+	}
+	if m.LastModeCValidated || !m.LastModeCGarbled || *m.LastModeC != 100 {
+		t.Errorf("Mode C = %v (validated %v, garbled %v), want 100 (false, true)",
+			*m.LastModeC, m.LastModeCValidated, m.LastModeCGarbled)
+	}
+
+	// Edition 1.20 keeps the item as raw bytes
+	var raw v120.MeasuredInformation
+	if _, err := raw.Decode(bytes.NewBuffer(data)); err != nil {
+		t.Fatalf("v120 Decode() error = %v", err)
+	}
+	if !bytes.Equal(raw.Data, data) {
+		t.Errorf("v120 Data = % X, want % X", raw.Data, data)
+	}
+}
+
+func TestMeasuredInformation_DetectionType(t *testing.T) {
+	tests := []struct {
+		reportType uint8
+		ssr, psr   bool
+	}{
+		{0, false, false}, // No detection
+		{1, false, true},  // Single PSR
+		{2, true, false},  // Single SSR
+		{3, true, false},  // SSR + PSR
+		{4, true, false},  // Mode S All-Call
+		{5, true, false},  // Mode S Roll-Call
+		{6, true, false},  // Mode S All-Call + PSR
+		{7, true, false},  // Mode S Roll-Call + PSR
+	}
+	for _, tt := range tests {
+		m := v117.MeasuredInformation{ReportType: &tt.reportType}
+		if m.IsSSRDerived() != tt.ssr || m.IsPSROnly() != tt.psr {
+			t.Errorf("report type %d: IsSSRDerived() = %v, IsPSROnly() = %v, want %v, %v",
+				tt.reportType, m.IsSSRDerived(), m.IsPSROnly(), tt.ssr, tt.psr)
+		}
+	}
+
+	var m v117.MeasuredInformation
+	if m.IsSSRDerived() || m.IsPSROnly() {
+		t.Error("detection type reported without a report type subfield")
+	}
+}
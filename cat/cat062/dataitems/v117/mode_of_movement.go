@@ -94,6 +94,11 @@ func (m *ModeOfMovement) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (m *ModeOfMovement) Reset() {
+	*m = ModeOfMovement{}
+}
+
 func (m *ModeOfMovement) String() string {
 	trans := "Undetermined"
 	switch m.Trans {
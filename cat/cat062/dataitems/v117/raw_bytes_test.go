@@ -0,0 +1,56 @@
+// dataitems/cat062/raw_bytes_test.go
+package v117_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+)
+
+func TestRawBytes(t *testing.T) {
+	addr, ident, heading, age, rate := uint32(0x3C6586), "DLH4AB", 90.0, 1.5, -640.0
+	items := []struct {
+		name  string
+		typed asterix.DataItem
+		fresh func() asterix.DataItem
+	}{
+		{"I062/060", &v117.TrackMode3ACode{Code: 07000},
+			func() asterix.DataItem { return &v117.TrackMode3ACode{} }},
+		{"I062/290", &v117.SystemTrackUpdateAges{TrackAge: &age, SSRAge: &age, MLTAge: &age},
+			func() asterix.DataItem { return &v117.SystemTrackUpdateAges{} }},
+		{"I062/295", &v117.TrackDataAges{MFLAge: &age, MHGAge: &age, IASAge: &age},
+			func() asterix.DataItem { return &v117.TrackDataAges{} }},
+		{"I062/380", &v117.AircraftDerivedData{
+			TargetAddress: &addr, TargetIdentification: &ident, MagneticHeading: &heading, BarometricVertRate: &rate,
+			ModeSMBData: []v117.ModeSMB{{BDS1: 4, Data: []byte{1, 2, 3, 4, 5, 6, 7}}},
+		}, func() asterix.DataItem { return &v117.AircraftDerivedData{} }},
+	}
+
+	for _, it := range items {
+		if _, ok := it.typed.(asterix.RawBytesProvider).RawBytes(); ok {
+			t.Errorf("%s: RawBytes() of an item built from typed fields reported bytes", it.name)
+		}
+		var wire bytes.Buffer
+		if _, err := it.typed.Encode(&wire); err != nil {
+			t.Fatalf("%s: Encode() error = %v", it.name, err)
+		}
+		input := wire.Bytes()
+
+		// A trailing octet of the next item must not end up in the raw bytes
+		item := it.fresh()
+		if _, err := item.Decode(bytes.NewBuffer(append(bytes.Clone(input), 0xFF))); err != nil {
+			t.Fatalf("%s: Decode() error = %v", it.name, err)
+		}
+		raw, ok := item.(asterix.RawBytesProvider).RawBytes()
+		if !ok || !bytes.Equal(raw, input) {
+			t.Errorf("%s: RawBytes() = % X, %v, want % X", it.name, raw, ok, input)
+		}
+
+		item.(asterix.RawRetainer).DiscardRaw()
+		if _, ok := item.(asterix.RawBytesProvider).RawBytes(); ok {
+			t.Errorf("%s: RawBytes() reported bytes after DiscardRaw()", it.name)
+		}
+	}
+}
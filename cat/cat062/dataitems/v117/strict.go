@@ -0,0 +1,21 @@
+// dataitems/cat062/strict.go
+package v117
+
+import "sync/atomic"
+
+// strictValidation is the package-wide validation mode, see SetStrictValidation
+var strictValidation atomic.Bool
+
+// SetStrictValidation switches the items of this package between lenient
+// validation, the default, which checks only the fields Validate has always
+// checked, and strict validation, which checks every populated field
+// against the range of its encoding in edition 1.17. The mode applies to
+// all goroutines.
+func SetStrictValidation(strict bool) {
+	strictValidation.Store(strict)
+}
+
+// StrictValidation reports whether strict validation is enabled
+func StrictValidation() bool {
+	return strictValidation.Load()
+}
@@ -0,0 +1,54 @@
+// dataitems/cat062/strict_test.go
+package v117_test
+
+import (
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+)
+
+func TestStrictValidation(t *testing.T) {
+	t.Cleanup(func() { v117.SetStrictValidation(false) })
+
+	age, rate := 64.0, 40.0
+	items := []struct {
+		name string
+		item asterix.DataItem
+	}{
+		{"I062/295 BPS age", &v117.TrackDataAges{BPSAge: &age}},
+		{"I062/295 MB age", &v117.TrackDataAges{MBAge: &age}},
+		{"I062/380 track angle rate", &v117.AircraftDerivedData{TrackAngleRate: &rate}},
+		{"I062/380 Mode S MB data", &v117.AircraftDerivedData{ModeSMBData: []v117.ModeSMB{{BDS1: 4, Data: []byte{1}}}}},
+		{"I062/380 trajectory intent", &v117.AircraftDerivedData{TrajectoryIntent: &v117.TrajIntent{
+			Points: []v117.TrajIntentPoint{{TCPNumber: 64, TCPTurnRadius: 0.5}},
+		}}},
+	}
+
+	for _, it := range items {
+		if err := it.item.Validate(); err != nil {
+			t.Errorf("%s: lenient Validate() error = %v", it.name, err)
+		}
+	}
+	v117.SetStrictValidation(true)
+	if !v117.StrictValidation() {
+		t.Fatal("StrictValidation() = false after SetStrictValidation(true)")
+	}
+	for _, it := range items {
+		if err := it.item.Validate(); err == nil {
+			t.Errorf("%s: strict Validate() accepted an out of range value", it.name)
+		}
+	}
+
+	// In range values still pass
+	age, rate = 63.75, -32
+	valid := []asterix.DataItem{
+		&v117.TrackDataAges{MFLAge: &age, BPSAge: &age},
+		&v117.AircraftDerivedData{TrackAngleRate: &rate, ModeSMBData: []v117.ModeSMB{{BDS1: 4, Data: make([]byte, 7)}}},
+	}
+	for _, item := range valid {
+		if err := item.Validate(); err != nil {
+			t.Errorf("strict Validate() of %T error = %v", item, err)
+		}
+	}
+}
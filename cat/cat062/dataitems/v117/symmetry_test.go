@@ -0,0 +1,57 @@
+// dataitems/cat062/symmetry_test.go
+package v117_test
+
+import (
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/asterix/asterixtest"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+)
+
+func TestMeasuredInformation_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v117.MeasuredInformation{} }, [][]byte{
+		{0x80, 0x19, 0x64},
+		{0x40, 0x1E, 0x00, 0x40, 0x00},
+		{0x20, 0xFF, 0xFE},
+		{0x10, 0xC1, 0x90},
+		{0xFC, 0x19, 0x64, 0x1E, 0x00, 0x40, 0x00, 0x01, 0x90, 0x01, 0x90, 0x0A, 0x9C, 0xA8},
+	})
+}
+
+func TestEstimatedAccuracies_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v117.EstimatedAccuracies{} }, [][]byte{
+		{0x80, 0x00, 0x10, 0x00, 0x20},
+		{0x40, 0xFF, 0xF6},
+		{0xFE, 0x00, 0x10, 0x00, 0x20, 0x00, 0x14, 0x00, 0x08, 0x00, 0x08, 0x10, 0x04, 0x08, 0x04, 0x02, 0x01},
+		{0x01, 0x80, 0x0A},
+	})
+}
+
+func TestAircraftDerivedData_Symmetry(t *testing.T) {
+	asterixtest.RunItemSuite(t, func() asterix.DataItem { return &v117.AircraftDerivedData{} }, [][]byte{
+		{0xC0, 0x3C, 0x65, 0x86, 0x10, 0xC2, 0x34, 0x04, 0x28, 0x20},
+		{0x01, 0x80, 0x40},
+		{0x01, 0x80, 0x81, 0x00},
+		{0x01, 0x80, 0x01, 0x03, 0xA0},
+		{0x01, 0x20, 0x24, 0xF5},
+		{0x01, 0x10, 0xF0, 0x03},
+		{0x01, 0x08, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07},
+		{0x01, 0x04, 0x01, 0x00},
+		{0x01, 0x02, 0xFF, 0x38},
+		{0x01, 0x01, 0x80, 0xFF, 0x9C},
+		{0x01, 0x01, 0x40, 0x01, 0x00},
+		{0x01, 0x01, 0x40, 0x41, 0x40},
+		{0x01, 0x01, 0x40, 0xBE, 0x00},
+		{0x01, 0x01, 0x20, 0x40, 0x00},
+		{0x01, 0x01, 0x10, 0x01, 0x00},
+		{0x01, 0x01, 0x08, 0x05},
+		{0x01, 0x01, 0x02, 0x05},
+		{0x01, 0x01, 0x01, 0x80, 0x23, 0xA0, 0x6D, 0xFA, 0x1D, 0x95},
+		{0x01, 0x01, 0x01, 0x40, 0x06, 0x40},
+		{0x01, 0x01, 0x01, 0x20, 0x07},
+		{0x01, 0x01, 0x01, 0x08, 0x01, 0x2C},
+		{0x01, 0x01, 0x01, 0x04, 0x00, 0x61},
+		{0x01, 0x01, 0x01, 0x02, 0x0A, 0xB3},
+	})
+}
@@ -511,3 +511,19 @@ func (s *SystemTrackUpdateAges) Validate() error {
 
 	return nil
 }
+
+// Reset implements asterix.Resetter
+func (s *SystemTrackUpdateAges) Reset() {
+	*s = SystemTrackUpdateAges{}
+}
+
+// DiscardRaw implements asterix.RawRetainer
+func (s *SystemTrackUpdateAges) DiscardRaw() {
+	s.rawData = nil
+}
+
+// RawBytes returns the bytes retained from the last Decode, which Encode
+// replays; ok is false once they have been discarded
+func (s *SystemTrackUpdateAges) RawBytes() ([]byte, bool) {
+	return s.rawData, len(s.rawData) > 0
+}
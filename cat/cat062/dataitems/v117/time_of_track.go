@@ -67,6 +67,11 @@ func (t *TimeOfTrackInformation) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TimeOfTrackInformation) Reset() {
+	*t = TimeOfTrackInformation{}
+}
+
 func (t *TimeOfTrackInformation) String() string {
 	// We'll convert to a human-readable time format
 	// Note: This doesn't account for time exceeding 24 hours
@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
 )
 
 // TrackDataAges implements I062/295
@@ -62,24 +64,11 @@ func (t *TrackDataAges) Decode(buf *bytes.Buffer) (int, error) {
 	t.rawData = nil
 
 	// Read FSPEC bytes (up to 5 octets)
-	fspec := make([]byte, 0, 5)
-	hasExtension := true
-
-	for hasExtension && len(fspec) < 5 {
-		if buf.Len() < 1 {
-			return bytesRead, fmt.Errorf("buffer too short for FSPEC byte %d", len(fspec)+1)
-		}
-
-		fspecByte, err := buf.ReadByte()
-		if err != nil {
-			return bytesRead, fmt.Errorf("reading FSPEC byte %d: %w", len(fspec)+1, err)
-		}
-		bytesRead++
-		fspec = append(fspec, fspecByte)
-		t.rawData = append(t.rawData, fspecByte)
-
-		// Check if we need to continue reading FSPEC (FX bit)
-		hasExtension = (fspecByte & 0x01) != 0
+	fspec, err := asterix.ReadFXChain(buf, 5)
+	bytesRead += len(fspec)
+	t.rawData = append(t.rawData, fspec...)
+	if err != nil {
+		return bytesRead, fmt.Errorf("reading FSPEC: %w", err)
 	}
 
 	// Process first FSPEC byte
@@ -1210,39 +1199,46 @@ func (t *TrackDataAges) String() string {
 	return fmt.Sprintf("TrackDataAges[%s]", strings.Join(parts, ", "))
 }
 
-// Validate performs validation on the Track Data Ages
+// Validate performs validation on the Track Data Ages. All ages are 8-bit
+// values with LSB = 1/4 second, so at most 63.75 seconds. Outside strict
+// validation only the ages of the first FSPEC octet are checked.
 func (t *TrackDataAges) Validate() error {
-	// Check that all ages are within valid ranges
-	// All ages are 8-bit values with LSB = 1/4 second
-	// Maximum value is 63.75 seconds (255 * 0.25)
-
-	// Check first FSPEC byte fields
-	if t.MFLAge != nil && (*t.MFLAge < 0 || *t.MFLAge > 63.75) {
-		return fmt.Errorf("MFL age out of range [0,63.75]: %.2f", *t.MFLAge)
-	}
-	if t.MD1Age != nil && (*t.MD1Age < 0 || *t.MD1Age > 63.75) {
-		return fmt.Errorf("MD1 age out of range [0,63.75]: %.2f", *t.MD1Age)
-	}
-	if t.MD2Age != nil && (*t.MD2Age < 0 || *t.MD2Age > 63.75) {
-		return fmt.Errorf("MD2 age out of range [0,63.75]: %.2f", *t.MD2Age)
-	}
-	if t.MDAAge != nil && (*t.MDAAge < 0 || *t.MDAAge > 63.75) {
-		return fmt.Errorf("MDA age out of range [0,63.75]: %.2f", *t.MDAAge)
-	}
-	if t.MD4Age != nil && (*t.MD4Age < 0 || *t.MD4Age > 63.75) {
-		return fmt.Errorf("MD4 age out of range [0,63.75]: %.2f", *t.MD4Age)
+	ages := t.ages()
+	if !StrictValidation() {
+		ages = ages[:7]
 	}
-	if t.MD5Age != nil && (*t.MD5Age < 0 || *t.MD5Age > 63.75) {
-		return fmt.Errorf("MD5 age out of range [0,63.75]: %.2f", *t.MD5Age)
-	}
-	if t.MHGAge != nil && (*t.MHGAge < 0 || *t.MHGAge > 63.75) {
-		return fmt.Errorf("MHG age out of range [0,63.75]: %.2f", *t.MHGAge)
+	for _, age := range ages {
+		if age.value != nil && (*age.value < 0 || *age.value > 63.75) {
+			return fmt.Errorf("%s age out of range [0,63.75]: %.2f", age.name, *age.value)
+		}
 	}
+	return nil
+}
 
-	// Check all other fields (abbreviated for readability)
-	// In a real implementation, all fields would be validated in the same way
+// Reset implements asterix.Resetter
+func (t *TrackDataAges) Reset() {
+	*t = TrackDataAges{}
+}
 
-	return nil
+// ages lists the age fields in FSPEC order
+func (t *TrackDataAges) ages() []struct {
+	name  string
+	value *float64
+} {
+	return []struct {
+		name  string
+		value *float64
+	}{
+		{"MFL", t.MFLAge}, {"MD1", t.MD1Age}, {"MD2", t.MD2Age}, {"MDA", t.MDAAge},
+		{"MD4", t.MD4Age}, {"MD5", t.MD5Age}, {"MHG", t.MHGAge},
+		{"IAS", t.IASAge}, {"TAS", t.TASAge}, {"SAL", t.SALAge}, {"FSS", t.FSSAge},
+		{"TID", t.TIDAge}, {"COM", t.COMAge}, {"SAB", t.SABAge},
+		{"ACS", t.ACSAge}, {"BVR", t.BVRAge}, {"GVR", t.GVRAge}, {"RAN", t.RANAge},
+		{"TAR", t.TARAge}, {"TAN", t.TANAge}, {"GSP", t.GSPAge},
+		{"VUN", t.VUNAge}, {"MET", t.METAge}, {"EMC", t.EMCAge}, {"POS", t.POSAge},
+		{"GAL", t.GALAge}, {"PUN", t.PUNAge}, {"MB", t.MBAge},
+		{"IAR", t.IARAge}, {"MAC", t.MACAge}, {"BPS", t.BPSAge},
+	}
 }
 
 // Helper function for min values
@@ -1252,3 +1248,14 @@ func min(a, b float64) float64 {
 	}
 	return b
 }
+
+// DiscardRaw implements asterix.RawRetainer
+func (t *TrackDataAges) DiscardRaw() {
+	t.rawData = nil
+}
+
+// RawBytes returns the bytes retained from the last Decode, which Encode
+// replays; ok is false once they have been discarded
+func (t *TrackDataAges) RawBytes() ([]byte, bool) {
+	return t.rawData, len(t.rawData) > 0
+}
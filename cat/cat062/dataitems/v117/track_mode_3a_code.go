@@ -137,3 +137,24 @@ func (t *TrackMode3ACode) Validate() error {
 
 	return nil
 }
+
+// Reset implements asterix.Resetter
+func (t *TrackMode3ACode) Reset() {
+	*t = TrackMode3ACode{}
+}
+
+// DiscardRaw implements asterix.RawRetainer
+func (t *TrackMode3ACode) DiscardRaw() {
+	t.rawData = nil
+}
+
+// RawBytes returns the bytes retained from the last Decode, which Encode
+// replays; ok is false once they have been discarded
+func (t *TrackMode3ACode) RawBytes() ([]byte, bool) {
+	return t.rawData, len(t.rawData) == 2
+}
+
+// HasChanged implements asterix.ChangeProvider, reporting the CH bit
+func (t *TrackMode3ACode) HasChanged() bool {
+	return t.Changed
+}
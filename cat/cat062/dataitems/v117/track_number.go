@@ -45,3 +45,13 @@ func (t *TrackNumber) String() string {
 func (t *TrackNumber) Validate() error {
 	return nil
 }
+
+// Reset implements asterix.Resetter
+func (t *TrackNumber) Reset() {
+	*t = TrackNumber{}
+}
+
+// TrackNumber implements asterix.TrackNumberProvider
+func (t *TrackNumber) TrackNumber() uint16 {
+	return t.Value
+}
@@ -377,6 +377,11 @@ func (t *TrackStatus) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (t *TrackStatus) Reset() {
+	*t = TrackStatus{}
+}
+
 func (t *TrackStatus) String() string {
 	var details []string
 
@@ -588,3 +593,13 @@ func (t *TrackStatus) SetHasExtension() {
 
 	t.hasExtensions = 0
 }
+
+// Confirmed implements asterix.TrackStatusProvider
+func (t *TrackStatus) Confirmed() bool {
+	return !t.CNF
+}
+
+// Coasted implements asterix.TrackStatusProvider
+func (t *TrackStatus) Coasted() bool {
+	return t.CST
+}
@@ -51,14 +51,19 @@ func (c *CalculatedRateOfClimbDescent) Encode(buf *bytes.Buffer) (int, error) {
 }
 
 func (c *CalculatedRateOfClimbDescent) Validate() error {
-	// int16 range with LSB of 6.25 gives range of approximately ±32000 feet/minute
-	// but there's no specific range mentioned in the spec, so we use a reasonable limit
-	if c.Rate < -32000 || c.Rate > 32000 {
-		return fmt.Errorf("rate of climb/descent out of range: %f", c.Rate)
+	// The spec gives no range; accept everything the 16-bit two's complement
+	// field can carry so every decoded value encodes again
+	if c.Rate < -204800 || c.Rate > 204793.75 {
+		return fmt.Errorf("rate of climb/descent out of range [-204800,204793.75]: %f", c.Rate)
 	}
 	return nil
 }
 
+// RateFtMin returns the rate in feet per minute, positive when climbing
+func (c *CalculatedRateOfClimbDescent) RateFtMin() float64 {
+	return c.Rate
+}
+
 func (c *CalculatedRateOfClimbDescent) String() string {
 	if c.Rate > 0 {
 		return fmt.Sprintf("Rate of Climb: %.0f ft/min", c.Rate)
@@ -4,6 +4,8 @@ package v120
 import (
 	"bytes"
 	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
 )
 
 // FlightPlanRelatedData implements I062/390
@@ -17,20 +19,11 @@ func (f *FlightPlanRelatedData) Decode(buf *bytes.Buffer) (int, error) {
 	f.Data = nil
 
 	// Primary subfield can be up to 3 octets, each with FX bit
-	for i := 0; i < 3; i++ {
-		octet := make([]byte, 1)
-		n, err := buf.Read(octet)
-		if err != nil {
-			return bytesRead, fmt.Errorf("reading flight plan related data primary subfield octet %d: %w", i+1, err)
-		}
-		bytesRead += n
-		f.Data = append(f.Data, octet[0])
-
-		// Check if further extension exists
-		hasExtension := (octet[0] & 0x01) != 0
-		if !hasExtension {
-			break
-		}
+	primary, err := asterix.ReadFXChain(buf, 3)
+	bytesRead += len(primary)
+	f.Data = append(f.Data, primary...)
+	if err != nil {
+		return bytesRead, fmt.Errorf("reading flight plan related data primary subfield: %w", err)
 	}
 
 	// Now read subfields based on the bits set in the primary subfield
@@ -4,6 +4,8 @@ package v120
 import (
 	"bytes"
 	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
 )
 
 // TrackDataAges implements I062/295
@@ -17,20 +19,11 @@ func (t *TrackDataAges) Decode(buf *bytes.Buffer) (int, error) {
 	t.Data = nil
 
 	// Primary subfield can be up to 5 octets, each with FX bit
-	for i := 0; i < 5; i++ {
-		octet := make([]byte, 1)
-		n, err := buf.Read(octet)
-		if err != nil {
-			return bytesRead, fmt.Errorf("reading track data ages primary subfield octet %d: %w", i+1, err)
-		}
-		bytesRead += n
-		t.Data = append(t.Data, octet[0])
-
-		// Check if further extension exists
-		hasExtension := (octet[0] & 0x01) != 0
-		if !hasExtension {
-			break
-		}
+	primary, err := asterix.ReadFXChain(buf, 5)
+	bytesRead += len(primary)
+	t.Data = append(t.Data, primary...)
+	if err != nil {
+		return bytesRead, fmt.Errorf("reading track data ages primary subfield: %w", err)
 	}
 
 	// Now read each subfield based on bits set in the primary subfield
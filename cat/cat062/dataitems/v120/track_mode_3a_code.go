@@ -127,3 +127,8 @@ func (t *TrackMode3ACode) String() string {
 
 	return fmt.Sprintf("%s%04o", flags, t.Code)
 }
+
+// HasChanged implements asterix.ChangeProvider, reporting the CH bit
+func (t *TrackMode3ACode) HasChanged() bool {
+	return t.Changed
+}
@@ -588,3 +588,13 @@ func (t *TrackStatus) SetHasExtension() {
 
 	t.hasExtensions = 0
 }
+
+// Confirmed implements asterix.TrackStatusProvider
+func (t *TrackStatus) Confirmed() bool {
+	return !t.CNF
+}
+
+// Coasted implements asterix.TrackStatusProvider
+func (t *TrackStatus) Coasted() bool {
+	return t.CST
+}
@@ -389,7 +389,7 @@ var cat062v117Fields = []asterix.DataField{
 		FRN:         34,
 		DataItem:    "RE062",
 		Description: "Reserved Expansion Field",
-		Type:        asterix.Repetitive,
+		Type:        asterix.Explicit,
 		Length:      1,
 		Mandatory:   false,
 	},
@@ -397,7 +397,7 @@ var cat062v117Fields = []asterix.DataField{
 		FRN:         35,
 		DataItem:    "SP062",
 		Description: "Special Purpose Field",
-		Type:        asterix.Repetitive,
+		Type:        asterix.Explicit,
 		Length:      1,
 		Mandatory:   false,
 	},
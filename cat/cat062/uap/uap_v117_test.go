@@ -0,0 +1,154 @@
+// cat/cat062/uap/uap_v117_test.go
+package uap_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat062"
+	v117 "github.com/davidkohl/gobelix/cat/cat062/dataitems/v117"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestUAP117_TrackRecordRoundTrip(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	record, err := asterix.NewRecord(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I062/010", &common.DataSourceIdentifier{SAC: 25, SIC: 4}},
+		{"I062/015", &common.ServiceIdentification{Value: 7}},
+		{"I062/070", &v117.TimeOfTrackInformation{Time: 43200.5}},
+		{"I062/105", &v117.CalculatedPositionWGS84{Latitude: 48.8566, Longitude: 2.3522}},
+		{"I062/185", &v117.CalculatedTrackVelocity{Vx: 120.25, Vy: -45.5}},
+		{"I062/040", &v117.TrackNumber{Value: 1234}},
+		{"I062/080", &v117.TrackStatus{}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(record); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	data, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(data); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if decoded.Length() != 1 {
+		t.Fatalf("Decode() records = %d, want 1", decoded.Length())
+	}
+	got := decoded.Records()[0]
+
+	item, _, ok := got.GetDataItem("I062/010")
+	if !ok {
+		t.Fatalf("I062/010 missing after decode")
+	}
+	if src := item.(*common.DataSourceIdentifier); src.SAC != 25 || src.SIC != 4 {
+		t.Errorf("I062/010 = %d/%d, want 25/4", src.SAC, src.SIC)
+	}
+
+	item, _, ok = got.GetDataItem("I062/015")
+	if !ok {
+		t.Fatalf("I062/015 missing after decode")
+	}
+	if svc := item.(*common.ServiceIdentification); svc.Value != 7 {
+		t.Errorf("I062/015 = %d, want 7", svc.Value)
+	}
+
+	item, _, ok = got.GetDataItem("I062/070")
+	if !ok {
+		t.Fatalf("I062/070 missing after decode")
+	}
+	if tod := item.(*v117.TimeOfTrackInformation); tod.Time != 43200.5 {
+		t.Errorf("I062/070 = %v, want 43200.5", tod.Time)
+	}
+
+	item, _, ok = got.GetDataItem("I062/105")
+	if !ok {
+		t.Fatalf("I062/105 missing after decode")
+	}
+	pos := item.(*v117.CalculatedPositionWGS84)
+	if math.Abs(pos.Latitude-48.8566) > 1e-5 || math.Abs(pos.Longitude-2.3522) > 1e-5 {
+		t.Errorf("I062/105 = %v,%v, want 48.8566,2.3522", pos.Latitude, pos.Longitude)
+	}
+
+	item, _, ok = got.GetDataItem("I062/185")
+	if !ok {
+		t.Fatalf("I062/185 missing after decode")
+	}
+	if vel := item.(*v117.CalculatedTrackVelocity); vel.Vx != 120.25 || vel.Vy != -45.5 {
+		t.Errorf("I062/185 = %v,%v, want 120.25,-45.5", vel.Vx, vel.Vy)
+	}
+
+	item, _, ok = got.GetDataItem("I062/040")
+	if !ok {
+		t.Fatalf("I062/040 missing after decode")
+	}
+	if tn := item.(*v117.TrackNumber); tn.Value != 1234 {
+		t.Errorf("I062/040 = %d, want 1234", tn.Value)
+	}
+}
+
+func TestUAP117_EncodedLengthWithTrajectoryIntent(t *testing.T) {
+	uap, err := cat062.NewUAP(cat062.Version117)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+	record, err := asterix.NewRecord(asterix.Cat062, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I062/010", &common.DataSourceIdentifier{SAC: 25, SIC: 4}},
+		{"I062/070", &v117.TimeOfTrackInformation{Time: 43200.5}},
+		{"I062/040", &v117.TrackNumber{Value: 1234}},
+		{"I062/080", &v117.TrackStatus{}},
+		{"I062/380", &v117.AircraftDerivedData{TrajectoryIntent: &v117.TrajIntent{
+			StatusPresent: true,
+			Status:        &v117.TrajIntentStatus{NavigationAvailable: true},
+			Points:        []v117.TrajIntentPoint{{TCPAvailable: true, TCPNumber: 1, Altitude: 35000}},
+		}}},
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	n, err := record.Encode(&buf)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if n != buf.Len() {
+		t.Errorf("Encode() reported %d bytes, wrote %d", n, buf.Len())
+	}
+}
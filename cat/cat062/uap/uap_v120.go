@@ -382,7 +382,7 @@ var cat062Fields = []asterix.DataField{
 		FRN:         34,
 		DataItem:    "RE062",
 		Description: "Reserved Expansion Field",
-		Type:        asterix.Repetitive,
+		Type:        asterix.Explicit,
 		Length:      1,
 		Mandatory:   false,
 	},
@@ -390,7 +390,7 @@ var cat062Fields = []asterix.DataField{
 		FRN:         35,
 		DataItem:    "SP062",
 		Description: "Special Purpose Field",
-		Type:        asterix.Repetitive,
+		Type:        asterix.Explicit,
 		Length:      1,
 		Mandatory:   false,
 	},
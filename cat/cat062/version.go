@@ -22,7 +22,7 @@ func NewUAP(version string) (asterix.UAP, error) {
 	case Version120:
 		return uap.NewUAP120()
 	default:
-		return nil, fmt.Errorf("unsupported CAT062 version: %s", version)
+		return nil, fmt.Errorf("%w: CAT062 %s", asterix.ErrUnsupportedVersion, version)
 	}
 }
 
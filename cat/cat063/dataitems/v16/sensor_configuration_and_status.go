@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+
+	"github.com/davidkohl/gobelix/asterix"
 )
 
 // SensorConnectionStatus defines the connection status values
@@ -36,6 +38,13 @@ type SensorConfigurationAndStatus struct {
 	MSC            bool // Monitoring System Disconnected
 	TSV            bool // Time Source Invalid
 	NPW            bool // No Plot Warning
+
+	maxFXDepth int // Set by SetMaxFXDepth, 0 for the default depth
+}
+
+// SetMaxFXDepth implements asterix.FXDepthLimiter
+func (s *SensorConfigurationAndStatus) SetMaxFXDepth(octets int) {
+	s.maxFXDepth = octets
 }
 
 func (s *SensorConfigurationAndStatus) Decode(buf *bytes.Buffer) (int, error) {
@@ -83,15 +92,10 @@ func (s *SensorConfigurationAndStatus) Decode(buf *bytes.Buffer) (int, error) {
 		fx = (firstExtent & 0x01) != 0
 		if fx {
 			// Read and skip any additional extensions
-			for fx {
-				data := make([]byte, 1)
-				n, err := buf.Read(data)
-				if err != nil {
-					return bytesRead, fmt.Errorf("reading sensor configuration additional extent: %w", err)
-				}
-
-				bytesRead += n
-				fx = (data[0] & 0x01) != 0
+			extents, err := asterix.ReadFXChain(buf, s.maxFXDepth)
+			bytesRead += len(extents)
+			if err != nil {
+				return bytesRead, fmt.Errorf("reading sensor configuration additional extent: %w", err)
 			}
 		}
 	}
@@ -182,7 +182,7 @@ var cat063Fields = []asterix.DataField{
 		FRN:         13,
 		DataItem:    "RE063",
 		Description: "Reserved Expansion Field",
-		Type:        asterix.Repetitive,
+		Type:        asterix.Explicit,
 		Length:      1,
 		Mandatory:   false,
 	},
@@ -190,7 +190,7 @@ var cat063Fields = []asterix.DataField{
 		FRN:         14,
 		DataItem:    "SP063",
 		Description: "Special Purpose Field",
-		Type:        asterix.Repetitive,
+		Type:        asterix.Explicit,
 		Length:      1,
 		Mandatory:   false,
 	},
@@ -19,7 +19,7 @@ func NewUAP(version string) (asterix.UAP, error) {
 	case Version16:
 		return uap.NewUAP063()
 	default:
-		return nil, fmt.Errorf("unsupported CAT063 version: %s", version)
+		return nil, fmt.Errorf("%w: CAT063 %s", asterix.ErrUnsupportedVersion, version)
 	}
 }
 
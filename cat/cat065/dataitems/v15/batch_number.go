@@ -0,0 +1,39 @@
+// cat/cat065/dataitems/v15/batch_number.go
+package v15
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// BatchNumber implements I065/020
+// A number indicating the completion of a service for that batch of track
+// data, from 0 to N-1, N being the number of batches used to make one
+// complete processing cycle.
+type BatchNumber struct {
+	Value uint8
+}
+
+func (b *BatchNumber) Decode(buf *bytes.Buffer) (int, error) {
+	v, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading batch number: %w", err)
+	}
+	b.Value = v
+	return 1, nil
+}
+
+func (b *BatchNumber) Encode(buf *bytes.Buffer) (int, error) {
+	if err := buf.WriteByte(b.Value); err != nil {
+		return 0, fmt.Errorf("writing batch number: %w", err)
+	}
+	return 1, nil
+}
+
+func (b *BatchNumber) Validate() error {
+	return nil
+}
+
+func (b *BatchNumber) String() string {
+	return fmt.Sprintf("%d", b.Value)
+}
@@ -0,0 +1,62 @@
+// cat/cat065/dataitems/v15/message_type.go
+package v15
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Message types defined for I065/000
+const (
+	MessageTypeSDPSStatus          uint8 = 1
+	MessageTypeEndOfBatch          uint8 = 2
+	MessageTypeServiceStatusReport uint8 = 3
+)
+
+// MessageType implements I065/000
+// This Data Item allows for a more convenient handling of the messages at
+// the receiver side by further defining the type of transaction.
+type MessageType struct {
+	Value uint8
+}
+
+func (m *MessageType) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading message type: %w", err)
+	}
+	m.Value = b
+
+	return 1, m.Validate()
+}
+
+func (m *MessageType) Encode(buf *bytes.Buffer) (int, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	if err := buf.WriteByte(m.Value); err != nil {
+		return 0, fmt.Errorf("writing message type: %w", err)
+	}
+	return 1, nil
+}
+
+func (m *MessageType) Validate() error {
+	if m.Value < MessageTypeSDPSStatus || m.Value > MessageTypeServiceStatusReport {
+		return fmt.Errorf("invalid message type: %d", m.Value)
+	}
+	return nil
+}
+
+func (m *MessageType) String() string {
+	switch m.Value {
+	case MessageTypeSDPSStatus:
+		return "SDPS Status"
+	case MessageTypeEndOfBatch:
+		return "End of Batch"
+	case MessageTypeServiceStatusReport:
+		return "Service Status Report"
+	default:
+		return fmt.Sprintf("Unknown (%d)", m.Value)
+	}
+}
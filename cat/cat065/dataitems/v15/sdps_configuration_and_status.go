@@ -0,0 +1,96 @@
+// cat/cat065/dataitems/v15/sdps_configuration_and_status.go
+package v15
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// SDPSConfigurationAndStatus implements I065/040
+// Status of an SDPS.
+type SDPSConfigurationAndStatus struct {
+	NOGO uint8 // 0=Operational, 1=Degraded, 2=Not currently connected, 3=Unknown
+	OVL  bool  // Overload
+	TSV  bool  // Time source invalid
+	PSS  uint8 // Processing system status: 0=Not applicable, 1-3=SDPS-1..3 selected
+	STTN bool  // Track re-numbering indication (toggles on each re-numbering)
+}
+
+func (s *SDPSConfigurationAndStatus) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading SDPS configuration and status: %w", err)
+	}
+
+	s.NOGO = (b >> 6) & 0x03
+	s.OVL = (b & 0x20) != 0
+	s.TSV = (b & 0x10) != 0
+	s.PSS = (b >> 2) & 0x03
+	s.STTN = (b & 0x02) != 0
+	// Bit 1 is spare
+
+	return 1, nil
+}
+
+func (s *SDPSConfigurationAndStatus) Encode(buf *bytes.Buffer) (int, error) {
+	if err := s.Validate(); err != nil {
+		return 0, err
+	}
+
+	b := (s.NOGO << 6) | (s.PSS << 2)
+	if s.OVL {
+		b |= 0x20
+	}
+	if s.TSV {
+		b |= 0x10
+	}
+	if s.STTN {
+		b |= 0x02
+	}
+
+	if err := buf.WriteByte(b); err != nil {
+		return 0, fmt.Errorf("writing SDPS configuration and status: %w", err)
+	}
+	return 1, nil
+}
+
+func (s *SDPSConfigurationAndStatus) Validate() error {
+	if s.NOGO > 3 {
+		return fmt.Errorf("invalid NOGO value: %d", s.NOGO)
+	}
+	if s.PSS > 3 {
+		return fmt.Errorf("invalid PSS value: %d", s.PSS)
+	}
+	return nil
+}
+
+func (s *SDPSConfigurationAndStatus) String() string {
+	parts := []string{}
+
+	switch s.NOGO {
+	case 0:
+		parts = append(parts, "Operational")
+	case 1:
+		parts = append(parts, "Degraded")
+	case 2:
+		parts = append(parts, "Not currently connected")
+	case 3:
+		parts = append(parts, "Unknown")
+	}
+
+	if s.OVL {
+		parts = append(parts, "Overload")
+	}
+	if s.TSV {
+		parts = append(parts, "Time source invalid")
+	}
+	if s.PSS > 0 {
+		parts = append(parts, fmt.Sprintf("SDPS-%d selected", s.PSS))
+	}
+	if s.STTN {
+		parts = append(parts, "STTN")
+	}
+
+	return strings.Join(parts, ", ")
+}
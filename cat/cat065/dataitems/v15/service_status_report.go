@@ -0,0 +1,59 @@
+// cat/cat065/dataitems/v15/service_status_report.go
+package v15
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ServiceStatusReport implements I065/050
+// Report sent by the SDPS related to a service.
+type ServiceStatusReport struct {
+	Report uint8
+}
+
+var serviceStatusReports = map[uint8]string{
+	1:  "Service degradation",
+	2:  "Service degradation ended",
+	3:  "Main radar out of service",
+	4:  "Service interrupted by the operator",
+	5:  "Service interrupted due to contingency",
+	6:  "Ready for service restart after contingency",
+	7:  "Service ended by the operator",
+	8:  "Failure of user main radar",
+	9:  "Service restarted by the operator",
+	10: "Main radar becoming operational",
+	11: "Main radar becoming degraded",
+	12: "Service continuity interrupted due to disconnection with adjacent unit",
+	13: "Service continuity restarted",
+	14: "Service synchronised on backup radar",
+	15: "Service synchronised on main radar",
+	16: "Main and backup radar, if any, failed",
+}
+
+func (s *ServiceStatusReport) Decode(buf *bytes.Buffer) (int, error) {
+	b, err := buf.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("reading service status report: %w", err)
+	}
+	s.Report = b
+	return 1, nil
+}
+
+func (s *ServiceStatusReport) Encode(buf *bytes.Buffer) (int, error) {
+	if err := buf.WriteByte(s.Report); err != nil {
+		return 0, fmt.Errorf("writing service status report: %w", err)
+	}
+	return 1, nil
+}
+
+func (s *ServiceStatusReport) Validate() error {
+	return nil
+}
+
+func (s *ServiceStatusReport) String() string {
+	if desc, ok := serviceStatusReports[s.Report]; ok {
+		return desc
+	}
+	return fmt.Sprintf("Report %d", s.Report)
+}
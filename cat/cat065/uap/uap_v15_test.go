@@ -0,0 +1,128 @@
+// cat/cat065/uap/uap_v15_test.go
+package uap_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat065"
+	v15 "github.com/davidkohl/gobelix/cat/cat065/dataitems/v15"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func newServiceStatusRecord(t *testing.T, uap asterix.UAP, withReport bool) *asterix.Record {
+	t.Helper()
+	record, err := asterix.NewRecord(asterix.Cat065, uap)
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	items := []struct {
+		id   string
+		item asterix.DataItem
+	}{
+		{"I065/010", &common.DataSourceIdentifier{SAC: 25, SIC: 200}},
+		{"I065/000", &v15.MessageType{Value: v15.MessageTypeServiceStatusReport}},
+		{"I065/015", &common.ServiceIdentification{Value: 3}},
+		{"I065/030", &v15.TimeOfMessage{Time: 36000.25}},
+		{"I065/040", &v15.SDPSConfigurationAndStatus{NOGO: 1, TSV: true, PSS: 2, STTN: true}},
+	}
+	if withReport {
+		items = append(items, struct {
+			id   string
+			item asterix.DataItem
+		}{"I065/050", &v15.ServiceStatusReport{Report: 11}})
+	}
+	for _, it := range items {
+		if err := record.SetDataItem(it.id, it.item); err != nil {
+			t.Fatalf("SetDataItem(%s) error = %v", it.id, err)
+		}
+	}
+	return record
+}
+
+func TestUAP065_ServiceStatusRoundTrip(t *testing.T) {
+	uap, err := cat065.NewUAP(cat065.Version15)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat065, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.AddRecord(newServiceStatusRecord(t, uap, true)); err != nil {
+		t.Fatalf("AddRecord() error = %v", err)
+	}
+	encoded, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	want := []byte{
+		65, 0x00, 0x0D, // CAT, LEN
+		0xF6,       // FSPEC: 010, 000, 015, 030, 040, 050
+		0x19, 0xC8, // I065/010
+		0x03,             // I065/000
+		0x03,             // I065/015
+		0x46, 0x50, 0x20, // I065/030
+		0x5A, // I065/040
+		0x0B, // I065/050
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Fatalf("Encode() = % X, want % X", encoded, want)
+	}
+
+	decoder, err := asterix.NewDecoder(uap)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	msg, err := decoder.Decode(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if msg.GetRecordCount() != 1 {
+		t.Fatalf("Decode() records = %d, want 1", msg.GetRecordCount())
+	}
+
+	decoded, err := asterix.NewDataBlock(asterix.Cat065, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatalf("DataBlock.Decode() error = %v", err)
+	}
+	record := decoded.Records()[0]
+
+	item, _, ok := record.GetDataItem("I065/040")
+	if !ok {
+		t.Fatal("I065/040 missing after decode")
+	}
+	status := item.(*v15.SDPSConfigurationAndStatus)
+	if status.NOGO != 1 || status.OVL || !status.TSV || status.PSS != 2 || !status.STTN {
+		t.Errorf("I065/040 = %+v, want NOGO=1 TSV PSS=2 STTN", status)
+	}
+
+	item, _, ok = record.GetDataItem("I065/030")
+	if !ok || item.(*v15.TimeOfMessage).Time != 36000.25 {
+		t.Errorf("I065/030 = %v, want 36000.25", item)
+	}
+	item, _, ok = record.GetDataItem("I065/050")
+	if !ok || item.(*v15.ServiceStatusReport).Report != 11 {
+		t.Errorf("I065/050 = %v, want report 11", item)
+	}
+}
+
+func TestUAP065_ServiceStatusRequiresReport(t *testing.T) {
+	uap, err := cat065.NewUAP(cat065.Version15)
+	if err != nil {
+		t.Fatalf("NewUAP() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, err = newServiceStatusRecord(t, uap, false).Encode(&buf)
+	if !errors.Is(err, asterix.ErrMandatoryField) {
+		t.Errorf("Encode() error = %v, want %v", err, asterix.ErrMandatoryField)
+	}
+}
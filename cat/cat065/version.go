@@ -0,0 +1,34 @@
+// cat/cat065/version.go
+package cat065
+
+import (
+	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat065/uap"
+)
+
+// Version constants
+const (
+	Version15 = "1.5"
+)
+
+// NewUAP returns the UAP for the specified version of CAT065
+func NewUAP(version string) (asterix.UAP, error) {
+	switch version {
+	case Version15:
+		return uap.NewUAP065()
+	default:
+		return nil, fmt.Errorf("%w: CAT065 %s", asterix.ErrUnsupportedVersion, version)
+	}
+}
+
+// LatestVersion returns the latest available version
+func LatestVersion() string {
+	return Version15
+}
+
+// AvailableVersions returns all supported versions
+func AvailableVersions() []string {
+	return []string{Version15}
+}
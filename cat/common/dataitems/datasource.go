@@ -4,6 +4,8 @@ package common
 import (
 	"bytes"
 	"fmt"
+
+	"github.com/davidkohl/gobelix/asterix/sources"
 )
 
 type DataSourceIdentifier struct {
@@ -54,6 +56,35 @@ func (d *DataSourceIdentifier) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (d *DataSourceIdentifier) Reset() {
+	*d = DataSourceIdentifier{}
+}
+
+// Name returns the sensor name registered for this SAC/SIC in the sources registry
+func (d *DataSourceIdentifier) Name() (string, bool) {
+	return sources.Name(d.SAC, d.SIC)
+}
+
+// Source implements asterix.SourceProvider
+func (d *DataSourceIdentifier) Source() (sac, sic uint8) {
+	return d.SAC, d.SIC
+}
+
 func (d *DataSourceIdentifier) String() string {
+	if name, ok := d.Name(); ok {
+		return fmt.Sprintf("%s (SAC: %d, SIC: %d)", name, d.SAC, d.SIC)
+	}
 	return fmt.Sprintf("SAC: %d, SIC: %d", d.SAC, d.SIC)
 }
+
+// GetField implements asterix.FieldGetter for SAC and SIC
+func (d *DataSourceIdentifier) GetField(name string) (any, bool) {
+	switch name {
+	case "SAC":
+		return d.SAC, true
+	case "SIC":
+		return d.SIC, true
+	}
+	return nil, false
+}
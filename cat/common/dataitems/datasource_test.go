@@ -0,0 +1,38 @@
+// dataitems/common/datasource_test.go
+package common_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix/sources"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestDataSourceIdentifier_Name(t *testing.T) {
+	sources.Reset()
+	defer sources.Reset()
+
+	item := &common.DataSourceIdentifier{SAC: 25, SIC: 100}
+	if _, ok := item.Name(); ok {
+		t.Fatalf("Name() found a name before registration")
+	}
+	if got := item.String(); got != "SAC: 25, SIC: 100" {
+		t.Errorf("String() = %q, want %q", got, "SAC: 25, SIC: 100")
+	}
+
+	sources.Register(25, 100, "LFPG-MLAT")
+	name, ok := item.Name()
+	if !ok || name != "LFPG-MLAT" {
+		t.Errorf("Name() = %q, %v, want %q, true", name, ok, "LFPG-MLAT")
+	}
+	if got := item.String(); !strings.Contains(got, "LFPG-MLAT") {
+		t.Errorf("String() = %q, want it to contain %q", got, "LFPG-MLAT")
+	}
+
+	sources.Load(map[sources.ID]string{{SAC: 25, SIC: 101}: "LFPG-ASR"})
+	other := &common.DataSourceIdentifier{SAC: 25, SIC: 101}
+	if name, _ := other.Name(); name != "LFPG-ASR" {
+		t.Errorf("Name() after Load = %q, want %q", name, "LFPG-ASR")
+	}
+}
@@ -57,6 +57,11 @@ func (f *FlightLevel) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (f *FlightLevel) Reset() {
+	*f = FlightLevel{}
+}
+
 func (f *FlightLevel) String() string {
 	if f.Value < 0 {
 		return fmt.Sprintf("FL-%.0f", float64(-f.Value))
@@ -103,6 +103,27 @@ func (p *Position) Validate() error {
 	return nil
 }
 
+// Reset implements asterix.Resetter
+func (p *Position) Reset() {
+	*p = Position{}
+}
+
 func (p *Position) String() string {
 	return fmt.Sprintf("%.6f°N %.6f°E", p.Latitude, p.Longitude)
 }
+
+// WGS84 implements asterix.PositionProvider
+func (p *Position) WGS84() (float64, float64) {
+	return p.Latitude, p.Longitude
+}
+
+// GetField implements asterix.FieldGetter for Latitude and Longitude
+func (p *Position) GetField(name string) (any, bool) {
+	switch name {
+	case "Latitude":
+		return p.Latitude, true
+	case "Longitude":
+		return p.Longitude, true
+	}
+	return nil, false
+}
@@ -35,3 +35,8 @@ func (s *ServiceIdentification) Decode(buf *bytes.Buffer) (int, error) {
 func (s *ServiceIdentification) Validate() error {
 	return nil // All uint8 values are valid for service ID
 }
+
+// Reset implements asterix.Resetter
+func (s *ServiceIdentification) Reset() {
+	*s = ServiceIdentification{}
+}
@@ -0,0 +1,100 @@
+// dataitems/common/time_of_day.go
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"time"
+)
+
+// TimeOfDayResolution is the LSB of the 3-octet time of day (1/128 s)
+const TimeOfDayResolution = 1.0 / 128.0
+
+// todTick is the duration of one time of day count (7.8125 ms)
+const todTick = time.Second / 128
+
+// maxTODCounts is the largest value that fits in 3 octets
+const maxTODCounts = 1<<24 - 1
+
+// DecodeTOD converts a big-endian 3-octet time of day into the duration
+// since midnight. The conversion is exact: one count is 7812500 ns.
+func DecodeTOD(data [3]byte) time.Duration {
+	counts := uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2])
+	return time.Duration(counts) * todTick
+}
+
+// EncodeTOD converts a duration since midnight into a big-endian 3-octet
+// time of day, rounding to the nearest 1/128 s. Durations outside the
+// range of 3 octets saturate at 0 and 2^24-1 counts.
+func EncodeTOD(d time.Duration) [3]byte {
+	counts := (d + todTick/2) / todTick
+	if d < 0 {
+		counts = 0
+	} else if counts > maxTODCounts {
+		counts = maxTODCounts
+	}
+	return [3]byte{byte(counts >> 16), byte(counts >> 8), byte(counts)}
+}
+
+// TimeOfDay is the 3-octet time of day shared by many items: elapsed time
+// since last midnight, expressed as UTC, with an LSB of 1/128 s. Encode
+// wraps times within half a count of midnight to 0.
+type TimeOfDay struct {
+	Time float64 // Time in seconds since midnight
+}
+
+func (t *TimeOfDay) Decode(buf *bytes.Buffer) (int, error) {
+	var data [3]byte
+	n, err := buf.Read(data[:])
+	if err != nil {
+		return n, fmt.Errorf("reading time of day: %w", err)
+	}
+	if n != 3 {
+		return n, fmt.Errorf("insufficient data: got %d bytes, want 3", n)
+	}
+
+	t.Time = DecodeTOD(data).Seconds()
+
+	return n, t.Validate()
+}
+
+func (t *TimeOfDay) Encode(buf *bytes.Buffer) (int, error) {
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+
+	// A time rounding up to midnight is the first count of the next day
+	d := t.Duration()
+	if d >= 24*time.Hour-todTick/2 {
+		d = 0
+	}
+	data := EncodeTOD(d)
+
+	n, err := buf.Write(data[:])
+	if err != nil {
+		return n, fmt.Errorf("writing time of day: %w", err)
+	}
+	return n, nil
+}
+
+func (t *TimeOfDay) Validate() error {
+	if t.Time < 0 || t.Time >= 86400 {
+		return fmt.Errorf("time out of valid range [0,86400): %f", t.Time)
+	}
+	return nil
+}
+
+// Reset implements asterix.Resetter
+func (t *TimeOfDay) Reset() {
+	*t = TimeOfDay{}
+}
+
+// Duration returns the time elapsed since midnight UTC
+func (t *TimeOfDay) Duration() time.Duration {
+	return time.Duration(math.Round(t.Time * float64(time.Second)))
+}
+
+func (t *TimeOfDay) String() string {
+	return fmt.Sprintf("Seconds since midnight %v", t.Time)
+}
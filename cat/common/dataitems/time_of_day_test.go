@@ -0,0 +1,91 @@
+// dataitems/common/time_of_day_test.go
+package common_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestTOD_RoundTripAllCounts(t *testing.T) {
+	for counts := uint32(0); counts < 1<<24; counts++ {
+		raw := [3]byte{byte(counts >> 16), byte(counts >> 8), byte(counts)}
+		if got := common.EncodeTOD(common.DecodeTOD(raw)); got != raw {
+			t.Fatalf("EncodeTOD(DecodeTOD(% X)) = % X", raw[:], got[:])
+		}
+	}
+}
+
+func TestEncodeTOD_Rounding(t *testing.T) {
+	tick := time.Second / 128
+	tests := []struct {
+		d    time.Duration
+		want [3]byte
+	}{
+		{0, [3]byte{0, 0, 0}},
+		{tick/2 - 1, [3]byte{0, 0, 0}},
+		{tick / 2, [3]byte{0, 0, 1}},
+		{tick + tick/2 - 1, [3]byte{0, 0, 1}},
+		{-time.Second, [3]byte{0, 0, 0}},
+		{time.Duration(1<<24) * tick, [3]byte{0xFF, 0xFF, 0xFF}},
+	}
+	for _, tt := range tests {
+		if got := common.EncodeTOD(tt.d); got != tt.want {
+			t.Errorf("EncodeTOD(%v) = % X, want % X", tt.d, got[:], tt.want[:])
+		}
+	}
+}
+
+func TestTimeOfDay_JustBeforeMidnight(t *testing.T) {
+	// 86399.9921875 s is the last count before 24h (0xA8BFFF)
+	raw := []byte{0xA8, 0xBF, 0xFF}
+
+	var tod common.TimeOfDay
+	if _, err := tod.Decode(bytes.NewBuffer(raw)); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if tod.Time != 86399.9921875 {
+		t.Errorf("Time = %v, want 86399.9921875", tod.Time)
+	}
+	if want := 24*time.Hour - time.Second/128; tod.Duration() != want {
+		t.Errorf("Duration() = %v, want %v", tod.Duration(), want)
+	}
+
+	var buf bytes.Buffer
+	if _, err := tod.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), raw) {
+		t.Errorf("Encode() = % X, want % X", buf.Bytes(), raw)
+	}
+}
+
+func TestTimeOfDay_RoundsToMidnight(t *testing.T) {
+	for _, seconds := range []float64{86399.99609375, 86399.999} {
+		tod := common.TimeOfDay{Time: seconds}
+		var buf bytes.Buffer
+		if _, err := tod.Encode(&buf); err != nil {
+			t.Fatalf("Encode(%v) error = %v", seconds, err)
+		}
+		if !bytes.Equal(buf.Bytes(), []byte{0, 0, 0}) {
+			t.Errorf("Encode(%v) = % X, want 00 00 00", seconds, buf.Bytes())
+		}
+
+		var decoded common.TimeOfDay
+		if _, err := decoded.Decode(&buf); err != nil {
+			t.Errorf("Decode(Encode(%v)) error = %v", seconds, err)
+		}
+	}
+
+	// Just below the midpoint the time still rounds to the last count
+	tod := common.TimeOfDay{Time: 86399.99609374}
+	var buf bytes.Buffer
+	if _, err := tod.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{0xA8, 0xBF, 0xFF}) {
+		t.Errorf("Encode(86399.99609374) = % X, want A8 BF FF", buf.Bytes())
+	}
+}
@@ -36,3 +36,8 @@ func (t *TrackNumber) Validate() error {
 	}
 	return nil
 }
+
+// Reset implements asterix.Resetter
+func (t *TrackNumber) Reset() {
+	*t = TrackNumber{}
+}
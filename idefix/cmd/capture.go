@@ -0,0 +1,98 @@
+// capture.go
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	captureDest string
+	capturePort int
+)
+
+func init() {
+	captureCmd := &cobra.Command{
+		Use:   "capture",
+		Short: "Record raw ASTERIX blocks from UDP to a file",
+		Long: `Listen on a UDP port and append every received datagram to a file exactly as
+received. ASTERIX blocks carry their own length, so the file can be replayed or
+checked with 'idefix validate'. Stop with Ctrl+C.
+Example: idefix capture --port 2000 --dest fixture.bin`,
+		RunE: runCapture,
+	}
+
+	captureCmd.Flags().StringVar(&captureDest, "dest", "", "File to write captured blocks to")
+	captureCmd.Flags().IntVar(&capturePort, "port", 0, "UDP port to listen on")
+	captureCmd.MarkFlagRequired("dest")
+	captureCmd.MarkFlagRequired("port")
+
+	rootCmd.AddCommand(captureCmd)
+}
+
+func runCapture(cmd *cobra.Command, args []string) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	f, err := os.Create(captureDest)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer f.Close()
+
+	conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", capturePort))
+	if err != nil {
+		return fmt.Errorf("failed to listen on UDP port %d: %w", capturePort, err)
+	}
+
+	// Closing the connection on SIGINT unblocks the read loop
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		<-sigCh
+		conn.Close()
+	}()
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Capturing UDP port %d to %s...\n", capturePort, captureDest)
+	}
+
+	w := bufio.NewWriter(f)
+	packets, written, err := capturePackets(conn, w)
+	if ferr := w.Flush(); ferr != nil && err == nil {
+		err = fmt.Errorf("flushing %s: %w", captureDest, ferr)
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "\nCaptured %d packets (%d bytes)\n", packets, written)
+	}
+	return err
+}
+
+// capturePackets copies datagrams from conn to w until the connection is closed
+func capturePackets(conn net.PacketConn, w *bufio.Writer) (packets, written int, err error) {
+	buf := make([]byte, 65536) // Max UDP packet size
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return packets, written, nil
+			}
+			return packets, written, fmt.Errorf("reading UDP packet: %w", err)
+		}
+
+		m, err := w.Write(buf[:n])
+		written += m
+		if err != nil {
+			return packets, written, fmt.Errorf("writing packet: %w", err)
+		}
+		packets++
+	}
+}
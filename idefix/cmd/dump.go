@@ -11,10 +11,17 @@ import (
 	"syscall"
 
 	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat001"
+	"github.com/davidkohl/gobelix/cat/cat002"
+	"github.com/davidkohl/gobelix/cat/cat004"
 	"github.com/davidkohl/gobelix/cat/cat021"
+	"github.com/davidkohl/gobelix/cat/cat023"
+	"github.com/davidkohl/gobelix/cat/cat030"
+	"github.com/davidkohl/gobelix/cat/cat034"
 	"github.com/davidkohl/gobelix/cat/cat048"
 	"github.com/davidkohl/gobelix/cat/cat062"
 	"github.com/davidkohl/gobelix/cat/cat063"
+	"github.com/davidkohl/gobelix/cat/cat065"
 	"github.com/davidkohl/gobelix/idefix/internal/asxreader"
 	"github.com/spf13/cobra"
 )
@@ -23,10 +30,17 @@ var (
 	portFlag   string
 	outputFile string
 	dumpAll    bool
+	dumpCat001 bool
+	dumpCat002 bool
+	dumpCat004 bool
 	dumpCat021 bool
+	dumpCat023 bool
+	dumpCat030 bool
+	dumpCat034 bool
 	dumpCat048 bool
 	dumpCat062 bool
 	dumpCat063 bool
+	dumpCat065 bool
 )
 
 func init() {
@@ -47,10 +61,17 @@ Example: idefix dump -p 2000/udp --dump021`,
 
 	// Add category flags
 	dumpCmd.Flags().BoolVar(&dumpAll, "dumpAll", false, "Dump all ASTERIX categories")
+	dumpCmd.Flags().BoolVar(&dumpCat001, "dump001", false, "Dump ASTERIX category 001")
+	dumpCmd.Flags().BoolVar(&dumpCat002, "dump002", false, "Dump ASTERIX category 002")
+	dumpCmd.Flags().BoolVar(&dumpCat004, "dump004", false, "Dump ASTERIX category 004")
 	dumpCmd.Flags().BoolVar(&dumpCat021, "dump021", false, "Dump ASTERIX category 021")
+	dumpCmd.Flags().BoolVar(&dumpCat023, "dump023", false, "Dump ASTERIX category 023")
+	dumpCmd.Flags().BoolVar(&dumpCat030, "dump030", false, "Dump ASTERIX category 030")
+	dumpCmd.Flags().BoolVar(&dumpCat034, "dump034", false, "Dump ASTERIX category 034")
 	dumpCmd.Flags().BoolVar(&dumpCat048, "dump048", false, "Dump ASTERIX category 048")
 	dumpCmd.Flags().BoolVar(&dumpCat062, "dump062", false, "Dump ASTERIX category 062")
 	dumpCmd.Flags().BoolVar(&dumpCat063, "dump063", false, "Dump ASTERIX category 063")
+	dumpCmd.Flags().BoolVar(&dumpCat065, "dump065", false, "Dump ASTERIX category 065")
 
 	rootCmd.AddCommand(dumpCmd)
 }
@@ -161,6 +182,30 @@ func processMessages(reader asxreader.AsterixReader, out *os.File, verbose bool)
 func createDecoder() (*asterix.Decoder, error) {
 	var uaps []asterix.UAP
 
+	if dumpAll || dumpCat001 {
+		uap001, err := cat001.NewUAP("1.1")
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Cat001 UAP: %w", err)
+		}
+		uaps = append(uaps, uap001)
+	}
+
+	if dumpAll || dumpCat002 {
+		uap002, err := cat002.NewUAP("1.0")
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Cat002 UAP: %w", err)
+		}
+		uaps = append(uaps, uap002)
+	}
+
+	if dumpAll || dumpCat004 {
+		uap004, err := cat004.NewUAP("1.12")
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Cat004 UAP: %w", err)
+		}
+		uaps = append(uaps, uap004)
+	}
+
 	if dumpAll || dumpCat021 {
 		uap021, err := cat021.NewUAP("2.6")
 		if err != nil {
@@ -169,6 +214,30 @@ func createDecoder() (*asterix.Decoder, error) {
 		uaps = append(uaps, uap021)
 	}
 
+	if dumpAll || dumpCat023 {
+		uap023, err := cat023.NewUAP("1.2")
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Cat023 UAP: %w", err)
+		}
+		uaps = append(uaps, uap023)
+	}
+
+	if dumpAll || dumpCat030 {
+		uap030, err := cat030.NewUAP("6.2")
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Cat030 UAP: %w", err)
+		}
+		uaps = append(uaps, uap030)
+	}
+
+	if dumpAll || dumpCat034 {
+		uap034, err := cat034.NewUAP("1.27")
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Cat034 UAP: %w", err)
+		}
+		uaps = append(uaps, uap034)
+	}
+
 	if dumpAll || dumpCat048 {
 		uap048, err := cat048.NewUAP("1.32")
 		if err != nil {
@@ -193,6 +262,14 @@ func createDecoder() (*asterix.Decoder, error) {
 		uaps = append(uaps, uap063)
 	}
 
+	if dumpAll || dumpCat065 {
+		uap065, err := cat065.NewUAP("1.5")
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Cat065 UAP: %w", err)
+		}
+		uaps = append(uaps, uap065)
+	}
+
 	if len(uaps) == 0 {
 		return nil, fmt.Errorf("no categories selected, use --dumpAll or specify categories")
 	}
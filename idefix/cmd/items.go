@@ -0,0 +1,169 @@
+// items.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/spf13/cobra"
+)
+
+var itemsJSON bool
+
+func init() {
+	itemsCmd := &cobra.Command{
+		Use:   "items <file>",
+		Short: "Count the records carrying each data item in a capture",
+		Long: `Read a file of concatenated ASTERIX blocks, decode every block and print,
+per category, how many records carry each data item. Items the library does
+not implement yet are counted too, which helps to decide what to implement
+next.
+Example: idefix items fixture.bin --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runItems,
+	}
+
+	itemsCmd.Flags().BoolVar(&itemsJSON, "json", false, "Print the histogram as JSON")
+
+	rootCmd.AddCommand(itemsCmd)
+}
+
+func runItems(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	uaps, err := allUAPs()
+	if err != nil {
+		return err
+	}
+
+	histogram, err := countItems(data, uaps)
+	if err != nil {
+		return err
+	}
+
+	if itemsJSON {
+		return writeItemsJSON(os.Stdout, histogram)
+	}
+	writeItems(os.Stdout, histogram)
+	return nil
+}
+
+// itemCount is the number of records of a category carrying one item
+type itemCount struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Records     int    `json:"records"`
+}
+
+// categoryItems is the item histogram of one category
+type categoryItems struct {
+	Category asterix.Category `json:"category"`
+	Records  int              `json:"records"`
+	Failed   int              `json:"failedBlocks"`
+	Items    []itemCount      `json:"items"`
+}
+
+// countItems decodes the concatenated blocks in data and returns the item
+// histogram of each category, ordered by category. Items are listed in FRN
+// order. Records are counted against the UAP they decoded with, so for a
+// UAPSelector each item is named by the profile of its record. Blocks of
+// categories without a UAP are skipped; blocks that fail to decode are
+// counted as failed.
+func countItems(data []byte, uaps map[asterix.Category]asterix.UAP) ([]categoryItems, error) {
+	type tally struct {
+		records int
+		failed  int
+		counts  map[string]*itemCount
+		frns    map[string]uint8
+	}
+	tallies := make(map[asterix.Category]*tally)
+
+	for offset := 0; offset < len(data); {
+		cat, length, err := asterix.Probe(data[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("block at offset %d: %w", offset, err)
+		}
+		blockData := data[offset : offset+length]
+		offset += length
+
+		uap, ok := uaps[cat]
+		if !ok {
+			continue
+		}
+		tl := tallies[cat]
+		if tl == nil {
+			tl = &tally{counts: make(map[string]*itemCount), frns: make(map[string]uint8)}
+			tallies[cat] = tl
+		}
+
+		block, err := asterix.NewDataBlock(cat, uap)
+		if err != nil {
+			return nil, err
+		}
+		if err := block.Decode(blockData); err != nil {
+			tl.failed++
+			continue
+		}
+		for _, record := range block.Records() {
+			tl.records++
+			present := record.PresentFRNs()
+			for _, field := range record.UAP().Fields() {
+				if field.DataItem == "" || !slices.Contains(present, field.FRN) {
+					continue
+				}
+				count := tl.counts[field.DataItem]
+				if count == nil {
+					count = &itemCount{ID: field.DataItem, Description: field.Description}
+					tl.counts[field.DataItem] = count
+					tl.frns[field.DataItem] = field.FRN
+				}
+				count.Records++
+			}
+		}
+	}
+
+	histogram := make([]categoryItems, 0, len(tallies))
+	for cat, tl := range tallies {
+		entry := categoryItems{Category: cat, Records: tl.records, Failed: tl.failed, Items: []itemCount{}}
+		for _, count := range tl.counts {
+			entry.Items = append(entry.Items, *count)
+		}
+		sort.Slice(entry.Items, func(i, j int) bool {
+			a, b := entry.Items[i], entry.Items[j]
+			if tl.frns[a.ID] != tl.frns[b.ID] {
+				return tl.frns[a.ID] < tl.frns[b.ID]
+			}
+			return a.ID < b.ID
+		})
+		histogram = append(histogram, entry)
+	}
+	sort.Slice(histogram, func(i, j int) bool { return histogram[i].Category < histogram[j].Category })
+	return histogram, nil
+}
+
+func writeItems(out io.Writer, histogram []categoryItems) {
+	for _, entry := range histogram {
+		fmt.Fprintf(out, "%s: %d records", entry.Category, entry.Records)
+		if entry.Failed > 0 {
+			fmt.Fprintf(out, ", %d blocks failed to decode", entry.Failed)
+		}
+		fmt.Fprintln(out)
+		for _, item := range entry.Items {
+			fmt.Fprintf(out, "  %-9s %8d  %s\n", item.ID, item.Records, item.Description)
+		}
+	}
+}
+
+func writeItemsJSON(out io.Writer, histogram []categoryItems) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(histogram)
+}
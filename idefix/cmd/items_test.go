@@ -0,0 +1,111 @@
+// items_test.go
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestCountItems(t *testing.T) {
+	uaps, err := allUAPs()
+	if err != nil {
+		t.Fatalf("allUAPs() error = %v", err)
+	}
+	uap := uaps[asterix.Cat021]
+
+	// Five records, two of which carry the time of message reception
+	var capture []byte
+	for _, timed := range [][]bool{{true, false, false}, {true, false}} {
+		block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewDataBlock() error = %v", err)
+		}
+		for _, withTime := range timed {
+			record, err := asterix.NewRecord(asterix.Cat021, uap)
+			if err != nil {
+				t.Fatalf("NewRecord() error = %v", err)
+			}
+			record.SetDataItem("I021/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100})
+			record.SetDataItem("I021/040", &v26.TargetReportDescriptor{ATP: 1})
+			record.SetDataItem("I021/080", &v26.TargetAddress{Address: 0xABCDEF})
+			if withTime {
+				record.SetDataItem("I021/073", &v26.TimeOfMessageReceptionPosition{Time: 3600})
+			}
+			block.AddRecord(record)
+		}
+		data, err := block.Encode()
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		capture = append(capture, data...)
+	}
+
+	histogram, err := countItems(capture, uaps)
+	if err != nil {
+		t.Fatalf("countItems() error = %v", err)
+	}
+	if len(histogram) != 1 || histogram[0].Category != asterix.Cat021 || histogram[0].Records != 5 {
+		t.Fatalf("countItems() = %+v, want 5 Cat021 records", histogram)
+	}
+
+	got := make(map[string]int)
+	for _, item := range histogram[0].Items {
+		got[item.ID] = item.Records
+	}
+	want := map[string]int{"I021/010": 5, "I021/040": 5, "I021/073": 2, "I021/080": 5}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("item counts = %v, want %v", got, want)
+	}
+
+	var out bytes.Buffer
+	writeItems(&out, histogram)
+	if !strings.Contains(out.String(), "I021/073         2") {
+		t.Errorf("writeItems() = %q, missing the I021/073 count", out.String())
+	}
+
+	out.Reset()
+	if err := writeItemsJSON(&out, histogram); err != nil {
+		t.Fatalf("writeItemsJSON() error = %v", err)
+	}
+	var decoded []categoryItems
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("unmarshalling JSON output: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, histogram) {
+		t.Errorf("JSON round trip = %+v, want %+v", decoded, histogram)
+	}
+}
+
+func TestCountItems_Cat001(t *testing.T) {
+	uaps, err := allUAPs()
+	if err != nil {
+		t.Fatalf("allUAPs() error = %v", err)
+	}
+
+	histogram, err := countItems(cat001Capture, uaps)
+	if err != nil {
+		t.Fatalf("countItems() error = %v", err)
+	}
+	if len(histogram) != 1 || histogram[0].Category != asterix.Cat001 || histogram[0].Records != 2 {
+		t.Fatalf("countItems() = %+v, want 2 Cat001 records", histogram)
+	}
+
+	var got []string
+	for _, item := range histogram[0].Items {
+		got = append(got, fmt.Sprintf("%s=%d", item.ID, item.Records))
+	}
+	// Items sit at the FRN of the first record carrying them: I001/040 and
+	// I001/070 at those of the plot profile, I001/161 at FRN 3 of the track
+	want := []string{"I001/010=2", "I001/020=2", "I001/040=2", "I001/161=1", "I001/070=2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("item counts = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,236 @@
+// patch.go
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/spf13/cobra"
+)
+
+var (
+	patchRecord int
+	patchItem   string
+	patchHex    string
+)
+
+func init() {
+	patchCmd := &cobra.Command{
+		Use:   "patch <in> <out>",
+		Short: "Replace the bytes of one data item in a capture",
+		Long: `Read a file of concatenated ASTERIX blocks, replace the bytes of one data
+item in one record and write the result. Every other byte of the capture is
+copied unchanged; only the length of the patched block is updated. The new
+bytes must decode as the item and, for fixed length items, match the length
+given by the UAP.
+Records are numbered from 0 across all blocks of supported categories.
+Example: idefix patch in.bin out.bin --record 3 --item I021/070 --hex 0A00`,
+		Args: cobra.ExactArgs(2),
+		RunE: runPatch,
+	}
+
+	patchCmd.Flags().IntVarP(&patchRecord, "record", "r", 0, "Index of the record to patch")
+	patchCmd.Flags().StringVarP(&patchItem, "item", "i", "", "Data item to replace, e.g. I021/070")
+	patchCmd.Flags().StringVarP(&patchHex, "hex", "x", "", "New item bytes in hexadecimal")
+	patchCmd.MarkFlagRequired("item")
+	patchCmd.MarkFlagRequired("hex")
+
+	rootCmd.AddCommand(patchCmd)
+}
+
+func runPatch(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	value, err := hex.DecodeString(patchHex)
+	if err != nil {
+		return fmt.Errorf("invalid --hex value: %w", err)
+	}
+
+	uaps, err := allUAPs()
+	if err != nil {
+		return err
+	}
+
+	patched, err := patchCapture(data, uaps, patchRecord, patchItem, value)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(args[1], patched, 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// itemSpan locates the bytes of one data item within a record
+type itemSpan struct {
+	field      asterix.DataField
+	start, end int
+}
+
+// patchCapture returns a copy of the concatenated blocks in data in which the
+// bytes of item in the given record are replaced by value. Blocks of
+// categories without a UAP are copied but their records are not counted.
+func patchCapture(data []byte, uaps map[asterix.Category]asterix.UAP, record int, item string, value []byte) ([]byte, error) {
+	if record < 0 {
+		return nil, fmt.Errorf("invalid record index %d", record)
+	}
+
+	out := make([]byte, 0, len(data)+len(value))
+	index := 0
+	patched := false
+
+	for offset := 0; offset < len(data); {
+		cat, length, err := asterix.Probe(data[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("block at offset %d: %w", offset, err)
+		}
+		block := data[offset : offset+length]
+		offset += length
+
+		uap, ok := uaps[cat]
+		if !ok || patched {
+			out = append(out, block...)
+			continue
+		}
+
+		body := block[3:]
+		for pos := 0; pos < len(body); {
+			spans, size, err := recordSpans(body[pos:], uap)
+			if err != nil {
+				return nil, fmt.Errorf("block at offset %d, record %d: %w", offset-length, index, err)
+			}
+			if index == record {
+				body, err = patchRecordItem(body, pos, spans, uap, item, value)
+				if err != nil {
+					return nil, fmt.Errorf("record %d: %w", index, err)
+				}
+				patched = true
+				break
+			}
+			pos += size
+			index++
+		}
+
+		if len(body)+3 > asterix.MaxBlockLength {
+			return nil, fmt.Errorf("patched block of %d bytes exceeds the maximum block length", len(body)+3)
+		}
+		out = append(out, byte(cat))
+		out = binary.BigEndian.AppendUint16(out, uint16(len(body)+3))
+		out = append(out, body...)
+	}
+
+	if !patched {
+		return nil, fmt.Errorf("record %d not found: capture holds %d records", record, index)
+	}
+	return out, nil
+}
+
+// patchRecordItem replaces the bytes of item in the record starting at pos
+// of body and returns the new body
+func patchRecordItem(body []byte, pos int, spans []itemSpan, uap asterix.UAP, item string, value []byte) ([]byte, error) {
+	for _, span := range spans {
+		if span.field.DataItem != item {
+			continue
+		}
+		if err := checkItemBytes(span.field, uap, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", item, err)
+		}
+
+		patched := make([]byte, 0, len(body)-(span.end-span.start)+len(value))
+		patched = append(patched, body[:pos+span.start]...)
+		patched = append(patched, value...)
+		patched = append(patched, body[pos+span.end:]...)
+		return patched, nil
+	}
+	return nil, fmt.Errorf("%w: %s not present in record", asterix.ErrUnknownDataItem, item)
+}
+
+// checkItemBytes verifies that value is a complete encoding of the field
+func checkItemBytes(field asterix.DataField, uap asterix.UAP, value []byte) error {
+	if field.Type == asterix.Fixed && len(value) != int(field.Length) {
+		return fmt.Errorf("%w: got %d bytes, UAP defines %d", asterix.ErrInvalidLength, len(value), field.Length)
+	}
+
+	item, err := uap.CreateDataItem(field.DataItem)
+	if err != nil {
+		// Unimplemented fixed length items can only be checked for length
+		if field.Type == asterix.Fixed {
+			return nil
+		}
+		return err
+	}
+
+	buf := bytes.NewBuffer(value)
+	if _, err := item.Decode(buf); err != nil {
+		return fmt.Errorf("new bytes do not decode: %w", err)
+	}
+	if buf.Len() > 0 {
+		return fmt.Errorf("%w: %d of %d bytes left after decoding", asterix.ErrTrailingBytes, buf.Len(), len(value))
+	}
+	return nil
+}
+
+// recordSpans decodes the record at the start of data and returns the byte
+// span of each item it carries, along with the size of the whole record. For
+// a UAPSelector the fields after the shared ones are those of the profile the
+// shared items select.
+func recordSpans(data []byte, uap asterix.UAP) ([]itemSpan, int, error) {
+	buf := bytes.NewBuffer(data)
+	fspec := asterix.NewFSPEC()
+	if _, err := fspec.Decode(buf); err != nil {
+		return nil, 0, fmt.Errorf("decoding FSPEC: %w", err)
+	}
+
+	var spans []itemSpan
+	items := make(map[string]asterix.DataItem)
+	walk := func(fields []asterix.DataField, after uint8) error {
+		for _, field := range fields {
+			if field.FRN <= after || !fspec.GetFRN(field.FRN) {
+				continue
+			}
+			start := len(data) - buf.Len()
+
+			item, err := uap.CreateDataItem(field.DataItem)
+			if err != nil {
+				if field.Type != asterix.Fixed {
+					return fmt.Errorf("creating item %s: %w", field.DataItem, err)
+				}
+				if buf.Len() < int(field.Length) {
+					return fmt.Errorf("%w: %s needs %d bytes, %d left",
+						asterix.ErrBufferTooShort, field.DataItem, field.Length, buf.Len())
+				}
+				buf.Next(int(field.Length))
+			} else if _, err := item.Decode(buf); err != nil {
+				return fmt.Errorf("decoding %s: %w", field.DataItem, err)
+			} else {
+				items[field.DataItem] = item
+			}
+
+			spans = append(spans, itemSpan{field: field, start: start, end: len(data) - buf.Len()})
+		}
+		return nil
+	}
+
+	shared := uap.Fields()
+	if err := walk(shared, 0); err != nil {
+		return nil, 0, err
+	}
+	if sel, ok := uap.(asterix.UAPSelector); ok {
+		profile, err := sel.SelectUAP(items)
+		if err != nil {
+			return nil, 0, fmt.Errorf("selecting UAP: %w", err)
+		}
+		if err := walk(profile.Fields(), shared[len(shared)-1].FRN); err != nil {
+			return nil, 0, err
+		}
+	}
+	return spans, len(data) - buf.Len(), nil
+}
@@ -0,0 +1,151 @@
+// patch_test.go
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func encodeCat021Block(t *testing.T, uap asterix.UAP, addresses ...uint32) []byte {
+	t.Helper()
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	for _, address := range addresses {
+		record, err := asterix.NewRecord(asterix.Cat021, uap)
+		if err != nil {
+			t.Fatalf("NewRecord() error = %v", err)
+		}
+		record.SetDataItem("I021/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100})
+		record.SetDataItem("I021/040", &v26.TargetReportDescriptor{ATP: 1})
+		record.SetDataItem("I021/080", &v26.TargetAddress{Address: address})
+		block.AddRecord(record)
+	}
+	data, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return data
+}
+
+// cat001Capture holds a Cat001 plot record followed by a track record
+var cat001Capture = []byte{
+	1, 0x00, 0x19, // CAT, LEN
+	0xF0,       // FSPEC: 010, 020, 040, 070
+	0x08, 0x01, // I001/010
+	0x30,                   // I001/020: plot, PSR+SSR
+	0x19, 0x00, 0x40, 0x00, // I001/040: 50 NM, 90°
+	0x0E, 0x00, // I001/070: 7000
+	0xF2,       // FSPEC: 010, 020, 161, 040, 070
+	0x08, 0x01, // I001/010
+	0xA0,       // I001/020: track, SSR
+	0x01, 0x01, // I001/161
+	0x19, 0x00, 0x40, 0x00, // I001/040: 50 NM, 90°
+	0x02, 0x9C, // I001/070: 1234
+}
+
+func TestPatchCapture(t *testing.T) {
+	uaps, err := allUAPs()
+	if err != nil {
+		t.Fatalf("allUAPs() error = %v", err)
+	}
+	uap := uaps[asterix.Cat021]
+
+	first := encodeCat021Block(t, uap, 0x000001, 0x000002)
+	capture := append(append([]byte(nil), first...), encodeCat021Block(t, uap, 0x000003)...)
+
+	// Record 2 is the only record of the second block
+	patched, err := patchCapture(capture, uaps, 2, "I021/080", []byte{0xAB, 0xCD, 0xEF})
+	if err != nil {
+		t.Fatalf("patchCapture() error = %v", err)
+	}
+	if len(patched) != len(capture) {
+		t.Fatalf("patchCapture() length = %d, want %d", len(patched), len(capture))
+	}
+
+	var changed []int
+	for i := range capture {
+		if capture[i] != patched[i] {
+			changed = append(changed, i)
+		}
+	}
+	// The address is the last three bytes of the capture; only the low
+	// octets differ from 0x000003
+	n := len(capture)
+	if len(changed) != 3 || changed[0] != n-3 || changed[2] != n-1 {
+		t.Fatalf("changed offsets = %v, want the final 3 bytes", changed)
+	}
+	if !bytes.Equal(patched[n-3:], []byte{0xAB, 0xCD, 0xEF}) {
+		t.Errorf("patched address = % X, want AB CD EF", patched[n-3:])
+	}
+
+	block, err := asterix.NewDataBlock(asterix.Cat021, uap)
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	if err := block.Decode(patched[len(first):]); err != nil {
+		t.Fatalf("Decode(patched block) error = %v", err)
+	}
+}
+
+func TestPatchCapture_Errors(t *testing.T) {
+	uaps, err := allUAPs()
+	if err != nil {
+		t.Fatalf("allUAPs() error = %v", err)
+	}
+	capture := encodeCat021Block(t, uaps[asterix.Cat021], 0x000001)
+
+	tests := []struct {
+		name   string
+		record int
+		item   string
+		value  []byte
+		want   error
+	}{
+		{"wrong length", 0, "I021/080", []byte{0xAB, 0xCD}, asterix.ErrInvalidLength},
+		{"item not present", 0, "I021/161", []byte{0x00, 0x07}, asterix.ErrUnknownDataItem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := patchCapture(capture, uaps, tt.record, tt.item, tt.value)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("patchCapture() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := patchCapture(capture, uaps, 1, "I021/080", []byte{0, 0, 1}); err == nil {
+		t.Errorf("patchCapture() accepted a record index beyond the capture")
+	}
+}
+
+func TestPatchCapture_Cat001(t *testing.T) {
+	uaps, err := allUAPs()
+	if err != nil {
+		t.Fatalf("allUAPs() error = %v", err)
+	}
+
+	// I001/070 of the track record follows items only its profile defines
+	patched, err := patchCapture(cat001Capture, uaps, 1, "I001/070", []byte{0x0E, 0x00})
+	if err != nil {
+		t.Fatalf("patchCapture(I001/070) error = %v", err)
+	}
+	n := len(cat001Capture)
+	if !bytes.Equal(patched[:n-2], cat001Capture[:n-2]) || !bytes.Equal(patched[n-2:], []byte{0x0E, 0x00}) {
+		t.Errorf("patchCapture(I001/070) = % X, want only the final 2 bytes changed", patched)
+	}
+
+	patched, err = patchCapture(cat001Capture, uaps, 1, "I001/010", []byte{0x09, 0x02})
+	if err != nil {
+		t.Fatalf("patchCapture(I001/010) error = %v", err)
+	}
+	if !bytes.Equal(patched[14:16], []byte{0x09, 0x02}) {
+		t.Errorf("patched I001/010 = % X, want 09 02", patched[14:16])
+	}
+}
@@ -0,0 +1,164 @@
+// validate.go
+package cmd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/davidkohl/gobelix/asterix"
+	"github.com/davidkohl/gobelix/cat/cat001"
+	"github.com/davidkohl/gobelix/cat/cat002"
+	"github.com/davidkohl/gobelix/cat/cat004"
+	"github.com/davidkohl/gobelix/cat/cat021"
+	"github.com/davidkohl/gobelix/cat/cat023"
+	"github.com/davidkohl/gobelix/cat/cat030"
+	"github.com/davidkohl/gobelix/cat/cat034"
+	"github.com/davidkohl/gobelix/cat/cat048"
+	"github.com/davidkohl/gobelix/cat/cat062"
+	"github.com/davidkohl/gobelix/cat/cat063"
+	"github.com/davidkohl/gobelix/cat/cat065"
+	"github.com/spf13/cobra"
+)
+
+var validateMaxFailures int
+
+func init() {
+	validateCmd := &cobra.Command{
+		Use:   "validate <file>",
+		Short: "Decode and validate every ASTERIX block in a file",
+		Long: `Read a file of concatenated ASTERIX blocks, such as one written by
+'idefix capture', decode and validate each block and report failures with
+their byte offset.
+Example: idefix validate fixture.bin --max 5`,
+		Args: cobra.ExactArgs(1),
+		RunE: runValidate,
+	}
+
+	validateCmd.Flags().IntVarP(&validateMaxFailures, "max", "n", 10, "Stop after reporting this many failures (0 = no limit)")
+
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	uaps, err := allUAPs()
+	if err != nil {
+		return err
+	}
+
+	result, err := validateBlocks(bufio.NewReader(f), uaps, os.Stdout, validateMaxFailures)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%d blocks checked, %d failed\n", result.blocks, result.failures)
+	if result.failures > 0 {
+		return fmt.Errorf("%d blocks failed validation", result.failures)
+	}
+	return nil
+}
+
+// allUAPs returns the UAPs of every supported category, keyed by category
+func allUAPs() (map[asterix.Category]asterix.UAP, error) {
+	constructors := []func() (asterix.UAP, error){
+		func() (asterix.UAP, error) { return cat001.NewUAP(cat001.LatestVersion()) },
+		func() (asterix.UAP, error) { return cat002.NewUAP(cat002.LatestVersion()) },
+		func() (asterix.UAP, error) { return cat004.NewUAP(cat004.LatestVersion()) },
+		func() (asterix.UAP, error) { return cat021.NewUAP(cat021.LatestVersion()) },
+		func() (asterix.UAP, error) { return cat023.NewUAP(cat023.LatestVersion()) },
+		func() (asterix.UAP, error) { return cat030.NewUAP(cat030.LatestVersion()) },
+		func() (asterix.UAP, error) { return cat034.NewUAP(cat034.LatestVersion()) },
+		func() (asterix.UAP, error) { return cat048.NewUAP(cat048.LatestVersion()) },
+		func() (asterix.UAP, error) { return cat062.NewUAP(cat062.Version117) },
+		func() (asterix.UAP, error) { return cat063.NewUAP(cat063.LatestVersion()) },
+		func() (asterix.UAP, error) { return cat065.NewUAP(cat065.LatestVersion()) },
+	}
+
+	uaps := make(map[asterix.Category]asterix.UAP, len(constructors))
+	for _, newUAP := range constructors {
+		uap, err := newUAP()
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize UAP: %w", err)
+		}
+		uaps[uap.Category()] = uap
+	}
+	return uaps, nil
+}
+
+type validateResult struct {
+	blocks   int
+	failures int
+}
+
+// validateBlocks decodes and validates each block read from r, reporting up
+// to maxFailures failures to out. Framing errors that make it impossible to
+// find the next block end the scan.
+func validateBlocks(r io.Reader, uaps map[asterix.Category]asterix.UAP, out io.Writer, maxFailures int) (validateResult, error) {
+	var (
+		result validateResult
+		offset int64
+		header [3]byte
+	)
+
+	report := func(cat asterix.Category, err error) bool {
+		result.failures++
+		fmt.Fprintf(out, "offset %d: %s: %v\n", offset, cat, err)
+		return maxFailures > 0 && result.failures >= maxFailures
+	}
+
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return result, nil
+			}
+			return result, fmt.Errorf("offset %d: reading block header: %w", offset, err)
+		}
+
+		cat := asterix.Category(header[0])
+		length := int(binary.BigEndian.Uint16(header[1:3]))
+		if length < 3 {
+			report(cat, fmt.Errorf("invalid block length %d", length))
+			return result, nil
+		}
+
+		data := make([]byte, length)
+		copy(data, header[:])
+		if _, err := io.ReadFull(r, data[3:]); err != nil {
+			report(cat, fmt.Errorf("block truncated: %w", err))
+			return result, nil
+		}
+		result.blocks++
+
+		if err := validateBlock(cat, data, uaps); err != nil {
+			if report(cat, err) {
+				return result, nil
+			}
+		}
+		offset += int64(length)
+	}
+}
+
+func validateBlock(cat asterix.Category, data []byte, uaps map[asterix.Category]asterix.UAP) error {
+	uap, ok := uaps[cat]
+	if !ok {
+		return fmt.Errorf("%w: %d", asterix.ErrUnknownCategory, cat)
+	}
+
+	block, err := asterix.NewDataBlock(cat, uap)
+	if err != nil {
+		return err
+	}
+	if err := block.Decode(data); err != nil {
+		return err
+	}
+	return block.Validate()
+}
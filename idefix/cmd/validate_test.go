@@ -0,0 +1,63 @@
+// validate_test.go
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/davidkohl/gobelix/asterix"
+	v26 "github.com/davidkohl/gobelix/cat/cat021/dataitems/v26"
+	common "github.com/davidkohl/gobelix/cat/common/dataitems"
+)
+
+func TestValidateBlocks(t *testing.T) {
+	uaps, err := allUAPs()
+	if err != nil {
+		t.Fatalf("allUAPs() error = %v", err)
+	}
+
+	record, err := asterix.NewRecord(asterix.Cat021, uaps[asterix.Cat021])
+	if err != nil {
+		t.Fatalf("NewRecord() error = %v", err)
+	}
+	record.SetDataItem("I021/010", &common.DataSourceIdentifier{SAC: 25, SIC: 100})
+	record.SetDataItem("I021/040", &v26.TargetReportDescriptor{ATP: 1})
+	record.SetDataItem("I021/080", &v26.TargetAddress{Address: 0xABCDEF})
+
+	block, err := asterix.NewDataBlock(asterix.Cat021, uaps[asterix.Cat021])
+	if err != nil {
+		t.Fatalf("NewDataBlock() error = %v", err)
+	}
+	block.AddRecord(record)
+	valid, err := block.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	// A record carrying only I021/040 lacks the mandatory data source
+	invalid := []byte{21, 0x00, 0x05, 0x40, 0x20}
+
+	var fixture bytes.Buffer
+	fixture.Write(valid)
+	fixture.Write(invalid)
+	fixture.Write(valid)
+
+	var out bytes.Buffer
+	result, err := validateBlocks(&fixture, uaps, &out, 10)
+	if err != nil {
+		t.Fatalf("validateBlocks() error = %v", err)
+	}
+	if result.blocks != 3 || result.failures != 1 {
+		t.Errorf("validateBlocks() = %+v, want 3 blocks with 1 failure", result)
+	}
+
+	report := out.String()
+	if want := fmt.Sprintf("offset %d: CAT021:", len(valid)); !strings.HasPrefix(report, want) {
+		t.Errorf("report = %q, want prefix %q", report, want)
+	}
+	if strings.Count(report, "\n") != 1 {
+		t.Errorf("report = %q, want exactly one failure line", report)
+	}
+}
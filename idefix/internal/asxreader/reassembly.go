@@ -0,0 +1,68 @@
+// reassembly.go
+package asxreader
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+// DefaultReassemblyTimeout is how long a partially received block is kept
+// waiting for the datagram carrying its remainder
+const DefaultReassemblyTimeout = time.Second
+
+// reassembler rebuilds ASTERIX blocks that a sender split across several
+// datagrams. Datagrams are concatenated and cut into blocks using the length
+// field of each block header; a trailing partial block is held until the
+// next datagram completes it or the timeout discards it.
+type reassembler struct {
+	pending  []byte
+	deadline time.Time
+	timeout  time.Duration
+	now      func() time.Time
+}
+
+func newReassembler(timeout time.Duration) *reassembler {
+	if timeout <= 0 {
+		timeout = DefaultReassemblyTimeout
+	}
+	return &reassembler{timeout: timeout, now: time.Now}
+}
+
+// push adds a datagram and returns the blocks it completes, in order. The
+// returned slices are copies and stay valid after the next call. A header
+// with a reserved category or a length below 3 cannot be resynchronised
+// from, so the buffered data is discarded and an error returned along with
+// the blocks completed before it.
+func (r *reassembler) push(datagram []byte) ([][]byte, error) {
+	now := r.now()
+	if len(r.pending) > 0 && now.After(r.deadline) {
+		r.pending = r.pending[:0]
+	}
+	r.pending = append(r.pending, datagram...)
+
+	var blocks [][]byte
+	for len(r.pending) >= 3 {
+		if cat := asterix.Category(r.pending[0]); cat.IsReserved() {
+			r.pending = r.pending[:0]
+			return blocks, fmt.Errorf("%w: %d", asterix.ErrReservedCategory, cat)
+		}
+		length := int(binary.BigEndian.Uint16(r.pending[1:3]))
+		if length < 3 {
+			r.pending = r.pending[:0]
+			return blocks, fmt.Errorf("%w: invalid block length %d", asterix.ErrInvalidLength, length)
+		}
+		if length > len(r.pending) {
+			break
+		}
+		blocks = append(blocks, append([]byte(nil), r.pending[:length]...))
+		r.pending = r.pending[:copy(r.pending, r.pending[length:])]
+	}
+
+	if len(r.pending) > 0 {
+		r.deadline = now.Add(r.timeout)
+	}
+	return blocks, nil
+}
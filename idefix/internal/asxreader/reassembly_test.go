@@ -0,0 +1,105 @@
+// reassembly_test.go
+package asxreader
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidkohl/gobelix/asterix"
+)
+
+// testBlock returns a Cat062 block of the given total length
+func testBlock(length int) []byte {
+	block := make([]byte, length)
+	block[0] = 62
+	block[1] = byte(length >> 8)
+	block[2] = byte(length)
+	for i := 3; i < length; i++ {
+		block[i] = byte(i)
+	}
+	return block
+}
+
+func TestReassembler_SplitBlock(t *testing.T) {
+	r := newReassembler(time.Second)
+	block := testBlock(2000)
+
+	blocks, err := r.push(block[:1400])
+	if err != nil || len(blocks) != 0 {
+		t.Fatalf("push(first part) = %d blocks, %v, want none", len(blocks), err)
+	}
+	blocks, err = r.push(block[1400:])
+	if err != nil {
+		t.Fatalf("push(second part) error = %v", err)
+	}
+	if len(blocks) != 1 || !bytes.Equal(blocks[0], block) {
+		t.Fatalf("push(second part) = %d blocks, want the reassembled block", len(blocks))
+	}
+}
+
+func TestReassembler_SeveralBlocksPerDatagram(t *testing.T) {
+	r := newReassembler(time.Second)
+	a, b, c := testBlock(10), testBlock(20), testBlock(30)
+
+	// The datagram carries a and b whole and the start of c
+	datagram := append(append(append([]byte(nil), a...), b...), c[:5]...)
+	blocks, err := r.push(datagram)
+	if err != nil {
+		t.Fatalf("push() error = %v", err)
+	}
+	if len(blocks) != 2 || !bytes.Equal(blocks[0], a) || !bytes.Equal(blocks[1], b) {
+		t.Fatalf("push() = %d blocks, want a and b", len(blocks))
+	}
+
+	blocks, err = r.push(c[5:])
+	if err != nil || len(blocks) != 1 || !bytes.Equal(blocks[0], c) {
+		t.Fatalf("push(rest of c) = %d blocks, %v, want c", len(blocks), err)
+	}
+}
+
+func TestReassembler_DiscardsStalePartial(t *testing.T) {
+	now := time.Unix(0, 0)
+	r := newReassembler(time.Second)
+	r.now = func() time.Time { return now }
+
+	stale := testBlock(100)
+	if blocks, _ := r.push(stale[:50]); len(blocks) != 0 {
+		t.Fatalf("push(partial) returned %d blocks", len(blocks))
+	}
+
+	// The remainder never arrives; a fresh block after the timeout decodes
+	now = now.Add(2 * time.Second)
+	fresh := testBlock(10)
+	blocks, err := r.push(fresh)
+	if err != nil || len(blocks) != 1 || !bytes.Equal(blocks[0], fresh) {
+		t.Fatalf("push(fresh) = %d blocks, %v, want the fresh block", len(blocks), err)
+	}
+}
+
+func TestReassembler_InvalidLength(t *testing.T) {
+	r := newReassembler(time.Second)
+	if _, err := r.push([]byte{62, 0x00, 0x02, 0xFF}); !errors.Is(err, asterix.ErrInvalidLength) {
+		t.Fatalf("push() error = %v, want %v", err, asterix.ErrInvalidLength)
+	}
+
+	// The reassembler recovers on the next datagram
+	block := testBlock(10)
+	if blocks, err := r.push(block); err != nil || len(blocks) != 1 {
+		t.Fatalf("push() after invalid length = %d blocks, %v", len(blocks), err)
+	}
+}
+
+func TestReassembler_ReservedCategory(t *testing.T) {
+	r := newReassembler(time.Second)
+
+	// A zero category byte with a plausible length is not a block start
+	blocks, err := r.push(append(testBlock(10), 0x00, 0x00, 0x05, 0x01, 0x02))
+	if !errors.Is(err, asterix.ErrReservedCategory) {
+		t.Fatalf("push() error = %v, want %v", err, asterix.ErrReservedCategory)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("push() = %d blocks, want the block before the reserved byte", len(blocks))
+	}
+}
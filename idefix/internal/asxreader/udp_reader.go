@@ -11,10 +11,12 @@ import (
 
 // udpAsterixReader implements AsterixReader for UDP connections
 type udpAsterixReader struct {
-	conn      net.PacketConn
-	buf       []byte
-	decoder   *asterix.Decoder
-	lastError error
+	conn       net.PacketConn
+	buf        []byte
+	decoder    *asterix.Decoder
+	reassembly *reassembler
+	blocks     [][]byte // Complete blocks not yet decoded
+	lastError  error
 }
 
 // NewUDPAsterixReader creates a reader for UDP ASTERIX messages
@@ -26,25 +28,35 @@ func NewUDPAsterixReader(port int, decoder *asterix.Decoder) (AsterixReader, err
 	}
 
 	return &udpAsterixReader{
-		conn:    conn,
-		buf:     make([]byte, 65536), // Max UDP packet size
-		decoder: decoder,
+		conn:       conn,
+		buf:        make([]byte, 65536), // Max UDP packet size
+		decoder:    decoder,
+		reassembly: newReassembler(DefaultReassemblyTimeout),
 	}, nil
 }
 
-// Next reads and decodes the next ASTERIX message from UDP
+// Next reads and decodes the next ASTERIX message from UDP. A datagram may
+// carry several blocks, and a block may be split across datagrams; blocks
+// are decoded one per call once fully received.
 func (r *udpAsterixReader) Next() (*asterix.AsterixMessage, error) {
-	n, _, err := r.conn.ReadFrom(r.buf)
-	if err != nil {
-		r.lastError = err
-		return nil, fmt.Errorf("reading UDP packet: %w", err)
+	for len(r.blocks) == 0 {
+		n, _, err := r.conn.ReadFrom(r.buf)
+		if err != nil {
+			r.lastError = err
+			return nil, fmt.Errorf("reading UDP packet: %w", err)
+		}
+
+		r.blocks, err = r.reassembly.push(r.buf[:n])
+		if err != nil && len(r.blocks) == 0 {
+			return nil, fmt.Errorf("reassembling ASTERIX block: %w", err)
+		}
 	}
 
-	// Create a reader from the UDP packet data
-	packetReader := bytes.NewReader(r.buf[:n])
+	block := r.blocks[0]
+	r.blocks = r.blocks[1:]
 
 	// Decode the message
-	msg, err := r.decoder.Decode(packetReader)
+	msg, err := r.decoder.Decode(bytes.NewReader(block))
 	if err != nil {
 		return nil, fmt.Errorf("decoding ASTERIX message: %w", err)
 	}